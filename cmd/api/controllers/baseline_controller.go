@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"net/http"
+)
+
+// BaselineController maneja las solicitudes HTTP de las líneas base de comportamiento.
+type BaselineController struct {
+	service services.BaselineService
+}
+
+// NewBaselineController crea una nueva instancia de BaselineController.
+func NewBaselineController(service services.BaselineService) *BaselineController {
+	return &BaselineController{
+		service: service,
+	}
+}
+
+// GetBaseline devuelve lo que es "normal" para un principal: los valores observados en cada
+// dimensión dentro de la ventana, con su primera y última observación.
+func (bc *BaselineController) GetBaseline(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	profile, err := bc.service.GetBaseline(r.Context(), query.Get("principal"), query.Get("dimension"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBaselineQuery) {
+			utils.ErrorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+		utils.ErrorJSON(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Línea base obtenida",
+		Data:    profile,
+	})
+}
@@ -67,6 +67,64 @@ func (ec *EnrichmentController) QueryEvents(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		logger.ErrorLog.Printf("Parámetros de búsqueda inválidos: %v", err)
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	projection, err := parseEventProjection(r)
+	if err != nil {
+		logger.ErrorLog.Printf("Parámetros de proyección inválidos: %v", err)
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	// Con fields o compact la respuesta se arma con documentos parciales en lugar del modelo completo
+	if !projection.IsEmpty() {
+		documents, err := ec.service.QueryEventsProjected(r.Context(), filter, projection)
+		if err != nil {
+			logger.ErrorLog.Printf("Error en el controlador al buscar eventos con proyección: %v", err)
+			if errors.Is(err, services.ErrInvalidProjection) {
+				utils.ErrorJSON(w, err, http.StatusBadRequest)
+				return
+			}
+			utils.ErrorJSON(w, fmt.Errorf("error al buscar eventos: %w", err), http.StatusInternalServerError)
+			return
+		}
+
+		payload := utils.JSONResponse{
+			Error:   false,
+			Message: fmt.Sprintf("%d eventos enriquecidos encontrados", len(documents)),
+			Data:    documents,
+		}
+		if err := utils.WriteJSON(w, http.StatusOK, payload); err != nil {
+			logger.ErrorLog.Println("Error al escribir la respuesta JSON:", err)
+		}
+		return
+	}
+
+	// Si se reciben filtros se realiza la búsqueda; si no, se mantienen los últimos 10 logs
+	if !filter.IsEmpty() {
+		records, err := ec.service.QueryEvents(r.Context(), filter)
+		if err != nil {
+			logger.ErrorLog.Printf("Error en el controlador al buscar eventos: %v", err)
+			utils.ErrorJSON(w, fmt.Errorf("error al buscar eventos: %w", err), http.StatusInternalServerError)
+			return
+		}
+
+		payload := utils.JSONResponse{
+			Error:   false,
+			Message: fmt.Sprintf("%d eventos enriquecidos encontrados", len(records)),
+			Data:    records,
+		}
+		if err := utils.WriteJSON(w, http.StatusOK, payload); err != nil {
+			logger.ErrorLog.Println("Error al escribir la respuesta JSON:", err)
+		}
+		return
+	}
+
 	// Llamar al servicio para obtener los últimos 10 logs
 	records, err := ec.service.Top10QueryEvents(r.Context())
 	if err != nil {
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/middleware"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
+	"cloudtrail-enrichment-api-golang/models"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+// FindingController maneja las solicitudes HTTP de consulta y triage de detecciones.
+type FindingController struct {
+	service services.FindingService
+}
+
+// NewFindingController crea una nueva instancia de FindingController.
+func NewFindingController(service services.FindingService) *FindingController {
+	return &FindingController{
+		service: service,
+	}
+}
+
+// findingErrorResponse traduce los errores del servicio al código de estado HTTP correspondiente.
+func findingErrorResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrFindingNotFound):
+		utils.ErrorJSON(w, err, http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidFinding):
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+	case errors.Is(err, services.ErrFindingConflict):
+		utils.ErrorJSON(w, err, http.StatusConflict)
+	default:
+		utils.ErrorJSON(w, err, http.StatusInternalServerError)
+	}
+}
+
+// findingActor obtiene el usuario autenticado a partir de los claims del JWT.
+func findingActor(r *http.Request) (models.FindingActor, bool) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		return models.FindingActor{}, false
+	}
+	return models.FindingActor{UserID: userClaims.ID, Email: userClaims.Email}, true
+}
+
+// ListFindings lista las detecciones filtrando por rule_id, severity, status, assignee y rango de fechas.
+func (fc *FindingController) ListFindings(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFindingFilter(r)
+	if err != nil {
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	findings, err := fc.service.ListFindings(r.Context(), filter)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al listar detecciones: %v", err)
+		findingErrorResponse(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("%d detecciones obtenidas", len(findings)),
+		Data:    findings,
+	})
+}
+
+// GetFinding devuelve una detección con sus comentarios e historial.
+func (fc *FindingController) GetFinding(w http.ResponseWriter, r *http.Request) {
+	finding, err := fc.service.GetFinding(r.Context(), chi.URLParam(r, "findingID"))
+	if err != nil {
+		findingErrorResponse(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Detección obtenida",
+		Data:    finding,
+	})
+}
+
+// UpdateFindingStatus cambia el estado de triage de una detección.
+func (fc *FindingController) UpdateFindingStatus(w http.ResponseWriter, r *http.Request) {
+	actor, ok := findingActor(r)
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	var payload models.FindingStatusPayload
+	if err := utils.ReadJSON(w, r, &payload); err != nil {
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	finding, err := fc.service.UpdateFindingStatus(r.Context(), actor, chi.URLParam(r, "findingID"), &payload)
+	if err != nil {
+		findingErrorResponse(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Estado de la detección actualizado",
+		Data:    finding,
+	})
+}
+
+// AssignFinding asigna una detección a un analista.
+func (fc *FindingController) AssignFinding(w http.ResponseWriter, r *http.Request) {
+	actor, ok := findingActor(r)
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	var payload models.FindingAssignPayload
+	if err := utils.ReadJSON(w, r, &payload); err != nil {
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	finding, err := fc.service.AssignFinding(r.Context(), actor, chi.URLParam(r, "findingID"), &payload)
+	if err != nil {
+		findingErrorResponse(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Asignación de la detección actualizada",
+		Data:    finding,
+	})
+}
+
+// CommentFinding agrega un comentario del usuario autenticado a una detección.
+func (fc *FindingController) CommentFinding(w http.ResponseWriter, r *http.Request) {
+	actor, ok := findingActor(r)
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	var payload models.FindingCommentPayload
+	if err := utils.ReadJSON(w, r, &payload); err != nil {
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	finding, err := fc.service.CommentFinding(r.Context(), actor, chi.URLParam(r, "findingID"), &payload)
+	if err != nil {
+		findingErrorResponse(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, utils.JSONResponse{
+		Error:   false,
+		Message: "Comentario agregado a la detección",
+		Data:    finding,
+	})
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/graph"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// GraphQLController expone el esquema GraphQL de los eventos enriquecidos.
+type GraphQLController struct {
+	schema graphql.Schema
+	limits graph.Limits
+}
+
+// NewGraphQLController crea una nueva instancia de GraphQLController.
+func NewGraphQLController(schema graphql.Schema, limits graph.Limits) *GraphQLController {
+	return &GraphQLController{
+		schema: schema,
+		limits: limits,
+	}
+}
+
+// graphQLRequest es el cuerpo estándar de una petición GraphQL sobre HTTP.
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// graphQLError responde con el formato de errores de GraphQL ({"errors": [...]}).
+func graphQLError(w http.ResponseWriter, status int, err error) {
+	utils.WriteJSON(w, status, &graphql.Result{
+		Errors: []gqlerrors.FormattedError{{Message: err.Error()}},
+	})
+}
+
+// ServeGraphQL ejecuta consultas GraphQL recibidas por POST (JSON) o GET (parámetros de la URL).
+func (gc *GraphQLController) ServeGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if raw := q.Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				graphQLError(w, http.StatusBadRequest, errors.New("parámetro 'variables' inválido"))
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := utils.ReadJSON(w, r, &req); err != nil {
+			logger.ErrorLog.Printf("Error al decodificar la petición GraphQL: %v", err)
+			graphQLError(w, http.StatusBadRequest, err)
+			return
+		}
+	default:
+		graphQLError(w, http.StatusMethodNotAllowed, errors.New("método no permitido"))
+		return
+	}
+
+	if req.Query == "" {
+		graphQLError(w, http.StatusBadRequest, errors.New("la consulta GraphQL es requerida"))
+		return
+	}
+
+	if err := graph.CheckLimits(req.Query, req.Variables, gc.limits); err != nil {
+		logger.ErrorLog.Printf("Consulta GraphQL rechazada: %v", err)
+		graphQLError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         gc.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        r.Context(),
+	})
+	if result.HasErrors() {
+		logger.ErrorLog.Printf("Errores al ejecutar la consulta GraphQL: %v", result.Errors)
+	}
+
+	if err := utils.WriteJSON(w, http.StatusOK, result); err != nil {
+		logger.ErrorLog.Println("Error al escribir la respuesta GraphQL:", err)
+	}
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
+	"cloudtrail-enrichment-api-golang/models"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// InventoryController maneja las solicitudes HTTP del inventario de cuentas y recursos. Las
+// claves (números de cuenta y ARN) se reciben como parámetros de la URL porque los ARN
+// contienen "/".
+type InventoryController struct {
+	service services.InventoryService
+}
+
+// NewInventoryController crea una nueva instancia de InventoryController.
+func NewInventoryController(service services.InventoryService) *InventoryController {
+	return &InventoryController{
+		service: service,
+	}
+}
+
+// inventoryError traduce los errores del servicio al código de estado HTTP correspondiente.
+func inventoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInventoryItemNotFound):
+		utils.ErrorJSON(w, err, http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidInventoryItem):
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+	default:
+		utils.ErrorJSON(w, err, http.StatusInternalServerError)
+	}
+}
+
+// ListInventory lista el inventario. Con kind y key devuelve un único elemento; con solo kind,
+// las cuentas o los recursos.
+func (ic *InventoryController) ListInventory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if key := query.Get("key"); key != "" {
+		item, err := ic.service.GetInventoryItem(r.Context(), query.Get("kind"), key)
+		if err != nil {
+			inventoryError(w, err)
+			return
+		}
+		utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+			Error:   false,
+			Message: "Elemento de inventario obtenido",
+			Data:    item,
+		})
+		return
+	}
+
+	items, err := ic.service.ListInventory(r.Context(), query.Get("kind"))
+	if err != nil {
+		logger.ErrorLog.Printf("Error al listar el inventario: %v", err)
+		inventoryError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("%d elementos de inventario obtenidos", len(items)),
+		Data:    items,
+	})
+}
+
+// UpsertInventoryItem crea o reemplaza un elemento del inventario.
+func (ic *InventoryController) UpsertInventoryItem(w http.ResponseWriter, r *http.Request) {
+	var payload models.InventoryPayload
+	if err := utils.ReadJSON(w, r, &payload); err != nil {
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	item, err := ic.service.UpsertInventoryItem(r.Context(), &payload)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al guardar elemento de inventario: %v", err)
+		inventoryError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Elemento de inventario guardado",
+		Data:    item,
+	})
+}
+
+// DeleteInventoryItem elimina el elemento indicado por los parámetros kind y key.
+func (ic *InventoryController) DeleteInventoryItem(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if err := ic.service.DeleteInventoryItem(r.Context(), query.Get("kind"), query.Get("key")); err != nil {
+		inventoryError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Elemento de inventario eliminado",
+	})
+}
+
+// ImportInventory vuelve a importar los archivos de inventario configurados.
+func (ic *InventoryController) ImportInventory(w http.ResponseWriter, r *http.Request) {
+	count, err := ic.service.ImportInventoryFiles(r.Context())
+	if err != nil {
+		logger.ErrorLog.Printf("Error al importar el inventario: %v", err)
+		inventoryError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("%d elementos de inventario importados", count),
+	})
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
+	"cloudtrail-enrichment-api-golang/models"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+// NotificationController maneja las solicitudes HTTP del envío de notificaciones.
+type NotificationController struct {
+	service services.NotificationService
+}
+
+// NewNotificationController crea una nueva instancia de NotificationController.
+func NewNotificationController(service services.NotificationService) *NotificationController {
+	return &NotificationController{
+		service: service,
+	}
+}
+
+// ListDeliveries devuelve el registro de entregas filtrando por finding_id, channel y status.
+func (nc *NotificationController) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseDeliveryFilter(r)
+	if err != nil {
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := nc.service.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDeliveryFilter) {
+			utils.ErrorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+		logger.ErrorLog.Printf("Error al listar entregas de notificaciones: %v", err)
+		utils.ErrorJSON(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("%d entregas obtenidas", len(deliveries)),
+		Data:    deliveries,
+	})
+}
+
+// SendTestNotification envía una detección de prueba al canal indicado y devuelve el resultado de la entrega.
+func (nc *NotificationController) SendTestNotification(w http.ResponseWriter, r *http.Request) {
+	delivery, err := nc.service.SendTest(r.Context(), chi.URLParam(r, "channel"))
+	if err != nil {
+		if errors.Is(err, services.ErrChannelNotFound) {
+			utils.ErrorJSON(w, err, http.StatusNotFound)
+			return
+		}
+		utils.ErrorJSON(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	message := "Notificación de prueba entregada"
+	if delivery.Status != models.DeliveryStatusDelivered {
+		status = http.StatusBadGateway
+		message = "La notificación de prueba no pudo entregarse"
+	}
+	utils.WriteJSON(w, status, utils.JSONResponse{
+		Error:   status != http.StatusOK,
+		Message: message,
+		Data:    delivery,
+	})
+}
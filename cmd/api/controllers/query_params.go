@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseEventFilter construye un models.EventFilter a partir de los parámetros de la URL.
+// Las fechas se esperan en formato RFC3339.
+func parseEventFilter(r *http.Request) (*models.EventFilter, error) {
+	q := r.URL.Query()
+
+	filter := &models.EventFilter{
+		EventName:          q.Get("eventName"),
+		EventSource:        q.Get("eventSource"),
+		AwsRegion:          q.Get("awsRegion"),
+		SourceIPAddress:    q.Get("sourceIPAddress"),
+		UserName:           q.Get("userName"),
+		AccountID:          q.Get("accountId"),
+		Actor:              q.Get("actor"),
+		IdentityAccount:    q.Get("identityAccount"),
+		IdentityRole:       q.Get("identityRole"),
+		SessionName:        q.Get("sessionName"),
+		PrincipalType:      q.Get("principalType"),
+		AccountName:        q.Get("accountName"),
+		Environment:        q.Get("environment"),
+		Team:               q.Get("team"),
+		ActionCategory:     q.Get("actionCategory"),
+		ResourceARN:        q.Get("resourceArn"),
+		ResourceType:       q.Get("resourceType"),
+		ResourceID:         q.Get("resourceId"),
+		DataClassification: q.Get("dataClassification"),
+		Country:            q.Get("country"),
+		ASN:                q.Get("asn"),
+		ASOrg:              q.Get("asOrg"),
+		UATool:             q.Get("uaTool"),
+		UACategory:         q.Get("uaCategory"),
+		UASDK:              q.Get("uaSDK"),
+		NetworkType:        q.Get("networkType"),
+		Site:               q.Get("site"),
+		Hostname:           q.Get("hostname"),
+		SortBy:             q.Get("sortBy"),
+	}
+	if filter.SortBy != "" && !models.EventSortFields[filter.SortBy] {
+		return nil, fmt.Errorf("parámetro 'sortBy' inválido: se admite eventTime o risk")
+	}
+	if filter.ASN != "" {
+		if filter.ASN = models.NormalizeASN(filter.ASN); filter.ASN == "" {
+			return nil, fmt.Errorf("parámetro 'asn' inválido: se espera p. ej. AS16509 o 16509")
+		}
+	}
+	if filter.PrincipalType != "" && !models.PrincipalTypes[filter.PrincipalType] {
+		return nil, fmt.Errorf("parámetro 'principalType' inválido: se admite root, user, role, assumed-role, federated-user, service, anonymous o unknown")
+	}
+	if filter.ActionCategory != "" && !models.ActionCategories[filter.ActionCategory] {
+		return nil, fmt.Errorf("parámetro 'actionCategory' inválido: se admite read, write, delete, permission-change o data-access")
+	}
+	if filter.UACategory != "" && !models.UserAgentCategories[filter.UACategory] {
+		return nil, fmt.Errorf("parámetro 'uaCategory' inválido: se admite console, cli, sdk, iac, aws-internal o unknown")
+	}
+	if filter.NetworkType != "" && !models.NetworkTypes[filter.NetworkType] {
+		return nil, fmt.Errorf("parámetro 'networkType' inválido: se admite public, private, reserved o vpc-endpoint")
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(q.Get("from")); err != nil {
+		return nil, fmt.Errorf("parámetro 'from' inválido: %w", err)
+	}
+	if filter.To, err = parseTimeParam(q.Get("to")); err != nil {
+		return nil, fmt.Errorf("parámetro 'to' inválido: %w", err)
+	}
+	if filter.Limit, err = parseIntParam(q.Get("limit")); err != nil {
+		return nil, fmt.Errorf("parámetro 'limit' inválido: %w", err)
+	}
+	if filter.Skip, err = parseIntParam(q.Get("skip")); err != nil {
+		return nil, fmt.Errorf("parámetro 'skip' inválido: %w", err)
+	}
+	if filter.MinRisk, err = parseIntParam(q.Get("minRisk")); err != nil {
+		return nil, fmt.Errorf("parámetro 'minRisk' inválido: %w", err)
+	}
+	if filter.IsRoot, err = parseBoolParam(q.Get("isRoot")); err != nil {
+		return nil, fmt.Errorf("parámetro 'isRoot' inválido: %w", err)
+	}
+	if filter.ReadOnly, err = parseBoolParam(q.Get("readOnly")); err != nil {
+		return nil, fmt.Errorf("parámetro 'readOnly' inválido: %w", err)
+	}
+	if filter.Sensitive, err = parseBoolParam(q.Get("sensitive")); err != nil {
+		return nil, fmt.Errorf("parámetro 'sensitive' inválido: %w", err)
+	}
+	if filter.IsTor, err = parseBoolParam(q.Get("isTor")); err != nil {
+		return nil, fmt.Errorf("parámetro 'isTor' inválido: %w", err)
+	}
+	if filter.IsVPN, err = parseBoolParam(q.Get("isVPN")); err != nil {
+		return nil, fmt.Errorf("parámetro 'isVPN' inválido: %w", err)
+	}
+	if filter.IsHosting, err = parseBoolParam(q.Get("isHosting")); err != nil {
+		return nil, fmt.Errorf("parámetro 'isHosting' inválido: %w", err)
+	}
+
+	return filter, nil
+}
+
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func parseIntParam(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("el valor no puede ser negativo")
+	}
+	return n, nil
+}
+
+func parseBoolParam(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// parseEventProjection construye la proyección a partir de los parámetros "fields"
+// (lista separada por comas) y "compact".
+func parseEventProjection(r *http.Request) (*models.EventProjection, error) {
+	q := r.URL.Query()
+	projection := &models.EventProjection{}
+
+	if raw := q.Get("fields"); raw != "" {
+		for _, field := range strings.Split(raw, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				projection.Fields = append(projection.Fields, field)
+			}
+		}
+	}
+
+	if raw := q.Get("compact"); raw != "" {
+		compact, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parámetro 'compact' inválido: %w", err)
+		}
+		projection.Compact = compact
+	}
+
+	return projection, nil
+}
+
+// parseFindingFilter construye un models.FindingFilter a partir de los parámetros de la URL.
+// Las fechas se esperan en formato RFC3339 y se aplican sobre created_at.
+func parseFindingFilter(r *http.Request) (*models.FindingFilter, error) {
+	q := r.URL.Query()
+
+	filter := &models.FindingFilter{
+		RuleID:   q.Get("rule_id"),
+		Severity: strings.ToLower(q.Get("severity")),
+		Status:   strings.ToLower(q.Get("status")),
+		Assignee: q.Get("assignee"),
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(q.Get("from")); err != nil {
+		return nil, fmt.Errorf("parámetro 'from' inválido: %w", err)
+	}
+	if filter.To, err = parseTimeParam(q.Get("to")); err != nil {
+		return nil, fmt.Errorf("parámetro 'to' inválido: %w", err)
+	}
+	if filter.Limit, err = parseIntParam(q.Get("limit")); err != nil {
+		return nil, fmt.Errorf("parámetro 'limit' inválido: %w", err)
+	}
+	if filter.Skip, err = parseIntParam(q.Get("skip")); err != nil {
+		return nil, fmt.Errorf("parámetro 'skip' inválido: %w", err)
+	}
+
+	return filter, nil
+}
+
+// parseDeliveryFilter construye un models.DeliveryFilter a partir de los parámetros de la URL.
+func parseDeliveryFilter(r *http.Request) (*models.DeliveryFilter, error) {
+	q := r.URL.Query()
+
+	filter := &models.DeliveryFilter{
+		FindingID: q.Get("finding_id"),
+		Channel:   q.Get("channel"),
+		Status:    strings.ToLower(q.Get("status")),
+	}
+
+	var err error
+	if filter.Limit, err = parseIntParam(q.Get("limit")); err != nil {
+		return nil, fmt.Errorf("parámetro 'limit' inválido: %w", err)
+	}
+	if filter.Skip, err = parseIntParam(q.Get("skip")); err != nil {
+		return nil, fmt.Errorf("parámetro 'skip' inválido: %w", err)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/middleware"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
+	"cloudtrail-enrichment-api-golang/models"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+)
+
+// SavedSearchController maneja las solicitudes HTTP de las búsquedas guardadas.
+type SavedSearchController struct {
+	service services.SavedSearchService
+}
+
+// NewSavedSearchController crea una nueva instancia de SavedSearchController.
+func NewSavedSearchController(service services.SavedSearchService) *SavedSearchController {
+	return &SavedSearchController{
+		service: service,
+	}
+}
+
+// savedSearchError traduce los errores del servicio al código de estado HTTP correspondiente.
+func savedSearchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrSavedSearchNotFound):
+		utils.ErrorJSON(w, err, http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidSavedSearch):
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+	default:
+		utils.ErrorJSON(w, err, http.StatusInternalServerError)
+	}
+}
+
+// CreateSavedSearch crea una búsqueda guardada para el usuario autenticado.
+func (sc *SavedSearchController) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	var payload models.SavedSearchPayload
+	if err := utils.ReadJSON(w, r, &payload); err != nil {
+		logger.ErrorLog.Printf("Error al decodificar payload de búsqueda guardada: %v", err)
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	search, err := sc.service.CreateSavedSearch(r.Context(), userClaims.ID, &payload)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear búsqueda guardada: %v", err)
+		savedSearchError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, utils.JSONResponse{
+		Error:   false,
+		Message: "Búsqueda guardada creada exitosamente",
+		Data:    search,
+	})
+}
+
+// ListSavedSearches lista las búsquedas guardadas del usuario autenticado.
+func (sc *SavedSearchController) ListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	searches, err := sc.service.ListSavedSearches(r.Context(), userClaims.ID)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al listar búsquedas guardadas: %v", err)
+		savedSearchError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("%d búsquedas guardadas obtenidas", len(searches)),
+		Data:    searches,
+	})
+}
+
+// GetSavedSearch devuelve una búsqueda guardada del usuario autenticado.
+func (sc *SavedSearchController) GetSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	search, err := sc.service.GetSavedSearch(r.Context(), userClaims.ID, chi.URLParam(r, "searchUUID"))
+	if err != nil {
+		logger.ErrorLog.Printf("Error al obtener búsqueda guardada: %v", err)
+		savedSearchError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Búsqueda guardada obtenida",
+		Data:    search,
+	})
+}
+
+// UpdateSavedSearch reemplaza los datos de una búsqueda guardada del usuario autenticado.
+func (sc *SavedSearchController) UpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	var payload models.SavedSearchPayload
+	if err := utils.ReadJSON(w, r, &payload); err != nil {
+		logger.ErrorLog.Printf("Error al decodificar payload de búsqueda guardada: %v", err)
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	search, err := sc.service.UpdateSavedSearch(r.Context(), userClaims.ID, chi.URLParam(r, "searchUUID"), &payload)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al actualizar búsqueda guardada: %v", err)
+		savedSearchError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Búsqueda guardada actualizada exitosamente",
+		Data:    search,
+	})
+}
+
+// DeleteSavedSearch elimina una búsqueda guardada del usuario autenticado.
+func (sc *SavedSearchController) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	if err := sc.service.DeleteSavedSearch(r.Context(), userClaims.ID, chi.URLParam(r, "searchUUID")); err != nil {
+		logger.ErrorLog.Printf("Error al eliminar búsqueda guardada: %v", err)
+		savedSearchError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Búsqueda guardada eliminada exitosamente",
+	})
+}
+
+// RunSavedSearch ejecuta inmediatamente una búsqueda guardada y devuelve la instantánea generada.
+func (sc *SavedSearchController) RunSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	result, err := sc.service.RunSavedSearch(r.Context(), userClaims.ID, chi.URLParam(r, "searchUUID"))
+	if err != nil {
+		logger.ErrorLog.Printf("Error al ejecutar búsqueda guardada: %v", err)
+		savedSearchError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("Búsqueda ejecutada: %d eventos, %d nuevos", result.Total, len(result.Added)),
+		Data:    result,
+	})
+}
+
+// GetSavedSearchResults devuelve las instantáneas almacenadas de una búsqueda guardada.
+func (sc *SavedSearchController) GetSavedSearchResults(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserClaims(r.Context())
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil {
+			utils.ErrorJSON(w, fmt.Errorf("parámetro 'limit' inválido: %w", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := sc.service.GetSavedSearchResults(r.Context(), userClaims.ID, chi.URLParam(r, "searchUUID"), limit)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al obtener resultados de búsqueda guardada: %v", err)
+		savedSearchError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("%d resultados obtenidos", len(results)),
+		Data:    results,
+	})
+}
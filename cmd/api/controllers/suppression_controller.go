@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
+	"cloudtrail-enrichment-api-golang/models"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+)
+
+// SuppressionController maneja las solicitudes HTTP de las supresiones temporales de detecciones.
+type SuppressionController struct {
+	service services.SuppressionService
+}
+
+// NewSuppressionController crea una nueva instancia de SuppressionController.
+func NewSuppressionController(service services.SuppressionService) *SuppressionController {
+	return &SuppressionController{
+		service: service,
+	}
+}
+
+// suppressionError traduce los errores del servicio al código de estado HTTP correspondiente.
+func suppressionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrSuppressionNotFound):
+		utils.ErrorJSON(w, err, http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidSuppression):
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+	default:
+		utils.ErrorJSON(w, err, http.StatusInternalServerError)
+	}
+}
+
+// CreateSuppression crea una supresión temporal a nombre del usuario autenticado.
+func (sc *SuppressionController) CreateSuppression(w http.ResponseWriter, r *http.Request) {
+	actor, ok := findingActor(r)
+	if !ok {
+		utils.ErrorJSON(w, errors.New("usuario no autenticado"), http.StatusUnauthorized)
+		return
+	}
+
+	var payload models.SuppressionPayload
+	if err := utils.ReadJSON(w, r, &payload); err != nil {
+		utils.ErrorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	suppression, err := sc.service.CreateSuppression(r.Context(), actor, &payload)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear supresión: %v", err)
+		suppressionError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, utils.JSONResponse{
+		Error:   false,
+		Message: "Supresión creada exitosamente",
+		Data:    suppression,
+	})
+}
+
+// ListSuppressions lista las supresiones; por defecto solo las vigentes (active=false incluye las vencidas).
+func (sc *SuppressionController) ListSuppressions(w http.ResponseWriter, r *http.Request) {
+	activeOnly := true
+	if raw := r.URL.Query().Get("active"); raw != "" {
+		var err error
+		if activeOnly, err = strconv.ParseBool(raw); err != nil {
+			utils.ErrorJSON(w, fmt.Errorf("parámetro 'active' inválido: %w", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	suppressions, err := sc.service.ListSuppressions(r.Context(), activeOnly)
+	if err != nil {
+		suppressionError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("%d supresiones obtenidas", len(suppressions)),
+		Data:    suppressions,
+	})
+}
+
+// ExpireSuppression levanta una supresión vigente antes de su vencimiento.
+func (sc *SuppressionController) ExpireSuppression(w http.ResponseWriter, r *http.Request) {
+	suppression, err := sc.service.ExpireSuppression(r.Context(), chi.URLParam(r, "suppressionID"))
+	if err != nil {
+		suppressionError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, utils.JSONResponse{
+		Error:   false,
+		Message: "Supresión levantada",
+		Data:    suppression,
+	})
+}
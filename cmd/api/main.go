@@ -5,7 +5,12 @@ import (
 	"cloudtrail-enrichment-api-golang/database/mongo"
 	"cloudtrail-enrichment-api-golang/database/postgresql"
 	"cloudtrail-enrichment-api-golang/internal/config"
+	"cloudtrail-enrichment-api-golang/internal/detection"
+	"cloudtrail-enrichment-api-golang/internal/enrich"
+	"cloudtrail-enrichment-api-golang/internal/graph"
+	"cloudtrail-enrichment-api-golang/internal/grpcserver"
 	"cloudtrail-enrichment-api-golang/internal/middleware"
+	"cloudtrail-enrichment-api-golang/internal/notify"
 	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
 	"cloudtrail-enrichment-api-golang/internal/pkg/token"
 	"cloudtrail-enrichment-api-golang/internal/repository"
@@ -16,8 +21,12 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type application struct {
@@ -27,9 +36,16 @@ type application struct {
 	// models     models.Models
 	middleware *middleware.Middleware
 	// productsController *controllers.ProductsController
-	authController       *controllers.AuthController
-	systemController     *controllers.SystemController
-	enrichmentController *controllers.EnrichmentController
+	authController         *controllers.AuthController
+	systemController       *controllers.SystemController
+	enrichmentController   *controllers.EnrichmentController
+	savedSearchController  *controllers.SavedSearchController
+	graphQLController      *controllers.GraphQLController
+	findingController      *controllers.FindingController
+	notificationController *controllers.NotificationController
+	suppressionController  *controllers.SuppressionController
+	baselineController     *controllers.BaselineController
+	inventoryController    *controllers.InventoryController
 }
 
 func main() {
@@ -108,6 +124,10 @@ func main() {
 	repository.SetAuthRepository(authRepo)         // Setear la implementación global del AuthRepo
 	repository.SetEnrichmentRepository(enrichRepo) // Setear la implementación global del AuthRepo
 
+	// Las búsquedas guardadas viven en PostgreSQL junto a los usuarios
+	savedSearchRepo := postgresql.NewSavedSearchPostgresRepository(db)
+	repository.SetSavedSearchRepository(savedSearchRepo)
+
 	// AHORA: Creamos una instancia de JWTService, no de JWTToken
 	jwtService := token.NewJWTService(config, authRepo) // CAMBIO IMPORTANTE AQUÍ
 
@@ -116,11 +136,247 @@ func main() {
 	authService := services.NewAuthService(repository.AuthRepo, jwtService) // CAMBIO IMPORTANTE AQUÍ
 	// enrichService := services.NewDefaultEnrichmentService(repository.EnrichmentRepo)
 	enrichService := services.NewDefaultEnrichmentService(repository.EnrichmentRepo)
+	savedSearchService := services.NewDefaultSavedSearchService(repository.SavedSearchRepo, repository.EnrichmentRepo)
+
+	// Motor de detección: evalúa las reglas YAML y Sigma sobre cada evento tras el enriquecimiento
+	// Las detecciones se consultan por la API aunque el motor esté deshabilitado
+	findingsCollection := config.MongoDBConfig.FindingsCollection
+	if findingsCollection == "" {
+		findingsCollection = "findings"
+	}
+	repository.SetFindingRepository(mongo.NewFindingMongoRepository(mongoClient, config.MongoDBConfig.Database, findingsCollection))
+	findingService := services.NewDefaultFindingService(repository.FindingRepo)
+	suppressionsCollection := config.MongoDBConfig.SuppressionsCollection
+	if suppressionsCollection == "" {
+		suppressionsCollection = "suppressions"
+	}
+	repository.SetSuppressionRepository(mongo.NewSuppressionMongoRepository(mongoClient, config.MongoDBConfig.Database, suppressionsCollection))
+	suppressionService := services.NewDefaultSuppressionService(repository.SuppressionRepo)
+
+	// Líneas base de comportamiento por principal: se consultan por la API aunque estén deshabilitadas
+	baselinesCollection := config.MongoDBConfig.BaselinesCollection
+	if baselinesCollection == "" {
+		baselinesCollection = "baselines"
+	}
+	repository.SetBaselineRepository(mongo.NewBaselineMongoRepository(mongoClient, config.MongoDBConfig.Database, baselinesCollection))
+	baselineService, err := services.NewDefaultBaselineService(repository.BaselineRepo, services.BaselineOptions{
+		Window:          config.BaselineConfig.Window,
+		LearningPeriod:  config.BaselineConfig.LearningPeriod,
+		AlertDimensions: config.BaselineConfig.AlertDimensions,
+		Severity:        config.BaselineConfig.Severity,
+	})
+	if err != nil {
+		log.Fatal("Error al configurar las líneas base:", err)
+	}
+
+	// Clasificadores del origen, aplicados antes de la geolocalización
+	networkClassifier, err := enrich.NewNetworkClassifier(config.EnrichersConfig.Network.Sites)
+	if err != nil {
+		log.Fatal("Error al configurar los rangos internos:", err)
+	}
+	enrichService.AddClassifier(networkClassifier)
+	if config.EnrichersConfig.AWSSource.Enabled {
+		awsSourceEnricher, err := enrich.NewAWSSourceEnricher(config.EnrichersConfig.AWSSource.IPRangesPath)
+		if err != nil {
+			log.Fatal("Error al cargar los rangos de IP de AWS:", err)
+		}
+		enrichService.AddClassifier(awsSourceEnricher)
+	}
+
+	// Enriquecedores opcionales, aplicados en orden antes del puntaje de riesgo
+	if config.EnrichersConfig.Identity.Enabled {
+		enrichService.AddEnricher(enrich.NewIdentityEnricher())
+	}
+	// Los recursos van antes del inventario, que etiqueta el evento con los ARN que encuentra
+	if config.EnrichersConfig.Resources.Enabled {
+		enrichService.AddEnricher(enrich.NewResourceEnricher())
+	}
+	// Inventario de cuentas y recursos en PostgreSQL: se administra por la API aunque el
+	// enriquecedor esté deshabilitado
+	repository.SetInventoryRepository(postgresql.NewInventoryPostgresRepository(db))
+	var inventoryIndex services.InventoryIndex
+	if config.EnrichersConfig.Inventory.Enabled {
+		inventoryEnricher := enrich.NewInventoryEnricher()
+		enrichService.AddEnricher(inventoryEnricher)
+		inventoryIndex = inventoryEnricher
+	}
+	inventoryService := services.NewDefaultInventoryService(repository.InventoryRepo, inventoryIndex, config.EnrichersConfig.Inventory.Files)
+	if _, err := inventoryService.ImportInventoryFiles(context.Background()); err != nil {
+		log.Fatal("Error al importar el inventario:", err)
+	}
+	inventoryService.Start(config.EnrichersConfig.Inventory.RefreshInterval)
+	defer inventoryService.Stop()
+	if config.EnrichersConfig.UserAgent.Enabled {
+		enrichService.AddEnricher(enrich.NewUserAgentEnricher())
+	}
+	if config.EnrichersConfig.ASN.Enabled {
+		asnEnricher, err := enrich.NewASNEnricher(config.EnrichersConfig.ASN.DatabasePaths)
+		if err != nil {
+			log.Fatal("Error al abrir las bases ASN:", err)
+		}
+		enrichService.AddEnricher(asnEnricher)
+		defer asnEnricher.Close()
+	}
+	if dnsConfig := config.EnrichersConfig.ReverseDNS; dnsConfig.Enabled {
+		reverseDNSEnricher := enrich.NewReverseDNSEnricher(enrich.ReverseDNSOptions{
+			Server:           dnsConfig.Server,
+			Workers:          dnsConfig.Workers,
+			QueueSize:        dnsConfig.QueueSize,
+			Timeout:          dnsConfig.Timeout,
+			CacheTTL:         dnsConfig.CacheTTL,
+			NegativeCacheTTL: dnsConfig.NegativeCacheTTL,
+			CacheSize:        dnsConfig.CacheSize,
+			StoreUnconfirmed: dnsConfig.StoreUnconfirmed,
+		})
+		enrichService.AddEnricher(reverseDNSEnricher)
+		reverseDNSEnricher.Start()
+		defer reverseDNSEnricher.Stop()
+	}
+	if config.EnrichersConfig.Actions.Enabled {
+		actionEnricher, err := enrich.NewActionEnricher(config.EnrichersConfig.Actions.MappingPath)
+		if err != nil {
+			log.Fatal("Error al cargar la clasificación de acciones:", err)
+		}
+		enrichService.AddEnricher(actionEnricher)
+	}
+	if config.EnrichersConfig.Attack.Enabled {
+		attackEnricher, err := enrich.NewAttackEnricher(config.EnrichersConfig.Attack.MappingPath)
+		if err != nil {
+			log.Fatal("Error al cargar el mapeo ATT&CK:", err)
+		}
+		enrichService.AddEnricher(attackEnricher)
+	}
+	if config.EnrichersConfig.ThreatIntel.Enabled {
+		threatIntelEnricher, err := enrich.NewThreatIntelEnricher(config.EnrichersConfig.ThreatIntel.FeedsPath)
+		if err != nil {
+			log.Fatal("Error al cargar los feeds de inteligencia de amenazas:", err)
+		}
+		enrichService.AddEnricher(threatIntelEnricher)
+		threatIntelEnricher.Start(config.EnrichersConfig.ThreatIntel.ReloadInterval)
+		defer threatIntelEnricher.Stop()
+	}
+	if anonymizerConfig := config.EnrichersConfig.Anonymizer; anonymizerConfig.Enabled {
+		anonymizerEnricher, err := enrich.NewAnonymizerEnricher(enrich.AnonymizerLists{
+			Tor:     anonymizerConfig.TorLists,
+			VPN:     anonymizerConfig.VPNLists,
+			Hosting: anonymizerConfig.HostingLists,
+		})
+		if err != nil {
+			log.Fatal("Error al cargar las listas de anonimizadores:", err)
+		}
+		enrichService.AddEnricher(anonymizerEnricher)
+		anonymizerEnricher.Start(anonymizerConfig.ReloadInterval)
+		defer anonymizerEnricher.Stop()
+	}
+
+	// Puntaje de riesgo de cada evento, calculado antes de persistirlo
+	if config.RiskConfig.Enabled {
+		riskService, err := services.NewDefaultRiskService(services.RiskOptions{
+			Weights:           config.RiskConfig.Weights,
+			SensitiveSources:  config.RiskConfig.SensitiveSources,
+			ErrorCodes:        config.RiskConfig.ErrorCodes,
+			ExpectedCountries: config.RiskConfig.ExpectedCountries,
+			BadIPCIDRs:        config.RiskConfig.BadIPCIDRs,
+		})
+		if err != nil {
+			log.Fatal("Error al configurar el puntaje de riesgo:", err)
+		}
+		if config.BaselineConfig.Enabled {
+			riskService.SetBaselines(baselineService)
+		}
+		enrichService.SetRiskScorer(riskService)
+	}
+
+	// Notificaciones de detecciones: webhook, Slack y PagerDuty con reintentos y registro de entregas
+	deliveriesCollection := config.MongoDBConfig.DeliveriesCollection
+	if deliveriesCollection == "" {
+		deliveriesCollection = "notification_deliveries"
+	}
+	repository.SetNotificationDeliveryRepository(mongo.NewNotificationDeliveryMongoRepository(mongoClient, config.MongoDBConfig.Database, deliveriesCollection))
+	var channels []*notify.Channel
+	if config.NotificationConfig.Enabled {
+		if channels, err = notify.LoadChannels(config.NotificationConfig.ChannelsPath); err != nil {
+			log.Fatal("Error al cargar los canales de notificación:", err)
+		}
+	}
+	notificationService := services.NewDefaultNotificationService(repository.NotificationDeliveryRepo, channels, services.NotificationOptions{
+		Workers:        config.NotificationConfig.Workers,
+		QueueSize:      config.NotificationConfig.QueueSize,
+		MaxAttempts:    config.NotificationConfig.MaxAttempts,
+		InitialBackoff: config.NotificationConfig.InitialBackoff,
+		MaxBackoff:     config.NotificationConfig.MaxBackoff,
+		Timeout:        config.NotificationConfig.Timeout,
+	})
+	if config.NotificationConfig.Enabled {
+		notificationService.Start()
+		defer notificationService.Stop()
+	}
+
+	if config.DetectionConfig.Enabled {
+		correlationStateCollection := config.MongoDBConfig.CorrelationStateCollection
+		if correlationStateCollection == "" {
+			correlationStateCollection = "correlation_state"
+		}
+		repository.SetCorrelationStateRepository(mongo.NewCorrelationStateMongoRepository(mongoClient, config.MongoDBConfig.Database, correlationStateCollection))
+
+		detectionService := services.NewDetectionService(detection.NewEngine(nil), detection.NewCorrelationEngine(nil), repository.FindingRepo, repository.CorrelationStateRepo, repository.SuppressionRepo)
+		if err := detectionService.ReloadRules(config.DetectionConfig.RulesPath, config.DetectionConfig.SigmaPath); err != nil {
+			log.Fatal("Error al cargar las reglas de detección:", err)
+		}
+		if travel := config.DetectionConfig.ImpossibleTravel; travel.Enabled {
+			travelDetector, err := detection.NewTravelDetector(detection.TravelConfig{
+				MaxSpeedKmh:   travel.MaxSpeedKmh,
+				MinDistanceKm: travel.MinDistanceKm,
+				Window:        travel.Window,
+				Severity:      travel.Severity,
+				IgnoreCIDRs:   travel.IgnoreCIDRs,
+			})
+			if err != nil {
+				log.Fatal("Error al configurar la detección de viaje imposible:", err)
+			}
+			detectionService.SetTravelDetector(travelDetector)
+		}
+		if err := detectionService.RestoreCorrelationState(context.Background()); err != nil {
+			logger.ErrorLog.Printf("Error al restaurar el estado de correlación: %v", err)
+		}
+		if config.NotificationConfig.Enabled {
+			detectionService.AddFindingObserver(notificationService)
+		}
+		if config.BaselineConfig.Enabled {
+			detectionService.AddFindingSource(baselineService)
+		}
+		enrichService.AddObserver(detectionService)
+	} else if config.BaselineConfig.Enabled {
+		// Sin motor de detección la línea base se sigue construyendo, pero no genera detecciones
+		enrichService.AddObserver(baselineService)
+	}
+
+	// Planificador en segundo plano para las búsquedas guardadas con programación cron
+	if err := savedSearchService.StartScheduler(context.Background()); err != nil {
+		logger.ErrorLog.Printf("Error al iniciar el planificador de búsquedas guardadas: %v", err)
+	}
+	defer savedSearchService.StopScheduler()
 
 	// Inicialización de controladores
 	authController := controllers.NewAuthController(authService)
 	systemController := controllers.NewSystemController()
 	enrichmentController := controllers.NewEnrichmentController(enrichService)
+	savedSearchController := controllers.NewSavedSearchController(savedSearchService)
+	findingController := controllers.NewFindingController(findingService)
+	notificationController := controllers.NewNotificationController(notificationService)
+	suppressionController := controllers.NewSuppressionController(suppressionService)
+	baselineController := controllers.NewBaselineController(baselineService)
+	inventoryController := controllers.NewInventoryController(inventoryService)
+
+	// Esquema GraphQL construido sobre el mismo servicio de enriquecimiento que la API REST
+	graphQLSchema, err := graph.NewSchema(enrichService)
+	if err != nil {
+		log.Fatal("Error al construir el esquema GraphQL:", err)
+	}
+	graphQLController := controllers.NewGraphQLController(graphQLSchema, graph.Limits{
+		MaxDepth:      config.GraphQLConfig.MaxDepth,
+		MaxComplexity: config.GraphQLConfig.MaxComplexity,
+	})
 
 	// PASAMOS jwtService al middleware
 	mw := middleware.NewMiddleware(jwtService, authService) // CAMBIO IMPORTANTE AQUÍ
@@ -131,9 +387,25 @@ func main() {
 		errorLog:   logger.ErrorLog,
 		middleware: mw,
 		// productsController: productsController,
-		authController:       authController,
-		systemController:     systemController,
-		enrichmentController: enrichmentController,
+		authController:         authController,
+		systemController:       systemController,
+		enrichmentController:   enrichmentController,
+		savedSearchController:  savedSearchController,
+		graphQLController:      graphQLController,
+		findingController:      findingController,
+		notificationController: notificationController,
+		suppressionController:  suppressionController,
+		baselineController:     baselineController,
+		inventoryController:    inventoryController,
+	}
+
+	// Servidor gRPC para productores internos de alto volumen, en paralelo al router chi
+	if config.GRPCConfig.Enabled {
+		go func() {
+			if err := app.serveGRPC(enrichService, jwtService); err != nil {
+				logger.ErrorLog.Printf("Error en el servidor gRPC: %v", err)
+			}
+		}()
 	}
 
 	err = app.serve()
@@ -180,3 +452,27 @@ func (app *application) serve() error {
 	app.infoLog.Printf("Iniciando servidor HTTPS con certificados en %s y %s", certFile, keyFile)
 	return srv.ListenAndServeTLS("", "")
 }
+
+// serveGRPC inicia el servidor gRPC de ingesta y consulta. Con TLS habilitado reutiliza los
+// certificados del servidor HTTP.
+func (app *application) serveGRPC(enrichService services.EnrichmentService, jwtService *token.JWTService) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", app.config.GRPCConfig.Port))
+	if err != nil {
+		return fmt.Errorf("error al abrir el puerto gRPC: %v", err)
+	}
+
+	var opts []grpc.ServerOption
+	if app.config.ServerConfig.TLS.Enabled {
+		creds, err := credentials.NewServerTLSFromFile(app.config.ServerConfig.TLS.CertFile, app.config.ServerConfig.TLS.KeyFile)
+		if err != nil {
+			return fmt.Errorf("error cargando certificados TLS para gRPC: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		app.infoLog.Println("TLS deshabilitado. Iniciando servidor gRPC sin cifrado.")
+	}
+
+	server := grpcserver.NewServer(enrichService, jwtService, opts...)
+	app.infoLog.Printf("Servidor gRPC escuchando en el puerto %d", app.config.GRPCConfig.Port)
+	return server.Serve(listener)
+}
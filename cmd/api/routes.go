@@ -37,6 +37,60 @@ func (app *application) routes() http.Handler {
 			r.Get("/", app.enrichmentController.QueryEvents)
 		})
 
+		r.Route("/graphql", func(r chi.Router) {
+			r.Use(app.middleware.AuthTokenMiddleware)
+			r.Get("/", app.graphQLController.ServeGraphQL)
+			r.Post("/", app.graphQLController.ServeGraphQL)
+		})
+
+		r.Route("/saved-searches", func(r chi.Router) {
+			r.Use(app.middleware.AuthTokenMiddleware)
+			r.Post("/", app.savedSearchController.CreateSavedSearch)
+			r.Get("/", app.savedSearchController.ListSavedSearches)
+			r.Get("/{searchUUID}", app.savedSearchController.GetSavedSearch)
+			r.Put("/{searchUUID}", app.savedSearchController.UpdateSavedSearch)
+			r.Delete("/{searchUUID}", app.savedSearchController.DeleteSavedSearch)
+			r.Post("/{searchUUID}/run", app.savedSearchController.RunSavedSearch)
+			r.Get("/{searchUUID}/results", app.savedSearchController.GetSavedSearchResults)
+		})
+
+		r.Route("/findings", func(r chi.Router) {
+			r.Use(app.middleware.AuthTokenMiddleware)
+			r.Get("/", app.findingController.ListFindings)
+			r.Get("/{findingID}", app.findingController.GetFinding)
+			r.Put("/{findingID}/status", app.findingController.UpdateFindingStatus)
+			r.Put("/{findingID}/assignee", app.findingController.AssignFinding)
+			r.Post("/{findingID}/comments", app.findingController.CommentFinding)
+		})
+
+		// La prueba de canales envía solicitudes salientes con los secretos configurados: solo admin
+		r.Route("/notifications", func(r chi.Router) {
+			r.Use(app.middleware.AuthTokenMiddleware)
+			r.Get("/deliveries", app.notificationController.ListDeliveries)
+			r.With(app.middleware.RequireRole("admin")).Post("/channels/{channel}/test", app.notificationController.SendTestNotification)
+		})
+
+		r.Route("/suppressions", func(r chi.Router) {
+			r.Use(app.middleware.AuthTokenMiddleware)
+			r.Post("/", app.suppressionController.CreateSuppression)
+			r.Get("/", app.suppressionController.ListSuppressions)
+			r.Delete("/{suppressionID}", app.suppressionController.ExpireSuppression)
+		})
+
+		r.Route("/baselines", func(r chi.Router) {
+			r.Use(app.middleware.AuthTokenMiddleware)
+			r.Get("/", app.baselineController.GetBaseline)
+		})
+
+		// Lectura para cualquier usuario autenticado; los cambios requieren el rol admin
+		r.Route("/inventory", func(r chi.Router) {
+			r.Use(app.middleware.AuthTokenMiddleware)
+			r.Get("/", app.inventoryController.ListInventory)
+			r.With(app.middleware.RequireRole("admin")).Put("/", app.inventoryController.UpsertInventoryItem)
+			r.With(app.middleware.RequireRole("admin")).Delete("/", app.inventoryController.DeleteInventoryItem)
+			r.With(app.middleware.RequireRole("admin")).Post("/import", app.inventoryController.ImportInventory)
+		})
+
 		// r.Route("/admin", func(r chi.Router) {
 		// 	r.Use(app.middleware.AuthTokenMiddleware)
 		// 	// Authorization middleware with roles example
@@ -0,0 +1,62 @@
+// webhook-receiver es un receptor HTTP local para probar los canales de notificación.
+// Imprime cada solicitud recibida, verifica la firma HMAC si se indica -secret y puede
+// simular fallos para ejercitar los reintentos del despachador.
+package main
+
+import (
+	"bytes"
+	"cloudtrail-enrichment-api-golang/internal/notify"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":9999", "dirección de escucha")
+	secret := flag.String("secret", "", "clave HMAC para verificar X-Signature-256")
+	failFirst := flag.Int("fail-first", 0, "responder 503 a las primeras N solicitudes")
+	flag.Parse()
+
+	var received int64
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&received, 1)
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		signature := "sin firma"
+		if *secret != "" {
+			if notify.Verify(*secret, r.Header.Get(notify.SignatureHeader), r.Header.Get(notify.TimestampHeader), body, 5*time.Minute) {
+				signature = "firma válida"
+			} else {
+				signature = "FIRMA INVÁLIDA"
+			}
+		}
+
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err != nil {
+			pretty.Write(body)
+		}
+		log.Printf("#%d %s %s (%s)\n%s", n, r.Method, r.URL.Path, signature, pretty.String())
+
+		if signature == "FIRMA INVÁLIDA" {
+			http.Error(w, "firma inválida", http.StatusUnauthorized)
+			return
+		}
+		if n <= int64(*failFirst) {
+			http.Error(w, "fallo simulado", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+
+	log.Printf("Receptor de webhooks escuchando en %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
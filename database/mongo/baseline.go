@@ -0,0 +1,157 @@
+package mongo
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BaselineMongoRepository implementa la interfaz BaselineRepository para MongoDB. Cada
+// documento es un valor observado para un principal en una dimensión.
+type BaselineMongoRepository struct {
+	mongoInstance *MongoInstance
+}
+
+// NewBaselineMongoRepository crea una nueva instancia de BaselineMongoRepository y asegura el
+// índice único por (principal, dimensión, valor) y el índice TTL que implementa la ventana móvil.
+func NewBaselineMongoRepository(client *mongo.Client, dbName, collectionName string) *BaselineMongoRepository {
+	logger.InfoLog.Printf("[DEBUG] Colección de líneas base: '%s.%s'", dbName, collectionName)
+	collection := client.Database(dbName).Collection(collectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "principal", Value: 1}, {Key: "dimension", Value: 1}, {Key: "value", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear los índices de la colección de líneas base: %v", err)
+	}
+
+	return &BaselineMongoRepository{
+		mongoInstance: &MongoInstance{
+			Client:     client,
+			Collection: collection,
+		},
+	}
+}
+
+// ObserveBaselines registra las observaciones del evento y devuelve, por observación, la
+// entrada vigente tal como estaba antes de actualizarla; las que no aparecen no se habían
+// observado dentro de la ventana. Una entrada vencida que el índice TTL aún no borró no cuenta
+// como vigente: se elimina en la misma escritura y la observación la crea de nuevo. Se hace
+// una lectura y una única escritura en lote por evento.
+func (m *BaselineMongoRepository) ObserveBaselines(ctx context.Context, principal string, observations []models.BaselineObservation, at, expiresAt time.Time) (map[models.BaselineObservation]*models.BaselineEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	previous := map[models.BaselineObservation]*models.BaselineEntry{}
+	if len(observations) == 0 {
+		return previous, nil
+	}
+	live := time.Now()
+	if at.After(live) {
+		live = at
+	}
+
+	keys := make(bson.A, 0, len(observations))
+	for _, observation := range observations {
+		keys = append(keys, bson.M{"dimension": observation.Dimension, "value": observation.Value})
+	}
+	cursor, err := m.mongoInstance.Collection.Find(ctx, bson.M{
+		"principal":  principal,
+		"$or":        keys,
+		"expires_at": bson.M{"$gt": live},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar la línea base de %s: %w", principal, err)
+	}
+	var entries []*models.BaselineEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("error al decodificar la línea base de %s: %w", principal, err)
+	}
+	for _, entry := range entries {
+		previous[models.BaselineObservation{Dimension: entry.Dimension, Value: entry.Value}] = entry
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(observations)+1)
+	writes = append(writes, mongo.NewDeleteManyModel().SetFilter(bson.M{
+		"principal":  principal,
+		"$or":        keys,
+		"expires_at": bson.M{"$lte": live},
+	}))
+	for _, observation := range observations {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"principal": principal, "dimension": observation.Dimension, "value": observation.Value}).
+			SetUpdate(bson.M{
+				"$min": bson.M{"first_seen": at},
+				"$max": bson.M{"last_seen": at, "expires_at": expiresAt},
+				"$inc": bson.M{"count": 1},
+			}).
+			SetUpsert(true))
+	}
+	if _, err := m.mongoInstance.Collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(true)); err != nil {
+		return nil, fmt.Errorf("error al actualizar la línea base de %s: %w", principal, err)
+	}
+	return previous, nil
+}
+
+// ListBaselineEntries devuelve las entradas vigentes del principal, opcionalmente de una sola
+// dimensión, de la más observada a la menos observada.
+func (m *BaselineMongoRepository) ListBaselineEntries(ctx context.Context, principal, dimension string) ([]*models.BaselineEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := bson.M{"principal": principal, "expires_at": bson.M{"$gt": time.Now()}}
+	if dimension != "" {
+		query["dimension"] = dimension
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "dimension", Value: 1}, {Key: "count", Value: -1}}).
+		SetLimit(maxSearchLimit)
+	cursor, err := m.mongoInstance.Collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar la línea base de %s: %w", principal, err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*models.BaselineEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("error al decodificar la línea base de %s: %w", principal, err)
+	}
+	return entries, nil
+}
+
+// GetBaselineEntry devuelve la entrada vigente del valor, o nil si no se observó dentro de la ventana.
+func (m *BaselineMongoRepository) GetBaselineEntry(ctx context.Context, principal, dimension, value string) (*models.BaselineEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var entry models.BaselineEntry
+	err := m.mongoInstance.Collection.FindOne(ctx, bson.M{
+		"principal":  principal,
+		"dimension":  dimension,
+		"value":      value,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error al buscar la línea base de %s (%s): %w", principal, dimension, err)
+	}
+	return &entry, nil
+}
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CorrelationStateMongoRepository implementa la interfaz CorrelationStateRepository para MongoDB.
+// Cada documento es el estado de una regla de correlación para una clave de agrupación.
+type CorrelationStateMongoRepository struct {
+	mongoInstance *MongoInstance
+}
+
+// NewCorrelationStateMongoRepository crea una nueva instancia de CorrelationStateMongoRepository
+// y asegura el índice TTL que elimina los estados cuya ventana ya expiró.
+func NewCorrelationStateMongoRepository(client *mongo.Client, dbName, collectionName string) *CorrelationStateMongoRepository {
+	logger.InfoLog.Printf("[DEBUG] Colección de estado de correlación: '%s.%s'", dbName, collectionName)
+	collection := client.Database(dbName).Collection(collectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear el índice TTL de la colección de estado de correlación: %v", err)
+	}
+
+	return &CorrelationStateMongoRepository{
+		mongoInstance: &MongoInstance{
+			Client:     client,
+			Collection: collection,
+		},
+	}
+}
+
+// LoadCorrelationStates devuelve todos los estados de correlación que aún no expiraron.
+func (m *CorrelationStateMongoRepository) LoadCorrelationStates(ctx context.Context) ([]*models.CorrelationState, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := m.mongoInstance.Collection.Find(ctx, bson.M{"expires_at": bson.M{"$gt": time.Now()}})
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar el estado de correlación: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	states := []*models.CorrelationState{}
+	if err := cursor.All(ctx, &states); err != nil {
+		return nil, fmt.Errorf("error al decodificar el estado de correlación: %w", err)
+	}
+	return states, nil
+}
+
+// SaveCorrelationState inserta o reemplaza el estado de correlación de una clave.
+func (m *CorrelationStateMongoRepository) SaveCorrelationState(ctx context.Context, state *models.CorrelationState) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := m.mongoInstance.Collection.ReplaceOne(ctx, bson.M{"_id": state.Key}, state, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("error al guardar el estado de correlación %s: %w", state.Key, err)
+	}
+	return nil
+}
+
+// DeleteCorrelationState elimina el estado de correlación de una clave.
+func (m *CorrelationStateMongoRepository) DeleteCorrelationState(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := m.mongoInstance.Collection.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		return fmt.Errorf("error al eliminar el estado de correlación %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package mongo
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindingMongoRepository implementa la interfaz FindingRepository para MongoDB.
+// Las detecciones se guardan en una colección separada de los eventos enriquecidos.
+type FindingMongoRepository struct {
+	mongoInstance *MongoInstance
+}
+
+// NewFindingMongoRepository crea una nueva instancia de FindingMongoRepository y asegura
+// los índices de consulta de la colección.
+func NewFindingMongoRepository(client *mongo.Client, dbName, collectionName string) *FindingMongoRepository {
+	logger.InfoLog.Printf("[DEBUG] Colección de detecciones: '%s.%s'", dbName, collectionName)
+	collection := client.Database(dbName).Collection(collectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "rule_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "severity", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "event_ids", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "assignee", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "rule_id", Value: 1}, {Key: "dedup_key", Value: 1}, {Key: "created_at", Value: -1}}},
+	}, options.CreateIndexes())
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear los índices de la colección de detecciones: %v", err)
+	}
+
+	return &FindingMongoRepository{
+		mongoInstance: &MongoInstance{
+			Client:     client,
+			Collection: collection,
+		},
+	}
+}
+
+// InsertFinding inserta una nueva detección en MongoDB.
+func (m *FindingMongoRepository) InsertFinding(ctx context.Context, finding *models.Finding) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := m.mongoInstance.Collection.InsertOne(ctx, finding)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al insertar detección en MongoDB: %v", err)
+		return fmt.Errorf("error al insertar detección: %w", err)
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		finding.ID = id
+	}
+	logger.InfoLog.Printf("Detección insertada en MongoDB. Regla: %s, Severidad: %s", finding.RuleID, finding.Severity)
+	return nil
+}
+
+// buildFindingFilter traduce un models.FindingFilter a un filtro bson.
+func buildFindingFilter(filter *models.FindingFilter) bson.M {
+	query := bson.M{}
+	if filter == nil {
+		return query
+	}
+
+	if filter.RuleID != "" {
+		query["rule_id"] = filter.RuleID
+	}
+	if filter.Severity != "" {
+		query["severity"] = filter.Severity
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Assignee != "" {
+		query["assignee"] = filter.Assignee
+	}
+
+	if filter.From != nil || filter.To != nil {
+		createdAt := bson.M{}
+		if filter.From != nil {
+			createdAt["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			createdAt["$lte"] = *filter.To
+		}
+		query["created_at"] = createdAt
+	}
+
+	return query
+}
+
+// ListFindings busca las detecciones que cumplen con el filtro, de la más reciente a la más antigua.
+func (m *FindingMongoRepository) ListFindings(ctx context.Context, filter *models.FindingFilter) ([]*models.Finding, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	limit := defaultSearchLimit
+	var skip int64
+	if filter != nil {
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+		skip = filter.Skip
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+
+	cursor, err := m.mongoInstance.Collection.Find(ctx, buildFindingFilter(filter), findOptions)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al buscar detecciones en MongoDB: %v", err)
+		return nil, fmt.Errorf("error al buscar detecciones: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	findings := []*models.Finding{}
+	if err := cursor.All(ctx, &findings); err != nil {
+		logger.ErrorLog.Printf("Error al decodificar detecciones de MongoDB: %v", err)
+		return nil, fmt.Errorf("error al decodificar detecciones: %w", err)
+	}
+	return findings, nil
+}
+
+// GetFinding recupera una detección por su ObjectID en formato hexadecimal.
+// Devuelve mongo.ErrNoDocuments si el identificador no existe.
+func (m *FindingMongoRepository) GetFinding(ctx context.Context, id string) (*models.Finding, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("identificador de detección inválido: %w", err)
+	}
+
+	var finding models.Finding
+	err = m.mongoInstance.Collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&finding)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, err
+		}
+		logger.ErrorLog.Printf("Error al obtener detección %s de MongoDB: %v", id, err)
+		return nil, fmt.Errorf("error al obtener detección: %w", err)
+	}
+	return &finding, nil
+}
+
+// SetFindingStatus cambia el estado de una detección y registra el cambio en su historial.
+// Solo actualiza si el estado sigue siendo change.From; si otro analista lo cambió antes
+// devuelve mongo.ErrNoDocuments.
+func (m *FindingMongoRepository) SetFindingStatus(ctx context.Context, id, status string, change models.FindingChange) (*models.Finding, error) {
+	return m.updateFinding(ctx, id, bson.M{"status": change.From}, bson.M{
+		"$set":  bson.M{"status": status, "updated_at": change.At},
+		"$push": bson.M{"history": change},
+	})
+}
+
+// SetFindingAssignee asigna (o desasigna, con assignee vacío) una detección y registra el cambio.
+// Solo actualiza si la asignación sigue siendo change.From; si otro analista la cambió antes
+// devuelve mongo.ErrNoDocuments.
+func (m *FindingMongoRepository) SetFindingAssignee(ctx context.Context, id, assignee string, change models.FindingChange) (*models.Finding, error) {
+	conditions := bson.M{"assignee": change.From}
+	if change.From == "" {
+		conditions = bson.M{"assignee": bson.M{"$in": bson.A{nil, ""}}} // Sin asignar: el campo no existe
+	}
+	update := bson.M{
+		"$set":  bson.M{"assignee": assignee, "updated_at": change.At},
+		"$push": bson.M{"history": change},
+	}
+	if assignee == "" {
+		update = bson.M{
+			"$set":   bson.M{"updated_at": change.At},
+			"$unset": bson.M{"assignee": ""},
+			"$push":  bson.M{"history": change},
+		}
+	}
+	return m.updateFinding(ctx, id, conditions, update)
+}
+
+// AddFindingComment agrega un comentario a una detección y registra el cambio.
+func (m *FindingMongoRepository) AddFindingComment(ctx context.Context, id string, comment models.FindingComment, change models.FindingChange) (*models.Finding, error) {
+	return m.updateFinding(ctx, id, nil, bson.M{
+		"$set":  bson.M{"updated_at": change.At},
+		"$push": bson.M{"comments": comment, "history": change},
+	})
+}
+
+// updateFinding aplica la actualización y devuelve la detección resultante. conditions agrega
+// condiciones al filtro por identificador. Devuelve mongo.ErrNoDocuments si ningún documento
+// las cumple.
+func (m *FindingMongoRepository) updateFinding(ctx context.Context, id string, conditions, update bson.M) (*models.Finding, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("identificador de detección inválido: %w", err)
+	}
+
+	filter := bson.M{"_id": objectID}
+	for field, value := range conditions {
+		filter[field] = value
+	}
+
+	var finding models.Finding
+	err = m.mongoInstance.Collection.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&finding)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, err
+		}
+		logger.ErrorLog.Printf("Error al actualizar detección %s en MongoDB: %v", id, err)
+		return nil, fmt.Errorf("error al actualizar detección: %w", err)
+	}
+	return &finding, nil
+}
+
+// CountFindingsSince cuenta las detecciones de la regla y clave de deduplicación creadas desde since.
+func (m *FindingMongoRepository) CountFindingsSince(ctx context.Context, ruleID, dedupKey string, since time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := m.mongoInstance.Collection.CountDocuments(ctx, bson.M{
+		"rule_id":    ruleID,
+		"dedup_key":  dedupKey,
+		"created_at": bson.M{"$gte": since},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error al contar detecciones de la regla %s: %w", ruleID, err)
+	}
+	return count, nil
+}
+
+// RecordSuppressed incrementa el contador de suprimidas de la última detección de la regla
+// y clave de deduplicación. lastSeen es la hora del evento suprimido y at la hora en que se
+// procesó. Si no existe ninguna detección no hace nada.
+func (m *FindingMongoRepository) RecordSuppressed(ctx context.Context, ruleID, dedupKey string, lastSeen, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := m.mongoInstance.Collection.FindOneAndUpdate(ctx,
+		bson.M{"rule_id": ruleID, "dedup_key": dedupKey},
+		bson.M{
+			"$inc": bson.M{"suppressed_count": 1},
+			"$set": bson.M{"last_suppressed_at": at},
+			"$max": bson.M{"last_seen": lastSeen},
+		},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	).Err()
+	if err != nil && err != mongo.ErrNoDocuments {
+		return fmt.Errorf("error al registrar la supresión en la regla %s: %w", ruleID, err)
+	}
+	return nil
+}
@@ -6,9 +6,12 @@ import (
 	"cloudtrail-enrichment-api-golang/models"
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -127,6 +130,19 @@ func NewEnrichMongoRepository(client *mongo.Client, dbName, collectionName strin
 	logger.InfoLog.Printf("[DEBUG] Conectando a MongoDB. Base de datos: '%s', Colección: '%s'", dbName, collectionName)
 	collection := client.Database(dbName).Collection(collectionName)
 
+	// Índices para ordenar y filtrar por puntaje de riesgo, por actor y por herramienta del user agent
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "risk.score", Value: -1}, {Key: "eventTime", Value: -1}}},
+		{Keys: bson.D{{Key: "identity.actor", Value: 1}, {Key: "eventTime", Value: -1}}},
+		{Keys: bson.D{{Key: "resources.arn", Value: 1}, {Key: "eventTime", Value: -1}}},
+		{Keys: bson.D{{Key: "userAgentInfo.tool", Value: 1}, {Key: "eventTime", Value: -1}}},
+	})
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear los índices de la colección de eventos enriquecidos: %v", err)
+	}
+
 	return &EnrichmentMongoRepository{
 		mongoInstance: &MongoInstance{
 			Client:     client,
@@ -140,11 +156,15 @@ func (m *EnrichmentMongoRepository) InsertLog(ctx context.Context, event *models
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second) // Usar el timeout del contexto, o definir uno si es nil
 	defer cancel()
 
-	_, err := m.mongoInstance.Collection.InsertOne(ctx, event)
+	result, err := m.mongoInstance.Collection.InsertOne(ctx, event)
 	if err != nil {
 		logger.ErrorLog.Printf("Error al insertar evento enriquecido en MongoDB: %v", err)
 		return fmt.Errorf("error al insertar evento enriquecido: %w", err)
 	}
+	// Se conserva el ID generado para que las detecciones puedan referenciar el evento
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		event.ID = id
+	}
 	logger.InfoLog.Printf("Evento enriquecido insertado en MongoDB. EventSource: %s", event.EventSource)
 	return nil
 }
@@ -183,3 +203,375 @@ func (m *EnrichmentMongoRepository) GetLatestLogs(ctx context.Context) ([]*model
 	logger.InfoLog.Println("Últimos 10 eventos enriquecidos obtenidos de MongoDB.")
 	return events, nil
 }
+
+// Límites de paginación para las búsquedas de eventos enriquecidos.
+const (
+	defaultSearchLimit int64 = 10
+	maxSearchLimit     int64 = 1000
+)
+
+// buildEventFilter traduce un models.EventFilter al filtro BSON equivalente.
+func buildEventFilter(filter *models.EventFilter) bson.M {
+	query := bson.M{}
+	if filter == nil {
+		return query
+	}
+
+	if filter.EventName != "" {
+		query["eventName"] = filter.EventName
+	}
+	if filter.EventSource != "" {
+		query["eventSource"] = filter.EventSource
+	}
+	if filter.AwsRegion != "" {
+		query["awsRegion"] = filter.AwsRegion
+	}
+	if filter.SourceIPAddress != "" {
+		query["sourceIPAddress"] = filter.SourceIPAddress
+	}
+	if filter.UserName != "" {
+		query["userIdentity.userName"] = filter.UserName
+	}
+	if filter.AccountID != "" {
+		query["userIdentity.accountId"] = filter.AccountID
+	}
+	if filter.Actor != "" {
+		query["identity.actor"] = filter.Actor
+	}
+	if filter.IdentityAccount != "" {
+		query["identity.account"] = filter.IdentityAccount
+	}
+	if filter.IdentityRole != "" {
+		query["identity.role"] = filter.IdentityRole
+	}
+	if filter.SessionName != "" {
+		query["identity.sessionName"] = filter.SessionName
+	}
+	if filter.PrincipalType != "" {
+		query["identity.principalType"] = filter.PrincipalType
+	}
+	if filter.AccountName != "" {
+		query["inventory.accountName"] = filter.AccountName
+	}
+	if filter.Environment != "" {
+		query["inventory.environment"] = filter.Environment
+	}
+	if filter.Team != "" {
+		query["inventory.team"] = filter.Team
+	}
+	if filter.DataClassification != "" {
+		query["inventory.dataClassification"] = filter.DataClassification
+	}
+	if filter.ActionCategory != "" {
+		query["action.category"] = filter.ActionCategory
+	}
+	if filter.ResourceARN != "" || filter.ResourceType != "" || filter.ResourceID != "" {
+		// Los criterios deben cumplirse en el mismo recurso
+		resource := bson.M{}
+		if filter.ResourceARN != "" {
+			resource["arn"] = filter.ResourceARN
+		}
+		if filter.ResourceType != "" {
+			resource["type"] = filter.ResourceType
+		}
+		if filter.ResourceID != "" {
+			resource["id"] = filter.ResourceID
+		}
+		query["resources"] = bson.M{"$elemMatch": resource}
+	}
+	if filter.Country != "" {
+		query["enrichment.country"] = filter.Country
+	}
+	if filter.ASN != "" {
+		// "16509" y "AS16509" son equivalentes
+		if asn := models.NormalizeASN(filter.ASN); asn != "" {
+			query["enrichment.asn"] = asn
+		} else {
+			query["enrichment.asn"] = filter.ASN
+		}
+	}
+	if filter.ASOrg != "" {
+		query["enrichment.asOrg"] = filter.ASOrg
+	}
+	if filter.UATool != "" {
+		query["userAgentInfo.tool"] = strings.ToLower(filter.UATool)
+	}
+	if filter.UACategory != "" {
+		query["userAgentInfo.category"] = filter.UACategory
+	}
+	if filter.UASDK != "" {
+		query["userAgentInfo.sdk"] = strings.ToLower(filter.UASDK)
+	}
+	if filter.NetworkType != "" {
+		query["enrichment.networkType"] = filter.NetworkType
+	}
+	if filter.Site != "" {
+		query["enrichment.site"] = filter.Site
+	}
+	if filter.Hostname != "" {
+		hostname := strings.ToLower(filter.Hostname)
+		if suffix, ok := strings.CutPrefix(hostname, "*"); ok {
+			query["enrichment.hostname"] = bson.M{"$regex": regexp.QuoteMeta(suffix) + "$"}
+		} else {
+			query["enrichment.hostname"] = hostname
+		}
+	}
+	// Los indicadores se guardan solo cuando son verdaderos, así que false equivale a "distinto de true"
+	for field, value := range map[string]*bool{
+		"enrichment.isTor":     filter.IsTor,
+		"enrichment.isVPN":     filter.IsVPN,
+		"enrichment.isHosting": filter.IsHosting,
+		"identity.isRoot":      filter.IsRoot,
+		"action.sensitive":     filter.Sensitive,
+	} {
+		if value == nil {
+			continue
+		}
+		if *value {
+			query[field] = true
+		} else {
+			query[field] = bson.M{"$ne": true}
+		}
+	}
+	// action.readOnly se guarda siempre; false solo debe devolver eventos clasificados
+	if filter.ReadOnly != nil {
+		query["action.readOnly"] = *filter.ReadOnly
+	}
+	if filter.MinRisk > 0 {
+		query["risk.score"] = bson.M{"$gte": filter.MinRisk}
+	}
+
+	if filter.From != nil || filter.To != nil {
+		timeRange := bson.M{}
+		if filter.From != nil {
+			timeRange["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			timeRange["$lte"] = *filter.To
+		}
+		query["eventTime"] = timeRange
+	}
+
+	return query
+}
+
+// buildEventSort devuelve el ordenamiento de la búsqueda: por fecha de evento descendente o,
+// con SortBy "risk", por puntaje de riesgo descendente y luego por fecha.
+func buildEventSort(filter *models.EventFilter) bson.D {
+	if filter != nil && filter.SortBy == models.EventSortRisk {
+		return bson.D{{Key: "risk.score", Value: -1}, {Key: "eventTime", Value: -1}}
+	}
+	return bson.D{{Key: "eventTime", Value: -1}}
+}
+
+// SearchLogs recupera los eventos enriquecidos que cumplen con el filtro indicado,
+// ordenados por fecha de evento descendente (o por riesgo, según filter.SortBy).
+func (m *EnrichmentMongoRepository) SearchLogs(ctx context.Context, filter *models.EventFilter) ([]*models.EnrichedEventRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	limit := defaultSearchLimit
+	var skip int64
+	if filter != nil {
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+		skip = filter.Skip
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(buildEventSort(filter))
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+
+	cursor, err := m.mongoInstance.Collection.Find(ctx, buildEventFilter(filter), findOptions)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al buscar eventos enriquecidos en MongoDB: %v", err)
+		return nil, fmt.Errorf("error al buscar eventos enriquecidos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []*models.EnrichedEventRecord{}
+	if err := cursor.All(ctx, &events); err != nil {
+		logger.ErrorLog.Printf("Error al decodificar eventos de MongoDB: %v", err)
+		return nil, fmt.Errorf("error al decodificar eventos: %w", err)
+	}
+
+	logger.InfoLog.Printf("Búsqueda de eventos enriquecidos completada: %d resultados.", len(events))
+	return events, nil
+}
+
+// buildProjection traduce la lista de campos solicitados a una proyección de MongoDB.
+// El identificador se expone como "id" en JSON, por lo que se traduce a "_id". La
+// proyección siempre incluye "_id" de forma explícita: una proyección vacía devolvería los
+// documentos completos.
+func buildProjection(fields []string) bson.D {
+	projection := bson.D{}
+	includeID := false
+	for _, field := range fields {
+		if field == "id" || field == "_id" {
+			includeID = true
+			continue
+		}
+		projection = append(projection, bson.E{Key: field, Value: 1})
+	}
+	if includeID {
+		projection = append(projection, bson.E{Key: "_id", Value: 1})
+	} else {
+		projection = append(projection, bson.E{Key: "_id", Value: 0})
+	}
+	return projection
+}
+
+// SearchLogsProjection busca eventos enriquecidos aplicando una proyección de campos y
+// devuelve los documentos como mapas, ya que no contienen todos los campos del modelo.
+func (m *EnrichmentMongoRepository) SearchLogsProjection(ctx context.Context, filter *models.EventFilter, fields []string) ([]map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	limit := defaultSearchLimit
+	var skip int64
+	if filter != nil {
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+		skip = filter.Skip
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(buildEventSort(filter))
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+	if len(fields) > 0 {
+		findOptions.SetProjection(buildProjection(fields))
+	}
+
+	cursor, err := m.mongoInstance.Collection.Find(ctx, buildEventFilter(filter), findOptions)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al buscar eventos enriquecidos con proyección en MongoDB: %v", err)
+		return nil, fmt.Errorf("error al buscar eventos enriquecidos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var raw []bson.M
+	if err := cursor.All(ctx, &raw); err != nil {
+		logger.ErrorLog.Printf("Error al decodificar eventos de MongoDB: %v", err)
+		return nil, fmt.Errorf("error al decodificar eventos: %w", err)
+	}
+
+	documents := make([]map[string]interface{}, 0, len(raw))
+	for _, doc := range raw {
+		if id, ok := doc["_id"]; ok {
+			doc["id"] = id
+			delete(doc, "_id")
+		}
+		documents = append(documents, doc)
+	}
+
+	logger.InfoLog.Printf("Búsqueda de eventos con proyección completada: %d resultados.", len(documents))
+	return documents, nil
+}
+
+// GetLogByID recupera un evento enriquecido por su ObjectID en formato hexadecimal.
+// Devuelve mongo.ErrNoDocuments si el identificador no existe.
+func (m *EnrichmentMongoRepository) GetLogByID(ctx context.Context, id string) (*models.EnrichedEventRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("identificador de evento inválido: %w", err)
+	}
+
+	var event models.EnrichedEventRecord
+	err = m.mongoInstance.Collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&event)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, err
+		}
+		logger.ErrorLog.Printf("Error al obtener evento %s de MongoDB: %v", id, err)
+		return nil, fmt.Errorf("error al obtener evento: %w", err)
+	}
+	return &event, nil
+}
+
+// CountLogs cuenta los eventos enriquecidos que cumplen con el filtro indicado.
+func (m *EnrichmentMongoRepository) CountLogs(ctx context.Context, filter *models.EventFilter) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := m.mongoInstance.Collection.CountDocuments(ctx, buildEventFilter(filter))
+	if err != nil {
+		logger.ErrorLog.Printf("Error al contar eventos enriquecidos en MongoDB: %v", err)
+		return 0, fmt.Errorf("error al contar eventos enriquecidos: %w", err)
+	}
+	return count, nil
+}
+
+// AggregateLogs agrupa los eventos que cumplen con el filtro por el campo indicado y devuelve
+// los grupos ordenados por cantidad descendente.
+func (m *EnrichmentMongoRepository) AggregateLogs(ctx context.Context, filter *models.EventFilter, field models.AggregationField, limit int64) ([]*models.AggregateBucket, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: buildEventFilter(filter)}},
+	}
+	if field.Array {
+		unwind := field.Path
+		if field.Unwind != "" {
+			unwind = field.Unwind
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$unwind", Value: "$" + unwind}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + field.Path},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+
+	cursor, err := m.mongoInstance.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al agregar eventos enriquecidos por %s en MongoDB: %v", field.Path, err)
+		return nil, fmt.Errorf("error al agregar eventos enriquecidos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var raw []bson.M
+	if err := cursor.All(ctx, &raw); err != nil {
+		logger.ErrorLog.Printf("Error al decodificar agregación de MongoDB: %v", err)
+		return nil, fmt.Errorf("error al decodificar agregación: %w", err)
+	}
+
+	buckets := make([]*models.AggregateBucket, 0, len(raw))
+	for _, doc := range raw {
+		bucket := &models.AggregateBucket{}
+		if doc["_id"] != nil {
+			bucket.Key = fmt.Sprint(doc["_id"])
+		}
+		switch count := doc["count"].(type) {
+		case int32:
+			bucket.Count = int64(count)
+		case int64:
+			bucket.Count = count
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
@@ -0,0 +1,33 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildProjection(t *testing.T) {
+	cases := map[string]struct {
+		fields []string
+		want   bson.D
+	}{
+		"solo id": {
+			fields: []string{"id"},
+			want:   bson.D{{Key: "_id", Value: 1}},
+		},
+		"campos con id": {
+			fields: []string{"eventName", "id"},
+			want:   bson.D{{Key: "eventName", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		"campos sin id": {
+			fields: []string{"eventName", "userIdentity.arn"},
+			want:   bson.D{{Key: "eventName", Value: 1}, {Key: "userIdentity.arn", Value: 1}, {Key: "_id", Value: 0}},
+		},
+	}
+	for name, tc := range cases {
+		if got := buildProjection(tc.fields); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: proyección = %v, se esperaba %v", name, got, tc.want)
+		}
+	}
+}
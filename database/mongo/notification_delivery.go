@@ -0,0 +1,109 @@
+package mongo
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationDeliveryMongoRepository implementa la interfaz NotificationDeliveryRepository para MongoDB.
+type NotificationDeliveryMongoRepository struct {
+	mongoInstance *MongoInstance
+}
+
+// NewNotificationDeliveryMongoRepository crea una nueva instancia de NotificationDeliveryMongoRepository
+// y asegura los índices de consulta de la colección.
+func NewNotificationDeliveryMongoRepository(client *mongo.Client, dbName, collectionName string) *NotificationDeliveryMongoRepository {
+	logger.InfoLog.Printf("[DEBUG] Colección de entregas de notificaciones: '%s.%s'", dbName, collectionName)
+	collection := client.Database(dbName).Collection(collectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "finding_id", Value: 1}}},
+		{Keys: bson.D{{Key: "channel", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear los índices de la colección de entregas: %v", err)
+	}
+
+	return &NotificationDeliveryMongoRepository{
+		mongoInstance: &MongoInstance{
+			Client:     client,
+			Collection: collection,
+		},
+	}
+}
+
+// InsertDelivery registra el resultado de una entrega.
+func (m *NotificationDeliveryMongoRepository) InsertDelivery(ctx context.Context, delivery *models.NotificationDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := m.mongoInstance.Collection.InsertOne(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("error al registrar la entrega de notificación: %w", err)
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		delivery.ID = id
+	}
+	return nil
+}
+
+// ListDeliveries busca entregas por detección, canal y estado, de la más reciente a la más antigua.
+func (m *NotificationDeliveryMongoRepository) ListDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]*models.NotificationDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	limit := defaultSearchLimit
+	var skip int64
+	if filter != nil {
+		if filter.FindingID != "" {
+			findingID, err := primitive.ObjectIDFromHex(filter.FindingID)
+			if err != nil {
+				return nil, fmt.Errorf("identificador de detección inválido: %w", err)
+			}
+			query["finding_id"] = findingID
+		}
+		if filter.Channel != "" {
+			query["channel"] = filter.Channel
+		}
+		if filter.Status != "" {
+			query["status"] = filter.Status
+		}
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+		skip = filter.Skip
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+	findOptions.SetLimit(limit)
+	findOptions.SetSkip(skip)
+
+	cursor, err := m.mongoInstance.Collection.Find(ctx, query, findOptions)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al buscar entregas de notificaciones en MongoDB: %v", err)
+		return nil, fmt.Errorf("error al buscar entregas de notificaciones: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []*models.NotificationDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("error al decodificar entregas de notificaciones: %w", err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,124 @@
+package mongo
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SuppressionMongoRepository implementa la interfaz SuppressionRepository para MongoDB.
+// Las supresiones vencidas se conservan como registro de auditoría.
+type SuppressionMongoRepository struct {
+	mongoInstance *MongoInstance
+}
+
+// NewSuppressionMongoRepository crea una nueva instancia de SuppressionMongoRepository.
+func NewSuppressionMongoRepository(client *mongo.Client, dbName, collectionName string) *SuppressionMongoRepository {
+	logger.InfoLog.Printf("[DEBUG] Colección de supresiones: '%s.%s'", dbName, collectionName)
+	collection := client.Database(dbName).Collection(collectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "expires_at", Value: -1}}})
+	if err != nil {
+		logger.ErrorLog.Printf("Error al crear los índices de la colección de supresiones: %v", err)
+	}
+
+	return &SuppressionMongoRepository{
+		mongoInstance: &MongoInstance{
+			Client:     client,
+			Collection: collection,
+		},
+	}
+}
+
+// InsertSuppression inserta una nueva supresión.
+func (m *SuppressionMongoRepository) InsertSuppression(ctx context.Context, suppression *models.Suppression) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := m.mongoInstance.Collection.InsertOne(ctx, suppression)
+	if err != nil {
+		return fmt.Errorf("error al insertar supresión: %w", err)
+	}
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		suppression.ID = id
+	}
+	return nil
+}
+
+// ListSuppressions lista las supresiones, de la más reciente a la más antigua. Si activeAt
+// no es nil, solo devuelve las vigentes en ese instante.
+func (m *SuppressionMongoRepository) ListSuppressions(ctx context.Context, activeAt *time.Time) ([]*models.Suppression, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if activeAt != nil {
+		query["expires_at"] = bson.M{"$gt": *activeAt}
+	}
+
+	cursor, err := m.mongoInstance.Collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(maxSearchLimit))
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar supresiones: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	suppressions := []*models.Suppression{}
+	if err := cursor.All(ctx, &suppressions); err != nil {
+		return nil, fmt.Errorf("error al decodificar supresiones: %w", err)
+	}
+	return suppressions, nil
+}
+
+// ExpireSuppression adelanta el vencimiento de una supresión vigente al instante indicado.
+// Devuelve mongo.ErrNoDocuments si no existe o ya estaba vencida.
+func (m *SuppressionMongoRepository) ExpireSuppression(ctx context.Context, id string, at time.Time) (*models.Suppression, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("identificador de supresión inválido: %w", err)
+	}
+
+	var suppression models.Suppression
+	err = m.mongoInstance.Collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": objectID, "expires_at": bson.M{"$gt": at}},
+		bson.M{"$set": bson.M{"expires_at": at}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&suppression)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error al vencer la supresión: %w", err)
+	}
+	return &suppression, nil
+}
+
+// RecordSuppressionHit incrementa el contador de detecciones suprimidas.
+func (m *SuppressionMongoRepository) RecordSuppressionHit(ctx context.Context, id string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("identificador de supresión inválido: %w", err)
+	}
+	_, err = m.mongoInstance.Collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{
+		"$inc": bson.M{"suppressed_count": 1},
+		"$set": bson.M{"last_suppressed_at": at},
+	})
+	if err != nil {
+		return fmt.Errorf("error al registrar la supresión %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package postgresql
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// InventoryPostgresRepository implementa la interfaz InventoryRepository para PostgreSQL.
+type InventoryPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewInventoryPostgresRepository crea una nueva instancia de InventoryPostgresRepository.
+// Recibe un *sql.DB ya inicializado para compartir la conexión con el repositorio de autenticación.
+func NewInventoryPostgresRepository(db *sql.DB) *InventoryPostgresRepository {
+	return &InventoryPostgresRepository{db: db}
+}
+
+const inventoryColumns = `id, kind, key, name, environment, team, data_classification, labels, source, created_at, updated_at`
+
+// upsertInventoryQuery inserta el elemento o, si ya existe uno con el mismo tipo y clave, lo reemplaza.
+const upsertInventoryQuery = `INSERT INTO inventory_items (kind, key, name, environment, team, data_classification, labels, source, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+ON CONFLICT (kind, key) DO UPDATE SET name = EXCLUDED.name, environment = EXCLUDED.environment, team = EXCLUDED.team,
+    data_classification = EXCLUDED.data_classification, labels = EXCLUDED.labels, source = EXCLUDED.source, updated_at = EXCLUDED.updated_at
+RETURNING id, created_at`
+
+// sqlExecutor abstrae *sql.DB y *sql.Tx para reutilizar las consultas dentro y fuera de una transacción.
+type sqlExecutor interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func scanInventoryItem(row rowScanner) (*models.InventoryItem, error) {
+	item := &models.InventoryItem{}
+	var labels []byte
+	err := row.Scan(&item.ID, &item.Kind, &item.Key, &item.Name, &item.Environment, &item.Team, &item.DataClassification, &labels, &item.Source, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(labels, &item.Labels); err != nil {
+		return nil, fmt.Errorf("error al decodificar las etiquetas del elemento de inventario: %w", err)
+	}
+	return item, nil
+}
+
+func upsertInventoryItem(ctx context.Context, exec sqlExecutor, item *models.InventoryItem) error {
+	labels, err := json.Marshal(item.Labels)
+	if err != nil {
+		return fmt.Errorf("error al serializar las etiquetas del elemento de inventario: %w", err)
+	}
+	if item.Labels == nil {
+		labels = []byte("{}")
+	}
+	item.UpdatedAt = time.Now()
+	return exec.QueryRowContext(ctx, upsertInventoryQuery, item.Kind, item.Key, item.Name, item.Environment, item.Team, item.DataClassification, labels, item.Source, item.UpdatedAt).Scan(&item.ID, &item.CreatedAt)
+}
+
+// UpsertInventoryItem crea o reemplaza un elemento del inventario.
+func (r *InventoryPostgresRepository) UpsertInventoryItem(ctx context.Context, item *models.InventoryItem) error {
+	if err := upsertInventoryItem(ctx, r.db, item); err != nil {
+		logger.ErrorLog.Printf("Error al guardar el elemento de inventario %s %s: %v", item.Kind, item.Key, err)
+		return fmt.Errorf("error al guardar elemento de inventario: %w", err)
+	}
+	logger.InfoLog.Printf("Elemento de inventario %s %s guardado en DB con ID: %d", item.Kind, item.Key, item.ID)
+	return nil
+}
+
+// GetInventoryItem recupera un elemento del inventario por tipo y clave.
+func (r *InventoryPostgresRepository) GetInventoryItem(ctx context.Context, kind, key string) (*models.InventoryItem, error) {
+	query := `SELECT ` + inventoryColumns + ` FROM inventory_items WHERE kind = $1 AND key = $2`
+	item, err := scanInventoryItem(r.db.QueryRowContext(ctx, query, kind, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		logger.ErrorLog.Printf("Error al obtener el elemento de inventario %s %s: %v", kind, key, err)
+		return nil, fmt.Errorf("error al obtener elemento de inventario: %w", err)
+	}
+	return item, nil
+}
+
+// ListInventoryItems recupera los elementos del inventario, todos o solo los del tipo indicado.
+func (r *InventoryPostgresRepository) ListInventoryItems(ctx context.Context, kind string) ([]*models.InventoryItem, error) {
+	query := `SELECT ` + inventoryColumns + ` FROM inventory_items WHERE $1 = '' OR kind = $1 ORDER BY kind, key`
+	rows, err := r.db.QueryContext(ctx, query, kind)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al consultar el inventario: %v", err)
+		return nil, fmt.Errorf("error al consultar inventario: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.InventoryItem{}
+	for rows.Next() {
+		item, err := scanInventoryItem(rows)
+		if err != nil {
+			logger.ErrorLog.Printf("Error al escanear elemento de inventario: %v", err)
+			return nil, fmt.Errorf("error al escanear elemento de inventario: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al recorrer el inventario: %w", err)
+	}
+	return items, nil
+}
+
+// DeleteInventoryItem elimina un elemento del inventario. Devuelve false si no existía.
+func (r *InventoryPostgresRepository) DeleteInventoryItem(ctx context.Context, kind, key string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM inventory_items WHERE kind = $1 AND key = $2`, kind, key)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al eliminar el elemento de inventario %s %s: %v", kind, key, err)
+		return false, fmt.Errorf("error al eliminar elemento de inventario: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar elemento de inventario: %w", err)
+	}
+	if deleted > 0 {
+		logger.InfoLog.Printf("Elemento de inventario %s %s eliminado de la DB.", kind, key)
+	}
+	return deleted > 0, nil
+}
+
+// ReplaceInventorySource reemplaza en una transacción los elementos importados desde source:
+// elimina los que ya no figuran en items y crea o actualiza el resto. Un elemento con la misma
+// clave creado desde otro origen pasa a pertenecer a source.
+func (r *InventoryPostgresRepository) ReplaceInventorySource(ctx context.Context, source string, items []*models.InventoryItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar la importación del inventario: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM inventory_items WHERE source = $1`, source); err != nil {
+		logger.ErrorLog.Printf("Error al eliminar el inventario importado desde %s: %v", source, err)
+		return fmt.Errorf("error al reemplazar el inventario de %s: %w", source, err)
+	}
+	for _, item := range items {
+		item.Source = source
+		if err := upsertInventoryItem(ctx, tx, item); err != nil {
+			logger.ErrorLog.Printf("Error al importar el elemento de inventario %s %s desde %s: %v", item.Kind, item.Key, source, err)
+			return fmt.Errorf("error al importar elemento de inventario %s %s: %w", item.Kind, item.Key, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar la importación del inventario de %s: %w", source, err)
+	}
+	logger.InfoLog.Printf("Inventario de %s importado: %d elementos.", source, len(items))
+	return nil
+}
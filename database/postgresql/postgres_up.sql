@@ -1,6 +1,9 @@
 --CREATE DATABASE IF NOT EXISTS authdb;
 \c authdb;
 
+DROP TABLE IF EXISTS inventory_items;
+DROP TABLE IF EXISTS saved_search_results;
+DROP TABLE IF EXISTS saved_searches;
 DROP TABLE IF EXISTS tokens;
 DROP TABLE IF EXISTS users;
 
@@ -37,6 +40,54 @@ CREATE TABLE public.tokens (
     updated_at timestamp without time zone NOT NULL DEFAULT now()  -- Usar now() para default
 );
 
+-- Búsquedas guardadas por usuario. filter contiene el models.EventFilter serializado
+-- y schedule una expresión cron opcional para su ejecución en segundo plano.
+CREATE TABLE public.saved_searches (
+    id serial NOT NULL PRIMARY KEY,
+    uuid character varying(36) NOT NULL UNIQUE,
+    user_id integer NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    name character varying(128) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    filter jsonb NOT NULL DEFAULT '{}',
+    schedule character varying(64),
+    last_run_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL DEFAULT now(),
+    updated_at timestamp without time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX saved_searches_user_id_idx ON public.saved_searches (user_id);
+
+-- Instantáneas de cada ejecución con la diferencia respecto a la anterior.
+CREATE TABLE public.saved_search_results (
+    id serial NOT NULL PRIMARY KEY,
+    saved_search_id integer NOT NULL REFERENCES public.saved_searches(id) ON DELETE CASCADE,
+    run_at timestamp without time zone NOT NULL DEFAULT now(),
+    total integer NOT NULL DEFAULT 0,
+    event_ids jsonb NOT NULL DEFAULT '[]',
+    added_ids jsonb NOT NULL DEFAULT '[]',
+    removed_ids jsonb NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX saved_search_results_search_run_idx ON public.saved_search_results (saved_search_id, run_at DESC);
+
+-- Inventario de cuentas y recursos. key es el número de cuenta o el ARN del recurso y
+-- source el archivo del que se importó el elemento o 'api'.
+CREATE TABLE public.inventory_items (
+    id serial NOT NULL PRIMARY KEY,
+    kind character varying(16) NOT NULL,
+    key character varying(2048) NOT NULL,
+    name character varying(256) NOT NULL DEFAULT '',
+    environment character varying(64) NOT NULL DEFAULT '',
+    team character varying(128) NOT NULL DEFAULT '',
+    data_classification character varying(64) NOT NULL DEFAULT '',
+    labels jsonb NOT NULL DEFAULT '{}',
+    source character varying(512) NOT NULL DEFAULT 'api',
+    created_at timestamp without time zone NOT NULL DEFAULT now(),
+    updated_at timestamp without time zone NOT NULL DEFAULT now(),
+    UNIQUE (kind, key)
+);
+
+CREATE INDEX inventory_items_source_idx ON public.inventory_items (source);
 
 -- TREVOR
 --   CREATE TABLE
@@ -0,0 +1,242 @@
+package postgresql
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SavedSearchPostgresRepository implementa la interfaz SavedSearchRepository para PostgreSQL.
+type SavedSearchPostgresRepository struct {
+	db *sql.DB
+}
+
+// NewSavedSearchPostgresRepository crea una nueva instancia de SavedSearchPostgresRepository.
+// Recibe un *sql.DB ya inicializado para compartir la conexión con el repositorio de autenticación.
+func NewSavedSearchPostgresRepository(db *sql.DB) *SavedSearchPostgresRepository {
+	return &SavedSearchPostgresRepository{db: db}
+}
+
+const savedSearchColumns = `id, uuid, user_id, name, description, filter, schedule, last_run_at, created_at, updated_at`
+
+// rowScanner abstrae *sql.Row y *sql.Rows para reutilizar la lógica de escaneo.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSavedSearch(row rowScanner) (*models.SavedSearch, error) {
+	search := &models.SavedSearch{}
+	var (
+		filterRaw []byte
+		schedule  sql.NullString
+		lastRun   sql.NullTime
+	)
+	err := row.Scan(&search.ID, &search.UUID, &search.UserID, &search.Name, &search.Description, &filterRaw, &schedule, &lastRun, &search.CreatedAt, &search.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filterRaw, &search.Filter); err != nil {
+		return nil, fmt.Errorf("error al decodificar el filtro de la búsqueda guardada: %w", err)
+	}
+	search.Schedule = schedule.String
+	if lastRun.Valid {
+		search.LastRunAt = &lastRun.Time
+	}
+	return search, nil
+}
+
+// InsertSavedSearch inserta una nueva búsqueda guardada en la base de datos.
+func (r *SavedSearchPostgresRepository) InsertSavedSearch(ctx context.Context, search *models.SavedSearch) error {
+	filterRaw, err := json.Marshal(search.Filter)
+	if err != nil {
+		return fmt.Errorf("error al serializar el filtro de la búsqueda guardada: %w", err)
+	}
+
+	query := `INSERT INTO saved_searches (uuid, user_id, name, description, filter, schedule, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8) RETURNING id`
+	search.CreatedAt = time.Now()
+	search.UpdatedAt = time.Now()
+
+	err = r.db.QueryRowContext(ctx, query, search.UUID, search.UserID, search.Name, search.Description, filterRaw, search.Schedule, search.CreatedAt, search.UpdatedAt).Scan(&search.ID)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al insertar búsqueda guardada en la base de datos: %v", err)
+		return fmt.Errorf("error al insertar búsqueda guardada: %w", err)
+	}
+	logger.InfoLog.Printf("Búsqueda guardada insertada en DB con ID: %d y UUID: %s", search.ID, search.UUID)
+	return nil
+}
+
+// GetSavedSearchByUUID recupera una búsqueda guardada por su UUID.
+func (r *SavedSearchPostgresRepository) GetSavedSearchByUUID(ctx context.Context, uuid string) (*models.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE uuid = $1`
+	search, err := scanSavedSearch(r.db.QueryRowContext(ctx, query, uuid))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.InfoLog.Printf("Búsqueda guardada con UUID %s no encontrada en la DB.", uuid)
+			return nil, sql.ErrNoRows
+		}
+		logger.ErrorLog.Printf("Error al obtener búsqueda guardada por UUID %s desde la base de datos: %v", uuid, err)
+		return nil, fmt.Errorf("error al obtener búsqueda guardada por UUID: %w", err)
+	}
+	return search, nil
+}
+
+// GetSavedSearchesByUserID recupera todas las búsquedas guardadas de un usuario.
+func (r *SavedSearchPostgresRepository) GetSavedSearchesByUserID(ctx context.Context, userID int) ([]*models.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE user_id = $1 ORDER BY created_at DESC`
+	return r.querySavedSearches(ctx, query, userID)
+}
+
+// GetScheduledSavedSearches recupera todas las búsquedas guardadas que tienen programación.
+func (r *SavedSearchPostgresRepository) GetScheduledSavedSearches(ctx context.Context) ([]*models.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE schedule IS NOT NULL`
+	return r.querySavedSearches(ctx, query)
+}
+
+func (r *SavedSearchPostgresRepository) querySavedSearches(ctx context.Context, query string, args ...interface{}) ([]*models.SavedSearch, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al consultar búsquedas guardadas: %v", err)
+		return nil, fmt.Errorf("error al consultar búsquedas guardadas: %w", err)
+	}
+	defer rows.Close()
+
+	searches := []*models.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			logger.ErrorLog.Printf("Error al escanear búsqueda guardada: %v", err)
+			return nil, fmt.Errorf("error al escanear búsqueda guardada: %w", err)
+		}
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al recorrer búsquedas guardadas: %w", err)
+	}
+	return searches, nil
+}
+
+// UpdateSavedSearch actualiza el nombre, la descripción, el filtro y la programación de una búsqueda guardada.
+func (r *SavedSearchPostgresRepository) UpdateSavedSearch(ctx context.Context, search *models.SavedSearch) error {
+	filterRaw, err := json.Marshal(search.Filter)
+	if err != nil {
+		return fmt.Errorf("error al serializar el filtro de la búsqueda guardada: %w", err)
+	}
+
+	query := `UPDATE saved_searches SET name = $1, description = $2, filter = $3, schedule = NULLIF($4, ''), updated_at = $5 WHERE id = $6`
+	search.UpdatedAt = time.Now()
+	_, err = r.db.ExecContext(ctx, query, search.Name, search.Description, filterRaw, search.Schedule, search.UpdatedAt, search.ID)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al actualizar búsqueda guardada %d: %v", search.ID, err)
+		return fmt.Errorf("error al actualizar búsqueda guardada: %w", err)
+	}
+	logger.InfoLog.Printf("Búsqueda guardada %s actualizada en DB.", search.UUID)
+	return nil
+}
+
+// UpdateSavedSearchLastRun registra la fecha de la última ejecución de una búsqueda guardada.
+func (r *SavedSearchPostgresRepository) UpdateSavedSearchLastRun(ctx context.Context, id int, lastRun time.Time) error {
+	query := `UPDATE saved_searches SET last_run_at = $1 WHERE id = $2`
+	if _, err := r.db.ExecContext(ctx, query, lastRun, id); err != nil {
+		logger.ErrorLog.Printf("Error al actualizar la última ejecución de la búsqueda guardada %d: %v", id, err)
+		return fmt.Errorf("error al actualizar la última ejecución: %w", err)
+	}
+	return nil
+}
+
+// DeleteSavedSearch elimina una búsqueda guardada y, en cascada, sus resultados.
+func (r *SavedSearchPostgresRepository) DeleteSavedSearch(ctx context.Context, id int) error {
+	query := `DELETE FROM saved_searches WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		logger.ErrorLog.Printf("Error al eliminar búsqueda guardada %d: %v", id, err)
+		return fmt.Errorf("error al eliminar búsqueda guardada: %w", err)
+	}
+	logger.InfoLog.Printf("Búsqueda guardada %d eliminada de la DB.", id)
+	return nil
+}
+
+// InsertSavedSearchResult inserta la instantánea de una ejecución de búsqueda guardada.
+func (r *SavedSearchPostgresRepository) InsertSavedSearchResult(ctx context.Context, result *models.SavedSearchResult) error {
+	eventIDs, err := json.Marshal(result.EventIDs)
+	if err != nil {
+		return fmt.Errorf("error al serializar los eventos del resultado: %w", err)
+	}
+	added, err := json.Marshal(result.Added)
+	if err != nil {
+		return fmt.Errorf("error al serializar los eventos añadidos: %w", err)
+	}
+	removed, err := json.Marshal(result.Removed)
+	if err != nil {
+		return fmt.Errorf("error al serializar los eventos eliminados: %w", err)
+	}
+
+	query := `INSERT INTO saved_search_results (saved_search_id, run_at, total, event_ids, added_ids, removed_ids) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	err = r.db.QueryRowContext(ctx, query, result.SavedSearchID, result.RunAt, result.Total, eventIDs, added, removed).Scan(&result.ID)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al insertar resultado de búsqueda guardada %d: %v", result.SavedSearchID, err)
+		return fmt.Errorf("error al insertar resultado de búsqueda guardada: %w", err)
+	}
+	return nil
+}
+
+const savedSearchResultColumns = `id, saved_search_id, run_at, total, event_ids, added_ids, removed_ids`
+
+func scanSavedSearchResult(row rowScanner) (*models.SavedSearchResult, error) {
+	result := &models.SavedSearchResult{}
+	var eventIDs, added, removed []byte
+	if err := row.Scan(&result.ID, &result.SavedSearchID, &result.RunAt, &result.Total, &eventIDs, &added, &removed); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventIDs, &result.EventIDs); err != nil {
+		return nil, fmt.Errorf("error al decodificar los eventos del resultado: %w", err)
+	}
+	if err := json.Unmarshal(added, &result.Added); err != nil {
+		return nil, fmt.Errorf("error al decodificar los eventos añadidos: %w", err)
+	}
+	if err := json.Unmarshal(removed, &result.Removed); err != nil {
+		return nil, fmt.Errorf("error al decodificar los eventos eliminados: %w", err)
+	}
+	return result, nil
+}
+
+// GetSavedSearchResults recupera las últimas instantáneas de una búsqueda guardada.
+func (r *SavedSearchPostgresRepository) GetSavedSearchResults(ctx context.Context, savedSearchID int, limit int) ([]*models.SavedSearchResult, error) {
+	query := `SELECT ` + savedSearchResultColumns + ` FROM saved_search_results WHERE saved_search_id = $1 ORDER BY run_at DESC LIMIT $2`
+	rows, err := r.db.QueryContext(ctx, query, savedSearchID, limit)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al consultar resultados de la búsqueda guardada %d: %v", savedSearchID, err)
+		return nil, fmt.Errorf("error al consultar resultados de búsqueda guardada: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*models.SavedSearchResult{}
+	for rows.Next() {
+		result, err := scanSavedSearchResult(rows)
+		if err != nil {
+			logger.ErrorLog.Printf("Error al escanear resultado de búsqueda guardada: %v", err)
+			return nil, fmt.Errorf("error al escanear resultado de búsqueda guardada: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al recorrer resultados de búsqueda guardada: %w", err)
+	}
+	return results, nil
+}
+
+// GetLatestSavedSearchResult recupera la instantánea más reciente de una búsqueda guardada.
+func (r *SavedSearchPostgresRepository) GetLatestSavedSearchResult(ctx context.Context, savedSearchID int) (*models.SavedSearchResult, error) {
+	query := `SELECT ` + savedSearchResultColumns + ` FROM saved_search_results WHERE saved_search_id = $1 ORDER BY run_at DESC LIMIT 1`
+	result, err := scanSavedSearchResult(r.db.QueryRowContext(ctx, query, savedSearchID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		logger.ErrorLog.Printf("Error al obtener el último resultado de la búsqueda guardada %d: %v", savedSearchID, err)
+		return nil, fmt.Errorf("error al obtener el último resultado de búsqueda guardada: %w", err)
+	}
+	return result, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -69,6 +70,11 @@ func LoadConfig() (*Config, error) {
 		config.MongoDBConfig.Password = os.Getenv("MONGO_PASSWORD")
 		config.MongoDBConfig.Database = os.Getenv("MONGO_DATABASE")
 		config.MongoDBConfig.Collection = os.Getenv("MONGO_COLLECTION")
+		config.MongoDBConfig.FindingsCollection = os.Getenv("MONGO_FINDINGS_COLLECTION")
+		config.MongoDBConfig.CorrelationStateCollection = os.Getenv("MONGO_CORRELATION_STATE_COLLECTION")
+		config.MongoDBConfig.DeliveriesCollection = os.Getenv("MONGO_DELIVERIES_COLLECTION")
+		config.MongoDBConfig.SuppressionsCollection = os.Getenv("MONGO_SUPPRESSIONS_COLLECTION")
+		config.MongoDBConfig.BaselinesCollection = os.Getenv("MONGO_BASELINES_COLLECTION")
 		mongoDBTimeout, _ := strconv.ParseInt(os.Getenv("MONGO_DB_TIMEOUT"), 10, 64)
 		config.MongoDBConfig.DBTimeout = time.Duration(mongoDBTimeout)
 
@@ -80,6 +86,113 @@ func LoadConfig() (*Config, error) {
 		tokenDuration, _ := strconv.ParseInt(os.Getenv("TOKEN_DURATION"), 10, 64)
 		config.AuthConfig.TokenDuration = time.Duration(tokenDuration)
 
+		config.GraphQLConfig.MaxDepth, _ = strconv.Atoi(os.Getenv("GRAPHQL_MAX_DEPTH"))
+		config.GraphQLConfig.MaxComplexity, _ = strconv.Atoi(os.Getenv("GRAPHQL_MAX_COMPLEXITY"))
+
+		config.GRPCConfig.Enabled, _ = strconv.ParseBool(os.Getenv("GRPC_ENABLED"))
+		config.GRPCConfig.Port, _ = strconv.Atoi(os.Getenv("GRPC_PORT"))
+
+		config.DetectionConfig.Enabled, _ = strconv.ParseBool(os.Getenv("DETECTION_ENABLED"))
+		config.DetectionConfig.RulesPath = os.Getenv("DETECTION_RULES_PATH")
+		config.DetectionConfig.SigmaPath = os.Getenv("DETECTION_SIGMA_PATH")
+		config.DetectionConfig.ImpossibleTravel.Enabled, _ = strconv.ParseBool(os.Getenv("IMPOSSIBLE_TRAVEL_ENABLED"))
+		config.DetectionConfig.ImpossibleTravel.MaxSpeedKmh, _ = strconv.ParseFloat(os.Getenv("IMPOSSIBLE_TRAVEL_MAX_SPEED_KMH"), 64)
+		config.DetectionConfig.ImpossibleTravel.MinDistanceKm, _ = strconv.ParseFloat(os.Getenv("IMPOSSIBLE_TRAVEL_MIN_DISTANCE_KM"), 64)
+		config.DetectionConfig.ImpossibleTravel.Window, _ = time.ParseDuration(os.Getenv("IMPOSSIBLE_TRAVEL_WINDOW"))
+		config.DetectionConfig.ImpossibleTravel.Severity = os.Getenv("IMPOSSIBLE_TRAVEL_SEVERITY")
+		if cidrs := os.Getenv("IMPOSSIBLE_TRAVEL_IGNORE_CIDRS"); cidrs != "" {
+			config.DetectionConfig.ImpossibleTravel.IgnoreCIDRs = strings.Split(cidrs, ",")
+		}
+
+		config.NotificationConfig.Enabled, _ = strconv.ParseBool(os.Getenv("NOTIFICATIONS_ENABLED"))
+		config.NotificationConfig.ChannelsPath = os.Getenv("NOTIFICATIONS_CHANNELS_PATH")
+		config.NotificationConfig.Workers, _ = strconv.Atoi(os.Getenv("NOTIFICATIONS_WORKERS"))
+		config.NotificationConfig.QueueSize, _ = strconv.Atoi(os.Getenv("NOTIFICATIONS_QUEUE_SIZE"))
+		config.NotificationConfig.MaxAttempts, _ = strconv.Atoi(os.Getenv("NOTIFICATIONS_MAX_ATTEMPTS"))
+		config.NotificationConfig.InitialBackoff, _ = time.ParseDuration(os.Getenv("NOTIFICATIONS_INITIAL_BACKOFF"))
+		config.NotificationConfig.MaxBackoff, _ = time.ParseDuration(os.Getenv("NOTIFICATIONS_MAX_BACKOFF"))
+		config.NotificationConfig.Timeout, _ = time.ParseDuration(os.Getenv("NOTIFICATIONS_TIMEOUT"))
+
+		config.BaselineConfig.Enabled, _ = strconv.ParseBool(os.Getenv("BASELINE_ENABLED"))
+		config.BaselineConfig.Window, _ = time.ParseDuration(os.Getenv("BASELINE_WINDOW"))
+		config.BaselineConfig.LearningPeriod, _ = time.ParseDuration(os.Getenv("BASELINE_LEARNING_PERIOD"))
+		if dimensions := os.Getenv("BASELINE_ALERT_DIMENSIONS"); dimensions != "" {
+			config.BaselineConfig.AlertDimensions = strings.Split(dimensions, ",")
+		}
+		config.BaselineConfig.Severity = os.Getenv("BASELINE_SEVERITY")
+
+		config.RiskConfig.Enabled, _ = strconv.ParseBool(os.Getenv("RISK_ENABLED"))
+		if weights := os.Getenv("RISK_WEIGHTS"); weights != "" {
+			// Formato: factor=peso separados por comas, p. ej. "root_identity=40,bad_ip=50"
+			config.RiskConfig.Weights = map[string]int{}
+			for _, pair := range strings.Split(weights, ",") {
+				factor, weight, _ := strings.Cut(pair, "=")
+				config.RiskConfig.Weights[strings.TrimSpace(factor)], _ = strconv.Atoi(strings.TrimSpace(weight))
+			}
+		}
+		if sources := os.Getenv("RISK_SENSITIVE_SOURCES"); sources != "" {
+			config.RiskConfig.SensitiveSources = strings.Split(sources, ",")
+		}
+		if codes := os.Getenv("RISK_ERROR_CODES"); codes != "" {
+			config.RiskConfig.ErrorCodes = strings.Split(codes, ",")
+		}
+		if countries := os.Getenv("RISK_EXPECTED_COUNTRIES"); countries != "" {
+			config.RiskConfig.ExpectedCountries = strings.Split(countries, ",")
+		}
+		if cidrs := os.Getenv("RISK_BAD_IP_CIDRS"); cidrs != "" {
+			config.RiskConfig.BadIPCIDRs = strings.Split(cidrs, ",")
+		}
+
+		config.EnrichersConfig.Attack.Enabled, _ = strconv.ParseBool(os.Getenv("ATTACK_ENABLED"))
+		config.EnrichersConfig.Attack.MappingPath = os.Getenv("ATTACK_MAPPING_PATH")
+		config.EnrichersConfig.ThreatIntel.Enabled, _ = strconv.ParseBool(os.Getenv("THREAT_INTEL_ENABLED"))
+		config.EnrichersConfig.ThreatIntel.FeedsPath = os.Getenv("THREAT_INTEL_FEEDS_PATH")
+		config.EnrichersConfig.ThreatIntel.ReloadInterval, _ = time.ParseDuration(os.Getenv("THREAT_INTEL_RELOAD_INTERVAL"))
+		if sites := os.Getenv("NETWORK_SITES"); sites != "" {
+			// Formato: cidr=sede separados por comas, p. ej. "10.1.0.0/16=madrid,10.2.0.0/16=bogota"
+			config.EnrichersConfig.Network.Sites = map[string]string{}
+			for _, pair := range strings.Split(sites, ",") {
+				cidr, site, _ := strings.Cut(pair, "=")
+				config.EnrichersConfig.Network.Sites[strings.TrimSpace(cidr)] = strings.TrimSpace(site)
+			}
+		}
+		config.EnrichersConfig.UserAgent.Enabled, _ = strconv.ParseBool(os.Getenv("USER_AGENT_ENABLED"))
+		config.EnrichersConfig.Identity.Enabled, _ = strconv.ParseBool(os.Getenv("IDENTITY_ENABLED"))
+		config.EnrichersConfig.Resources.Enabled, _ = strconv.ParseBool(os.Getenv("RESOURCES_ENABLED"))
+		config.EnrichersConfig.Actions.Enabled, _ = strconv.ParseBool(os.Getenv("ACTIONS_ENABLED"))
+		config.EnrichersConfig.Actions.MappingPath = os.Getenv("ACTIONS_MAPPING_PATH")
+		config.EnrichersConfig.ReverseDNS.Enabled, _ = strconv.ParseBool(os.Getenv("REVERSE_DNS_ENABLED"))
+		config.EnrichersConfig.ReverseDNS.Server = os.Getenv("REVERSE_DNS_SERVER")
+		config.EnrichersConfig.ReverseDNS.Workers, _ = strconv.Atoi(os.Getenv("REVERSE_DNS_WORKERS"))
+		config.EnrichersConfig.ReverseDNS.QueueSize, _ = strconv.Atoi(os.Getenv("REVERSE_DNS_QUEUE_SIZE"))
+		config.EnrichersConfig.ReverseDNS.Timeout, _ = time.ParseDuration(os.Getenv("REVERSE_DNS_TIMEOUT"))
+		config.EnrichersConfig.ReverseDNS.CacheTTL, _ = time.ParseDuration(os.Getenv("REVERSE_DNS_CACHE_TTL"))
+		config.EnrichersConfig.ReverseDNS.NegativeCacheTTL, _ = time.ParseDuration(os.Getenv("REVERSE_DNS_NEGATIVE_CACHE_TTL"))
+		config.EnrichersConfig.ReverseDNS.CacheSize, _ = strconv.Atoi(os.Getenv("REVERSE_DNS_CACHE_SIZE"))
+		config.EnrichersConfig.ReverseDNS.StoreUnconfirmed, _ = strconv.ParseBool(os.Getenv("REVERSE_DNS_STORE_UNCONFIRMED"))
+		config.EnrichersConfig.Inventory.Enabled, _ = strconv.ParseBool(os.Getenv("INVENTORY_ENABLED"))
+		if files := os.Getenv("INVENTORY_FILES"); files != "" {
+			config.EnrichersConfig.Inventory.Files = strings.Split(files, ",")
+		}
+		config.EnrichersConfig.Inventory.RefreshInterval, _ = time.ParseDuration(os.Getenv("INVENTORY_REFRESH_INTERVAL"))
+		config.EnrichersConfig.ASN.Enabled, _ = strconv.ParseBool(os.Getenv("ASN_ENABLED"))
+		if paths := os.Getenv("ASN_DATABASE_PATHS"); paths != "" {
+			config.EnrichersConfig.ASN.DatabasePaths = strings.Split(paths, ",")
+		}
+		config.EnrichersConfig.AWSSource.Enabled, _ = strconv.ParseBool(os.Getenv("AWS_SOURCE_ENABLED"))
+		config.EnrichersConfig.AWSSource.IPRangesPath = os.Getenv("AWS_IP_RANGES_PATH")
+		config.EnrichersConfig.Anonymizer.Enabled, _ = strconv.ParseBool(os.Getenv("ANONYMIZER_ENABLED"))
+		if lists := os.Getenv("ANONYMIZER_TOR_LISTS"); lists != "" {
+			config.EnrichersConfig.Anonymizer.TorLists = strings.Split(lists, ",")
+		}
+		if lists := os.Getenv("ANONYMIZER_VPN_LISTS"); lists != "" {
+			config.EnrichersConfig.Anonymizer.VPNLists = strings.Split(lists, ",")
+		}
+		if lists := os.Getenv("ANONYMIZER_HOSTING_LISTS"); lists != "" {
+			config.EnrichersConfig.Anonymizer.HostingLists = strings.Split(lists, ",")
+		}
+		config.EnrichersConfig.Anonymizer.ReloadInterval, _ = time.ParseDuration(os.Getenv("ANONYMIZER_RELOAD_INTERVAL"))
+
 		// También se puede cargar MONGO_URI si la estructura de Config lo soporta,
 		// o directamente en el cliente de MongoDB si no se necesita en Config.
 		// En tu main.go ya lo manejas directamente en NewMongoClient, lo cual es correcto.
@@ -175,3 +288,31 @@ func GetMongoDBConfig() MongoDBConfig {
 func GetAuthConfig() AuthConfig {
 	return appConfig.AuthConfig
 }
+
+func GetGraphQLConfig() GraphQLConfig {
+	return appConfig.GraphQLConfig
+}
+
+func GetGRPCConfig() GRPCConfig {
+	return appConfig.GRPCConfig
+}
+
+func GetDetectionConfig() DetectionConfig {
+	return appConfig.DetectionConfig
+}
+
+func GetNotificationConfig() NotificationConfig {
+	return appConfig.NotificationConfig
+}
+
+func GetBaselineConfig() BaselineConfig {
+	return appConfig.BaselineConfig
+}
+
+func GetRiskConfig() RiskConfig {
+	return appConfig.RiskConfig
+}
+
+func GetEnrichersConfig() EnrichersConfig {
+	return appConfig.EnrichersConfig
+}
@@ -3,10 +3,17 @@ package config
 import "time"
 
 type Config struct {
-	ServerConfig   ServerConfig   `json:"server_config"`
-	DatabaseConfig DatabaseConfig `json:"database_config"`
-	MongoDBConfig  MongoDBConfig  `json:"mongodb_config"`
-	AuthConfig     AuthConfig     `json:"auth_config"`
+	ServerConfig       ServerConfig       `json:"server_config"`
+	DatabaseConfig     DatabaseConfig     `json:"database_config"`
+	MongoDBConfig      MongoDBConfig      `json:"mongodb_config"`
+	AuthConfig         AuthConfig         `json:"auth_config"`
+	GraphQLConfig      GraphQLConfig      `json:"graphql_config"`
+	GRPCConfig         GRPCConfig         `json:"grpc_config"`
+	DetectionConfig    DetectionConfig    `json:"detection_config"`
+	NotificationConfig NotificationConfig `json:"notification_config"`
+	BaselineConfig     BaselineConfig     `json:"baseline_config"`
+	RiskConfig         RiskConfig         `json:"risk_config"`
+	EnrichersConfig    EnrichersConfig    `json:"enrichers_config"`
 }
 
 type ServerConfig struct {
@@ -41,12 +48,17 @@ type DatabaseConfig struct {
 }
 
 type MongoDBConfig struct {
-	Host       string `json:"host"`
-	Port       int    `json:"port"`
-	Username   string `json:"username"`
-	Password   string `json:"password"`
-	Database   string `json:"database"`
-	Collection string `json:"collection"` // ¡Nuevo campo para el nombre de la colección!
+	Host                       string `json:"host"`
+	Port                       int    `json:"port"`
+	Username                   string `json:"username"`
+	Password                   string `json:"password"`
+	Database                   string `json:"database"`
+	Collection                 string `json:"collection"`                   // ¡Nuevo campo para el nombre de la colección!
+	FindingsCollection         string `json:"findings_collection"`          // Colección de detecciones
+	CorrelationStateCollection string `json:"correlation_state_collection"` // Estado de las reglas de correlación
+	DeliveriesCollection       string `json:"deliveries_collection"`        // Registro de entregas de notificaciones
+	SuppressionsCollection     string `json:"suppressions_collection"`      // Supresiones creadas por analistas
+	BaselinesCollection        string `json:"baselines_collection"`         // Líneas base de comportamiento por principal
 	// SSLMode      string        `json:"ssl_mode"`
 	DBTimeout time.Duration `json:"db_timeout"`
 	// MaxOpenConns int           `json:"max_open_conns"`
@@ -62,6 +74,145 @@ type AuthConfig struct {
 	TokenDuration time.Duration `json:"token_duration"`
 }
 
+type GraphQLConfig struct {
+	MaxDepth      int `json:"max_depth"`      // Profundidad máxima de las consultas
+	MaxComplexity int `json:"max_complexity"` // Complejidad máxima (campos ponderados por "limit")
+}
+
+type GRPCConfig struct {
+	Enabled bool `json:"enabled"`
+	Port    int  `json:"port"`
+}
+
+type DetectionConfig struct {
+	Enabled          bool                   `json:"enabled"`
+	RulesPath        string                 `json:"rules_path"` // Archivo YAML o directorio con archivos .yaml/.yml
+	SigmaPath        string                 `json:"sigma_path"` // Opcional: archivo o directorio con reglas Sigma de CloudTrail
+	ImpossibleTravel ImpossibleTravelConfig `json:"impossible_travel"`
+}
+
+type ImpossibleTravelConfig struct {
+	Enabled       bool          `json:"enabled"`
+	MaxSpeedKmh   float64       `json:"max_speed_kmh"`   // Velocidad máxima plausible entre eventos consecutivos
+	MinDistanceKm float64       `json:"min_distance_km"` // Distancias menores se ignoran por la imprecisión de la geolocalización
+	Window        time.Duration `json:"window"`          // Tiempo durante el que se recuerda la última ubicación del principal
+	Severity      string        `json:"severity"`
+	IgnoreCIDRs   []string      `json:"ignore_cidrs"` // Rangos de salida de VPN o corporativos
+}
+
+type NotificationConfig struct {
+	Enabled        bool          `json:"enabled"`
+	ChannelsPath   string        `json:"channels_path"` // Archivo YAML con los canales de notificación
+	Workers        int           `json:"workers"`
+	QueueSize      int           `json:"queue_size"`
+	MaxAttempts    int           `json:"max_attempts"`
+	InitialBackoff time.Duration `json:"initial_backoff"` // Se duplica en cada reintento hasta max_backoff
+	MaxBackoff     time.Duration `json:"max_backoff"`
+	Timeout        time.Duration `json:"timeout"` // Timeout de cada solicitud HTTP
+}
+
+type BaselineConfig struct {
+	Enabled         bool          `json:"enabled"`
+	Window          time.Duration `json:"window"`           // Ventana móvil de la línea base
+	LearningPeriod  time.Duration `json:"learning_period"`  // Observación mínima de un principal antes de alertar
+	AlertDimensions []string      `json:"alert_dimensions"` // country, asn, userAgent, region, eventName
+	Severity        string        `json:"severity"`
+}
+
+type RiskConfig struct {
+	Enabled           bool           `json:"enabled"`
+	Weights           map[string]int `json:"weights"`            // sensitive_api, root_identity, missing_mfa, error_code, unusual_geo, bad_ip
+	SensitiveSources  []string       `json:"sensitive_sources"`  // eventSource considerados sensibles
+	ErrorCodes        []string       `json:"error_codes"`        // errorCode que suman riesgo
+	ExpectedCountries []string       `json:"expected_countries"` // Opcional: si está vacío se usa la línea base del principal
+	BadIPCIDRs        []string       `json:"bad_ip_cidrs"`       // IP de Tor o de mala reputación conocida
+}
+
+// EnrichersConfig agrupa la configuración de los enriquecedores opcionales de eventos.
+type EnrichersConfig struct {
+	Attack      AttackEnricherConfig      `json:"attack"`
+	ThreatIntel ThreatIntelEnricherConfig `json:"threat_intel"`
+	Anonymizer  AnonymizerEnricherConfig  `json:"anonymizer"`
+	AWSSource   AWSSourceEnricherConfig   `json:"aws_source"`
+	Network     NetworkClassifierConfig   `json:"network"`
+	ASN         ASNEnricherConfig         `json:"asn"`
+	UserAgent   UserAgentEnricherConfig   `json:"user_agent"`
+	Identity    IdentityEnricherConfig    `json:"identity"`
+	Resources   ResourcesEnricherConfig   `json:"resources"`
+	Actions     ActionsEnricherConfig     `json:"actions"`
+	Inventory   InventoryEnricherConfig   `json:"inventory"`
+	ReverseDNS  ReverseDNSEnricherConfig  `json:"reverse_dns"`
+}
+
+type AttackEnricherConfig struct {
+	Enabled     bool   `json:"enabled"`
+	MappingPath string `json:"mapping_path"` // Opcional: sobrescribe técnicas y mapeos del archivo incluido
+}
+
+type ThreatIntelEnricherConfig struct {
+	Enabled        bool          `json:"enabled"`
+	FeedsPath      string        `json:"feeds_path"`      // Archivo YAML con la lista de feeds de indicadores
+	ReloadInterval time.Duration `json:"reload_interval"` // Frecuencia con que se revisan los feeds en disco; 0 deshabilita la recarga
+}
+
+// NetworkClassifierConfig configura la clasificación de red, que siempre está activa.
+type NetworkClassifierConfig struct {
+	Sites map[string]string `json:"sites"` // Rango interno (CIDR) -> sede u oficina
+}
+
+type UserAgentEnricherConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+type IdentityEnricherConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+type ResourcesEnricherConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+type ActionsEnricherConfig struct {
+	Enabled     bool   `json:"enabled"`
+	MappingPath string `json:"mapping_path"` // Opcional: sobrescribe prefijos y acciones de la clasificación incluida
+}
+
+type ReverseDNSEnricherConfig struct {
+	Enabled          bool          `json:"enabled"`
+	Server           string        `json:"server"`             // Servidor DNS "host:puerto"; vacío usa el del sistema
+	Workers          int           `json:"workers"`            // Consultas simultáneas
+	QueueSize        int           `json:"queue_size"`         // Consultas pendientes como máximo
+	Timeout          time.Duration `json:"timeout"`            // Tiempo máximo de cada consulta
+	CacheTTL         time.Duration `json:"cache_ttl"`          // Vigencia de los nombres resueltos
+	NegativeCacheTTL time.Duration `json:"negative_cache_ttl"` // Vigencia de las IP sin nombre
+	CacheSize        int           `json:"cache_size"`         // Cantidad máxima de IP en caché
+	StoreUnconfirmed bool          `json:"store_unconfirmed"`  // Guarda también los nombres sin confirmación directa
+}
+
+type InventoryEnricherConfig struct {
+	Enabled         bool          `json:"enabled"`
+	Files           []string      `json:"files"`            // Inventarios YAML o CSV que se importan al iniciar
+	RefreshInterval time.Duration `json:"refresh_interval"` // Frecuencia con que se recarga el inventario desde PostgreSQL; 0 deshabilita la recarga
+}
+
+type ASNEnricherConfig struct {
+	Enabled       bool     `json:"enabled"`
+	DatabasePaths []string `json:"database_paths"` // Bases MMDB (GeoLite2-ASN) o tablas CSV de prefijos
+}
+
+type AWSSourceEnricherConfig struct {
+	Enabled      bool   `json:"enabled"`
+	IPRangesPath string `json:"ip_ranges_path"` // Copia local de ip-ranges.json; sin ella solo se reconocen los principales de servicio
+}
+
+type AnonymizerEnricherConfig struct {
+	Enabled        bool          `json:"enabled"`
+	TorLists       []string      `json:"tor_lists"`       // Listas de nodos de salida de Tor
+	VPNLists       []string      `json:"vpn_lists"`       // Rangos de anonimizadores y VPN comerciales
+	HostingLists   []string      `json:"hosting_lists"`   // Rangos de proveedores de hosting
+	ReloadInterval time.Duration `json:"reload_interval"` // Frecuencia con que se revisan las listas en disco; 0 deshabilita la recarga
+}
+
 // rovert
 type ConfigLegacy struct {
 	Port          int
@@ -0,0 +1,548 @@
+package detection
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"cloudtrail-enrichment-api-golang/models"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tipos de reglas de correlación.
+const (
+	CorrelationCount         = "count"          // N eventos que coinciden dentro de la ventana
+	CorrelationDistinctCount = "distinct_count" // N valores distintos de un campo dentro de la ventana
+	CorrelationSequence      = "sequence"       // Pasos ordenados, cada uno con su propio conteo
+)
+
+// maxCorrelationStates es la cantidad de estados a partir de la cual se purgan los
+// estados cuya ventana ya expiró. Si no alcanza, se descartan los estados más antiguos
+// hasta dejar correlationStatesAfterPurge, de modo que la purga no se repita en cada evento.
+const (
+	maxCorrelationStates        = 50000
+	correlationStatesAfterPurge = maxCorrelationStates * 9 / 10
+)
+
+// CorrelationStep es un paso de una regla de secuencia. NewValue exige que el valor del
+// campo indicado no haya aparecido en los eventos de los pasos anteriores.
+type CorrelationStep struct {
+	Name     string           `yaml:"name"`
+	Match    match.MatchBlock `yaml:"match"`
+	Count    int              `yaml:"count"`
+	NewValue string           `yaml:"new_value"`
+}
+
+// CorrelationRule es la definición YAML de una regla de correlación con estado. El estado
+// se mantiene por clave de agrupación (group_by) dentro de la ventana indicada.
+type CorrelationRule struct {
+	ID            string             `yaml:"id"`
+	Name          string             `yaml:"name"`
+	Description   string             `yaml:"description"`
+	Severity      string             `yaml:"severity"`
+	Enabled       *bool              `yaml:"enabled"`
+	Tags          []string           `yaml:"tags"`
+	Type          string             `yaml:"type"`
+	GroupBy       []string           `yaml:"group_by"`
+	Window        time.Duration      `yaml:"window"`
+	Match         match.MatchBlock   `yaml:"match"`          // count y distinct_count
+	Count         int                `yaml:"count"`          // count y distinct_count
+	DistinctField string             `yaml:"distinct_field"` // distinct_count
+	Steps         []CorrelationStep  `yaml:"steps"`          // sequence
+	Suppression   *SuppressionConfig `yaml:"suppression"`
+}
+
+// CorrelationSet es el formato de las correlaciones dentro de un archivo de reglas.
+type CorrelationSet struct {
+	Correlations []CorrelationRule `yaml:"correlations"`
+}
+
+// correlationStep es un paso compilado. Las reglas count y distinct_count se compilan
+// como una secuencia de un único paso.
+type correlationStep struct {
+	CorrelationStep
+	Matcher match.Matcher
+}
+
+// CompiledCorrelation es una regla de correlación validada y lista para evaluar.
+type CompiledCorrelation struct {
+	CorrelationRule
+	Source string
+	steps  []correlationStep
+}
+
+// IsEnabled indica si la regla está habilitada (por defecto lo está).
+func (r *CorrelationRule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// Compile valida la regla de correlación y construye sus matchers.
+func (r CorrelationRule) Compile(source string) (*CompiledCorrelation, error) {
+	if r.ID == "" {
+		return nil, fmt.Errorf("correlación sin id en %s", source)
+	}
+	if r.Name == "" {
+		r.Name = r.ID
+	}
+	r.Severity = strings.ToLower(r.Severity)
+	if _, ok := models.SeverityRank[r.Severity]; !ok {
+		return nil, fmt.Errorf("correlación %s: severidad '%s' no válida", r.ID, r.Severity)
+	}
+	if r.Window <= 0 {
+		return nil, fmt.Errorf("correlación %s: se requiere window > 0", r.ID)
+	}
+	if len(r.GroupBy) == 0 {
+		return nil, fmt.Errorf("correlación %s: se requiere al menos un campo en group_by", r.ID)
+	}
+
+	if r.Suppression != nil {
+		if err := r.Suppression.validate(); err != nil {
+			return nil, fmt.Errorf("correlación %s: %w", r.ID, err)
+		}
+	}
+
+	compiled := &CompiledCorrelation{CorrelationRule: r, Source: source}
+	switch r.Type {
+	case CorrelationCount, CorrelationDistinctCount:
+		if r.Count < 1 {
+			return nil, fmt.Errorf("correlación %s: se requiere count >= 1", r.ID)
+		}
+		if r.Type == CorrelationDistinctCount && r.DistinctField == "" {
+			return nil, fmt.Errorf("correlación %s: distinct_count requiere distinct_field", r.ID)
+		}
+		matcher, err := r.Match.Compile()
+		if err != nil {
+			return nil, fmt.Errorf("correlación %s: %w", r.ID, err)
+		}
+		compiled.steps = []correlationStep{{CorrelationStep: CorrelationStep{Count: r.Count}, Matcher: matcher}}
+	case CorrelationSequence:
+		if len(r.Steps) < 2 {
+			return nil, fmt.Errorf("correlación %s: una secuencia requiere al menos dos pasos", r.ID)
+		}
+		for i, step := range r.Steps {
+			if step.Count == 0 {
+				step.Count = 1
+			}
+			if step.Count < 1 {
+				return nil, fmt.Errorf("correlación %s: el paso %d requiere count >= 1", r.ID, i+1)
+			}
+			if step.NewValue != "" && i == 0 {
+				return nil, fmt.Errorf("correlación %s: new_value no aplica al primer paso", r.ID)
+			}
+			matcher, err := step.Match.Compile()
+			if err != nil {
+				return nil, fmt.Errorf("correlación %s, paso %d: %w", r.ID, i+1, err)
+			}
+			compiled.steps = append(compiled.steps, correlationStep{CorrelationStep: step, Matcher: matcher})
+		}
+	default:
+		return nil, fmt.Errorf("correlación %s: tipo '%s' no válido", r.ID, r.Type)
+	}
+	return compiled, nil
+}
+
+// trackedFields devuelve los campos cuyos valores deben guardarse en el estado.
+func (c *CompiledCorrelation) trackedFields() []string {
+	var fields []string
+	if c.DistinctField != "" {
+		fields = append(fields, c.DistinctField)
+	}
+	for _, step := range c.steps {
+		if step.NewValue != "" {
+			fields = append(fields, step.NewValue)
+		}
+	}
+	return fields
+}
+
+// ParseCorrelationRules decodifica y compila las correlaciones de un documento YAML. Las
+// correlaciones deshabilitadas se omiten.
+func ParseCorrelationRules(data []byte, source string) ([]*CompiledCorrelation, error) {
+	var set CorrelationSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error al decodificar correlaciones de %s: %w", source, err)
+	}
+
+	rules := make([]*CompiledCorrelation, 0, len(set.Correlations))
+	for _, rule := range set.Correlations {
+		if !rule.IsEnabled() {
+			continue
+		}
+		compiled, err := rule.Compile(source)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, compiled)
+	}
+	return rules, nil
+}
+
+// LoadCorrelationRules carga las correlaciones (clave correlations) de los mismos archivos
+// que LoadRules. Los identificadores deben ser únicos.
+func LoadCorrelationRules(path string) ([]*CompiledCorrelation, error) {
+	files, err := ruleFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*CompiledCorrelation
+	seen := map[string]string{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer el archivo de reglas %s: %w", file, err)
+		}
+		parsed, err := ParseCorrelationRules(data, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range parsed {
+			if previous, ok := seen[rule.ID]; ok {
+				return nil, fmt.Errorf("correlación %s duplicada en %s y %s", rule.ID, previous, file)
+			}
+			seen[rule.ID] = file
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// CorrelationResult es el resultado de evaluar un evento: las detecciones generadas y los
+// cambios de estado que deben persistirse.
+type CorrelationResult struct {
+	Findings []*models.Finding
+	Updated  []*models.CorrelationState
+	Deleted  []string
+}
+
+// CorrelationEngine evalúa las reglas de correlación manteniendo el estado con ventana de
+// tiempo por regla y clave de agrupación. El estado vive en memoria; quien lo usa persiste
+// los cambios devueltos en cada CorrelationResult y lo restaura con LoadStates.
+type CorrelationEngine struct {
+	mu     sync.Mutex
+	rules  map[string]*CompiledCorrelation
+	order  []*CompiledCorrelation
+	states map[string]*models.CorrelationState
+	latest time.Time // Hora del evento más reciente visto, referencia de la purga
+}
+
+// NewCorrelationEngine crea un motor de correlación con las reglas indicadas.
+func NewCorrelationEngine(rules []*CompiledCorrelation) *CorrelationEngine {
+	e := &CorrelationEngine{states: make(map[string]*models.CorrelationState)}
+	e.SetRules(rules)
+	return e
+}
+
+// SetRules reemplaza las reglas del motor. Se conserva el estado de las reglas que siguen
+// existiendo y se devuelven las claves de estado descartadas.
+func (e *CorrelationEngine) SetRules(rules []*CompiledCorrelation) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.order = rules
+	e.rules = make(map[string]*CompiledCorrelation, len(rules))
+	for _, rule := range rules {
+		e.rules[rule.ID] = rule
+	}
+
+	var deleted []string
+	for key, state := range e.states {
+		if _, ok := e.rules[state.RuleID]; !ok {
+			delete(e.states, key)
+			deleted = append(deleted, key)
+		}
+	}
+	return deleted
+}
+
+// Rules devuelve las correlaciones cargadas en el motor.
+func (e *CorrelationEngine) Rules() []*CompiledCorrelation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.order
+}
+
+// LoadStates restaura el estado persistido. Se ignoran los estados de reglas que ya no existen.
+func (e *CorrelationEngine) LoadStates(states []*models.CorrelationState) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	loaded := 0
+	for _, state := range states {
+		if _, ok := e.rules[state.RuleID]; !ok {
+			continue
+		}
+		e.states[state.Key] = state
+		if last := lastEventTime(state); last.After(e.latest) {
+			e.latest = last
+		}
+		loaded++
+	}
+	return loaded
+}
+
+// Evaluate evalúa las correlaciones sobre el evento ya convertido a Document.
+func (e *CorrelationEngine) Evaluate(record *models.EnrichedEventRecord, doc match.Document) CorrelationResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var result CorrelationResult
+	if record.EventTime.After(e.latest) {
+		e.latest = record.EventTime
+	}
+	if len(e.states) >= maxCorrelationStates {
+		result.Deleted = e.purgeExpired()
+	}
+
+	for _, rule := range e.order {
+		groupKey, ok := correlationGroupKey(doc, rule.GroupBy)
+		if !ok {
+			continue
+		}
+		key := rule.ID + "#" + groupKey
+
+		state, exists := e.states[key]
+		if !exists {
+			state = &models.CorrelationState{Key: key, RuleID: rule.ID, GroupKey: groupKey}
+		}
+		changed, finding := rule.apply(state, record, doc)
+		if !changed {
+			continue
+		}
+
+		if finding != nil || len(state.Events) == 0 {
+			if finding != nil {
+				result.Findings = append(result.Findings, finding)
+			}
+			if exists {
+				delete(e.states, key)
+				result.Deleted = append(result.Deleted, key)
+			}
+			continue
+		}
+
+		// La expiración se mide con la hora de los eventos, igual que la purga en memoria.
+		state.UpdatedAt = time.Now()
+		state.ExpiresAt = lastEventTime(state).Add(rule.Window)
+		e.states[key] = state
+		result.Updated = append(result.Updated, cloneState(state))
+	}
+	return result
+}
+
+// correlationGroupKey construye la clave de agrupación. Los eventos sin valor en alguno de
+// los campos de agrupación no se correlacionan.
+func correlationGroupKey(doc match.Document, fields []string) (string, bool) {
+	for _, field := range fields {
+		if len(doc.Values(field)) == 0 {
+			return "", false
+		}
+	}
+	return GroupKey(doc, fields), true
+}
+
+// apply incorpora el evento al estado. Devuelve si el estado cambió y la detección generada
+// cuando la condición de la regla se cumple.
+func (c *CompiledCorrelation) apply(state *models.CorrelationState, record *models.EnrichedEventRecord, doc match.Document) (bool, *models.Finding) {
+	changed := c.prune(state, record.EventTime)
+
+	current := c.progress(state)
+	step := -1
+	values := map[string]string{}
+	for _, field := range c.trackedFields() {
+		values[field] = strings.Join(doc.Values(field), ",")
+	}
+
+	// Se intenta primero el paso en curso y luego los anteriores, que extienden la evidencia.
+	for i := current; i >= 0; i-- {
+		if i >= len(c.steps) || !c.steps[i].Matcher.Match(doc) {
+			continue
+		}
+		if field := c.steps[i].NewValue; field != "" && !c.isNewValue(state, i, field, values[field]) {
+			continue
+		}
+		step = i
+		break
+	}
+	if step < 0 {
+		return changed, nil
+	}
+
+	event := models.CorrelationEvent{EventID: record.ID, Time: record.EventTime, Step: step}
+	if len(values) > 0 {
+		event.Values = values
+	}
+	c.add(state, event)
+
+	if c.satisfied(state) {
+		return true, newFinding(&CompiledRule{Rule: Rule{
+			ID:          c.ID,
+			Name:        c.Name,
+			Description: c.Description,
+			Severity:    c.Severity,
+			Tags:        c.Tags,
+		}}, state.GroupKey, correlationRefs(state))
+	}
+	return true, nil
+}
+
+// prune descarta los eventos fuera de la ventana y, en secuencias, los eventos de pasos
+// posteriores al primer paso que dejó de cumplirse.
+func (c *CompiledCorrelation) prune(state *models.CorrelationState, now time.Time) bool {
+	cutoff := now.Add(-c.Window)
+	events := state.Events[:0]
+	for _, event := range state.Events {
+		if !event.Time.Before(cutoff) {
+			events = append(events, event)
+		}
+	}
+	changed := len(events) != len(state.Events)
+	state.Events = events
+
+	current := c.progress(state)
+	events = state.Events[:0]
+	for _, event := range state.Events {
+		if event.Step <= current {
+			events = append(events, event)
+		}
+	}
+	changed = changed || len(events) != len(state.Events)
+	state.Events = events
+	return changed
+}
+
+// progress devuelve el índice del primer paso que aún no alcanzó su conteo.
+func (c *CompiledCorrelation) progress(state *models.CorrelationState) int {
+	for i := range c.steps {
+		if c.stepCount(state, i) < c.steps[i].Count {
+			return i
+		}
+	}
+	return len(c.steps)
+}
+
+// stepCount cuenta los eventos de un paso; en distinct_count cuenta valores distintos.
+func (c *CompiledCorrelation) stepCount(state *models.CorrelationState, step int) int {
+	if c.Type == CorrelationDistinctCount {
+		distinct := map[string]bool{}
+		for _, event := range state.Events {
+			distinct[event.Values[c.DistinctField]] = true
+		}
+		return len(distinct)
+	}
+	count := 0
+	for _, event := range state.Events {
+		if event.Step == step {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *CompiledCorrelation) satisfied(state *models.CorrelationState) bool {
+	return c.progress(state) == len(c.steps)
+}
+
+// isNewValue indica si value no apareció en los eventos de los pasos anteriores a step.
+func (c *CompiledCorrelation) isNewValue(state *models.CorrelationState, step int, field, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, event := range state.Events {
+		if event.Step < step && event.Values[field] == value {
+			return false
+		}
+	}
+	return true
+}
+
+// add agrega el evento al estado acotando su tamaño: en distinct_count se conserva el
+// último evento de cada valor y en los demás tipos, los últimos Count eventos del paso.
+func (c *CompiledCorrelation) add(state *models.CorrelationState, event models.CorrelationEvent) {
+	if c.Type == CorrelationDistinctCount {
+		for i, existing := range state.Events {
+			if existing.Values[c.DistinctField] == event.Values[c.DistinctField] {
+				state.Events = append(state.Events[:i], state.Events[i+1:]...)
+				break
+			}
+		}
+		state.Events = append(state.Events, event)
+		return
+	}
+
+	state.Events = append(state.Events, event)
+	if c.stepCount(state, event.Step) <= c.steps[event.Step].Count {
+		return
+	}
+	for i, existing := range state.Events {
+		if existing.Step == event.Step {
+			state.Events = append(state.Events[:i], state.Events[i+1:]...)
+			return
+		}
+	}
+}
+
+// purgeExpired elimina los estados cuyo último evento quedó fuera de la ventana de su
+// regla, tomando como referencia el evento más reciente visto (los eventos pueden llegar
+// con horas del pasado). Si quedan más de correlationStatesAfterPurge estados descarta los
+// de último evento más antiguo.
+func (e *CorrelationEngine) purgeExpired() []string {
+	var deleted []string
+	for key, state := range e.states {
+		rule, ok := e.rules[state.RuleID]
+		if !ok || len(state.Events) == 0 || lastEventTime(state).Before(e.latest.Add(-rule.Window)) {
+			delete(e.states, key)
+			deleted = append(deleted, key)
+		}
+	}
+
+	excess := len(e.states) - correlationStatesAfterPurge
+	if excess <= 0 {
+		return deleted
+	}
+	type stateAge struct {
+		key  string
+		last time.Time
+	}
+	states := make([]stateAge, 0, len(e.states))
+	for key, state := range e.states {
+		states = append(states, stateAge{key: key, last: lastEventTime(state)})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].last.Before(states[j].last) })
+	for _, state := range states[:excess] {
+		delete(e.states, state.key)
+		deleted = append(deleted, state.key)
+	}
+	return deleted
+}
+
+func lastEventTime(state *models.CorrelationState) time.Time {
+	var last time.Time
+	for _, event := range state.Events {
+		if event.Time.After(last) {
+			last = event.Time
+		}
+	}
+	return last
+}
+
+func correlationRefs(state *models.CorrelationState) []eventRef {
+	events := append([]models.CorrelationEvent(nil), state.Events...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+
+	refs := make([]eventRef, 0, len(events))
+	for _, event := range events {
+		refs = append(refs, eventRef{id: event.EventID, time: event.Time})
+	}
+	return refs
+}
+
+func cloneState(state *models.CorrelationState) *models.CorrelationState {
+	clone := *state
+	clone.Events = append([]models.CorrelationEvent(nil), state.Events...)
+	return &clone
+}
@@ -0,0 +1,75 @@
+package detection
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"cloudtrail-enrichment-api-golang/models"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func countCorrelation(t *testing.T) *CompiledCorrelation {
+	t.Helper()
+	rules, err := ParseCorrelationRules([]byte(`
+correlations:
+  - id: enumeration
+    severity: medium
+    type: count
+    group_by: [sourceIPAddress]
+    window: 10m
+    count: 2
+    match:
+      all:
+        - field: eventName
+          equals: ListBuckets
+`), "test")
+	if err != nil {
+		t.Fatalf("ParseCorrelationRules: %v", err)
+	}
+	return rules[0]
+}
+
+func evaluateAt(engine *CorrelationEngine, ip string, at time.Time) CorrelationResult {
+	record := &models.EnrichedEventRecord{ID: primitive.NewObjectID(), SourceIPAddress: ip, EventName: "ListBuckets"}
+	record.EventTime = at
+	return engine.Evaluate(record, match.Document{"sourceIPAddress": ip, "eventName": "ListBuckets"})
+}
+
+// Con eventos históricos la purga se mide con la hora del evento más reciente: la ventana
+// en curso sobrevive y el mapa queda acotado descartando los estados más antiguos.
+func TestCorrelationPurgeHistoricalEvents(t *testing.T) {
+	engine := NewCorrelationEngine([]*CompiledCorrelation{countCorrelation(t)})
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expired := base.Add(-time.Hour)
+	evaluateAt(engine, "192.0.2.1", expired)
+	for i := 1; i < maxCorrelationStates-1; i++ {
+		evaluateAt(engine, fmt.Sprintf("filler-%d", i), base.Add(time.Duration(i)*time.Millisecond))
+	}
+
+	first := base.Add(time.Minute)
+	result := evaluateAt(engine, "198.51.100.7", first)
+	if len(result.Updated) != 1 || !result.Updated[0].ExpiresAt.Equal(first.Add(10*time.Minute)) {
+		t.Fatalf("estado actualizado = %+v, se esperaba expires_at = hora del evento + ventana", result.Updated)
+	}
+
+	result = evaluateAt(engine, "198.51.100.7", first.Add(time.Minute))
+	if len(result.Findings) != 1 {
+		t.Fatalf("se generaron %d detecciones, se esperaba 1 con la ventana aún abierta", len(result.Findings))
+	}
+	if len(engine.states) > correlationStatesAfterPurge {
+		t.Errorf("quedaron %d estados tras la purga, se esperaban como máximo %d", len(engine.states), correlationStatesAfterPurge)
+	}
+	deleted := map[string]bool{}
+	for _, key := range result.Deleted {
+		deleted[key] = true
+	}
+	if !deleted["enumeration#sourceIPAddress=192.0.2.1"] || !deleted["enumeration#sourceIPAddress=filler-1"] {
+		t.Errorf("la purga no informó el estado expirado y el más antiguo entre %d claves", len(result.Deleted))
+	}
+	if _, ok := engine.states["enumeration#sourceIPAddress=filler-"+fmt.Sprint(maxCorrelationStates-2)]; !ok {
+		t.Errorf("se descartó el estado más reciente")
+	}
+}
@@ -0,0 +1,198 @@
+package detection
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"cloudtrail-enrichment-api-golang/models"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxThresholdGroups es la cantidad de grupos de umbral a partir de la cual se
+// purgan los grupos cuya ventana ya expiró. Si no alcanza, se descartan los grupos más
+// antiguos hasta dejar thresholdGroupsAfterPurge, de modo que la purga no se repita en
+// cada evento.
+const (
+	maxThresholdGroups        = 10000
+	thresholdGroupsAfterPurge = maxThresholdGroups * 9 / 10
+)
+
+// eventRef es la referencia a un evento que coincidió con una regla de umbral.
+type eventRef struct {
+	id   primitive.ObjectID
+	time time.Time
+}
+
+// Engine evalúa las reglas compiladas sobre cada evento enriquecido y mantiene en
+// memoria el estado de las reglas de umbral.
+type Engine struct {
+	mu    sync.RWMutex
+	rules []*CompiledRule
+
+	stateMu    sync.Mutex
+	thresholds map[string][]eventRef
+	latest     time.Time // Hora del evento más reciente visto, referencia de la purga
+}
+
+// NewEngine crea un motor de detección con las reglas indicadas.
+func NewEngine(rules []*CompiledRule) *Engine {
+	return &Engine{
+		rules:      rules,
+		thresholds: make(map[string][]eventRef),
+	}
+}
+
+// SetRules reemplaza las reglas del motor y descarta el estado de umbrales.
+func (e *Engine) SetRules(rules []*CompiledRule) {
+	e.mu.Lock()
+	e.rules = rules
+	e.mu.Unlock()
+
+	e.stateMu.Lock()
+	e.thresholds = make(map[string][]eventRef)
+	e.stateMu.Unlock()
+}
+
+// Rules devuelve las reglas cargadas en el motor.
+func (e *Engine) Rules() []*CompiledRule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.rules
+}
+
+// Evaluate evalúa todas las reglas sobre el evento y devuelve las detecciones generadas.
+func (e *Engine) Evaluate(record *models.EnrichedEventRecord) ([]*models.Finding, error) {
+	doc, err := match.NewDocument(record)
+	if err != nil {
+		return nil, err
+	}
+	return e.EvaluateDocument(record, doc), nil
+}
+
+// EvaluateDocument evalúa las reglas sobre un Document ya construido a partir de record.
+func (e *Engine) EvaluateDocument(record *models.EnrichedEventRecord, doc match.Document) []*models.Finding {
+	var findings []*models.Finding
+	for _, rule := range e.Rules() {
+		if !rule.Matcher.Match(doc) {
+			continue
+		}
+
+		if rule.Threshold == nil {
+			findings = append(findings, newFinding(rule, "", []eventRef{{id: record.ID, time: record.EventTime}}))
+			continue
+		}
+
+		if finding := e.threshold(rule, doc, record); finding != nil {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// GroupKey construye la clave de agrupación de un evento a partir de los campos indicados.
+func GroupKey(doc match.Document, fields []string) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, field+"="+strings.Join(doc.Values(field), ","))
+	}
+	return strings.Join(parts, "|")
+}
+
+// threshold acumula el evento en su grupo y genera una detección cuando el grupo alcanza
+// el umbral dentro de la ventana. Tras generar la detección el grupo se reinicia.
+func (e *Engine) threshold(rule *CompiledRule, doc match.Document, record *models.EnrichedEventRecord) *models.Finding {
+	groupKey := GroupKey(doc, rule.Threshold.GroupBy)
+	key := rule.ID + "#" + groupKey
+
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	if record.EventTime.After(e.latest) {
+		e.latest = record.EventTime
+	}
+	if len(e.thresholds) >= maxThresholdGroups {
+		e.purgeExpired()
+	}
+
+	cutoff := record.EventTime.Add(-rule.Threshold.Window)
+	var refs []eventRef
+	for _, ref := range e.thresholds[key] {
+		if !ref.time.Before(cutoff) {
+			refs = append(refs, ref)
+		}
+	}
+	refs = append(refs, eventRef{id: record.ID, time: record.EventTime})
+
+	if len(refs) < rule.Threshold.Count {
+		e.thresholds[key] = refs
+		return nil
+	}
+
+	delete(e.thresholds, key)
+	return newFinding(rule, groupKey, refs)
+}
+
+// purgeExpired elimina los grupos cuyo último evento es más antiguo que la ventana más
+// amplia de las reglas cargadas, tomando como referencia el evento más reciente visto (los
+// eventos pueden llegar con horas del pasado). Si quedan más de thresholdGroupsAfterPurge
+// grupos descarta los de último evento más antiguo.
+func (e *Engine) purgeExpired() {
+	var maxWindow time.Duration
+	for _, rule := range e.Rules() {
+		if rule.Threshold != nil && rule.Threshold.Window > maxWindow {
+			maxWindow = rule.Threshold.Window
+		}
+	}
+	cutoff := e.latest.Add(-maxWindow)
+	for key, refs := range e.thresholds {
+		if len(refs) == 0 || refs[len(refs)-1].time.Before(cutoff) {
+			delete(e.thresholds, key)
+		}
+	}
+
+	excess := len(e.thresholds) - thresholdGroupsAfterPurge
+	if excess <= 0 {
+		return
+	}
+	type groupAge struct {
+		key  string
+		last time.Time
+	}
+	groups := make([]groupAge, 0, len(e.thresholds))
+	for key, refs := range e.thresholds {
+		groups = append(groups, groupAge{key: key, last: refs[len(refs)-1].time})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].last.Before(groups[j].last) })
+	for _, group := range groups[:excess] {
+		delete(e.thresholds, group.key)
+	}
+}
+
+func newFinding(rule *CompiledRule, groupKey string, refs []eventRef) *models.Finding {
+	finding := &models.Finding{
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		Description: rule.Description,
+		Severity:    rule.Severity,
+		GroupKey:    groupKey,
+		EventIDs:    make([]primitive.ObjectID, 0, len(refs)),
+		FirstSeen:   refs[0].time,
+		LastSeen:    refs[0].time,
+		CreatedAt:   time.Now(),
+		Status:      models.FindingStatusOpen,
+	}
+	finding.UpdatedAt = finding.CreatedAt
+	for _, ref := range refs {
+		finding.EventIDs = append(finding.EventIDs, ref.id)
+		if ref.time.Before(finding.FirstSeen) {
+			finding.FirstSeen = ref.time
+		}
+		if ref.time.After(finding.LastSeen) {
+			finding.LastSeen = ref.time
+		}
+	}
+	return finding
+}
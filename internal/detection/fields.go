@@ -0,0 +1,81 @@
+package detection
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	knownFieldsOnce sync.Once
+	knownFields     map[string]bool
+)
+
+// KnownFields devuelve las rutas JSON disponibles en EnrichedEventRecord (por ejemplo
+// "userIdentity.arn"). Se calcula por reflexión para incluir automáticamente los campos nuevos.
+func KnownFields() map[string]bool {
+	knownFieldsOnce.Do(func() {
+		knownFields = map[string]bool{}
+		collectFields(reflect.TypeOf(models.EnrichedEventRecord{}), "", knownFields)
+	})
+	return knownFields
+}
+
+// IsKnownField indica si la ruta existe en el modelo de eventos enriquecidos. Los campos de
+// tipo mapa o interface{} admiten cualquier subruta (se registran como "campo.*").
+func IsKnownField(path string) bool {
+	fields := KnownFields()
+	if fields[path] {
+		return true
+	}
+	for i := strings.LastIndex(path, "."); i > 0; i = strings.LastIndex(path[:i], ".") {
+		if fields[path[:i]+".*"] {
+			return true
+		}
+	}
+	return false
+}
+
+func collectFields(t reflect.Type, prefix string, fields map[string]bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			// Los mapas "inline" (p. ej. requestParameters) admiten cualquier subruta
+			if field.Type.Kind() == reflect.Map && strings.Contains(field.Tag.Get("bson"), ",inline") && prefix != "" {
+				fields[prefix+".*"] = true
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		fields[path] = true
+
+		elem := field.Type
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Slice {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Map || elem.Kind() == reflect.Interface {
+			fields[path+".*"] = true
+			continue
+		}
+		collectFields(field.Type, path, fields)
+	}
+}
@@ -0,0 +1,178 @@
+package detection
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"cloudtrail-enrichment-api-golang/models"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Threshold convierte una regla en una regla de umbral: solo genera una detección cuando
+// Count eventos del mismo grupo coinciden dentro de Window (según eventTime).
+type Threshold struct {
+	Count   int           `yaml:"count"`
+	Window  time.Duration `yaml:"window"`
+	GroupBy []string      `yaml:"group_by"`
+}
+
+// SuppressionConfig limita las detecciones repetidas de una regla: dentro de Window, cada
+// grupo (GroupBy, o el grupo de la propia detección si se omite) genera como máximo
+// MaxAlerts detecciones; las coincidencias siguientes solo incrementan el contador de
+// suprimidas de la última detección.
+type SuppressionConfig struct {
+	GroupBy   []string      `yaml:"group_by"`
+	Window    time.Duration `yaml:"window"`
+	MaxAlerts int           `yaml:"max_alerts"`
+}
+
+// validate comprueba la configuración y aplica los valores por defecto.
+func (s *SuppressionConfig) validate() error {
+	if s.Window <= 0 {
+		return fmt.Errorf("la supresión requiere window > 0")
+	}
+	if s.MaxAlerts == 0 {
+		s.MaxAlerts = 1
+	}
+	if s.MaxAlerts < 0 {
+		return fmt.Errorf("la supresión requiere max_alerts >= 1")
+	}
+	return nil
+}
+
+// Rule es la definición YAML de una regla de detección.
+type Rule struct {
+	ID          string             `yaml:"id"`
+	Name        string             `yaml:"name"`
+	Description string             `yaml:"description"`
+	Severity    string             `yaml:"severity"`
+	Enabled     *bool              `yaml:"enabled"`
+	Tags        []string           `yaml:"tags"`
+	Match       match.MatchBlock   `yaml:"match"`
+	Threshold   *Threshold         `yaml:"threshold"`
+	Suppression *SuppressionConfig `yaml:"suppression"`
+}
+
+// RuleSet es el formato de un archivo de reglas.
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// CompiledRule es una regla validada con su Matcher listo para evaluar.
+type CompiledRule struct {
+	Rule
+	Source  string // Origen de la regla (archivo o importador)
+	Matcher match.Matcher
+}
+
+// IsEnabled indica si la regla está habilitada (por defecto lo está).
+func (r *Rule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// Compile valida la regla y construye su Matcher.
+func (r Rule) Compile(source string) (*CompiledRule, error) {
+	if r.ID == "" {
+		return nil, fmt.Errorf("regla sin id en %s", source)
+	}
+	if r.Name == "" {
+		r.Name = r.ID
+	}
+	r.Severity = strings.ToLower(r.Severity)
+	if _, ok := models.SeverityRank[r.Severity]; !ok {
+		return nil, fmt.Errorf("regla %s: severidad '%s' no válida", r.ID, r.Severity)
+	}
+	if r.Threshold != nil && (r.Threshold.Count < 1 || r.Threshold.Window <= 0) {
+		return nil, fmt.Errorf("regla %s: el umbral requiere count >= 1 y window > 0", r.ID)
+	}
+
+	if r.Suppression != nil {
+		if err := r.Suppression.validate(); err != nil {
+			return nil, fmt.Errorf("regla %s: %w", r.ID, err)
+		}
+	}
+
+	matcher, err := r.Match.Compile()
+	if err != nil {
+		return nil, fmt.Errorf("regla %s: %w", r.ID, err)
+	}
+
+	return &CompiledRule{Rule: r, Source: source, Matcher: matcher}, nil
+}
+
+// ParseRules decodifica y compila las reglas de un documento YAML. Las reglas
+// deshabilitadas se omiten.
+func ParseRules(data []byte, source string) ([]*CompiledRule, error) {
+	var set RuleSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error al decodificar reglas de %s: %w", source, err)
+	}
+
+	rules := make([]*CompiledRule, 0, len(set.Rules))
+	for _, rule := range set.Rules {
+		if !rule.IsEnabled() {
+			continue
+		}
+		compiled, err := rule.Compile(source)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, compiled)
+	}
+	return rules, nil
+}
+
+// LoadRules carga las reglas desde un archivo YAML o desde todos los archivos .yaml/.yml
+// de un directorio. Los identificadores de regla deben ser únicos.
+func LoadRules(path string) ([]*CompiledRule, error) {
+	files, err := ruleFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*CompiledRule
+	seen := map[string]string{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer el archivo de reglas %s: %w", file, err)
+		}
+		parsed, err := ParseRules(data, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range parsed {
+			if previous, ok := seen[rule.ID]; ok {
+				return nil, fmt.Errorf("regla %s duplicada en %s y %s", rule.ID, previous, file)
+			}
+			seen[rule.ID] = file
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// ruleFiles devuelve el archivo indicado o los archivos .yaml/.yml del directorio.
+func ruleFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al acceder a las reglas en %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
@@ -0,0 +1,543 @@
+package detection
+
+import (
+	"bytes"
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errUnsupported marca las construcciones Sigma que el compilador no admite.
+var errUnsupported = errors.New("no soportado")
+
+func unsupported(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", errUnsupported, fmt.Sprintf(format, args...))
+}
+
+// SigmaRule es el subconjunto del formato Sigma que se interpreta.
+type SigmaRule struct {
+	Title       string                 `yaml:"title"`
+	ID          string                 `yaml:"id"`
+	Status      string                 `yaml:"status"`
+	Description string                 `yaml:"description"`
+	Level       string                 `yaml:"level"`
+	Tags        []string               `yaml:"tags"`
+	Action      string                 `yaml:"action"`
+	LogSource   SigmaLogSource         `yaml:"logsource"`
+	Detection   map[string]interface{} `yaml:"detection"`
+}
+
+// SigmaLogSource identifica el origen de logs al que aplica una regla Sigma.
+type SigmaLogSource struct {
+	Product string `yaml:"product"`
+	Service string `yaml:"service"`
+}
+
+// SigmaUnsupported describe una regla Sigma que no se pudo importar y el motivo.
+type SigmaUnsupported struct {
+	File   string `json:"file"`
+	ID     string `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// SigmaReport resume el resultado de una importación de reglas Sigma.
+type SigmaReport struct {
+	Loaded      []string           `json:"loaded"`
+	Unsupported []SigmaUnsupported `json:"unsupported"`
+}
+
+// sigmaLevels traduce los niveles de Sigma a las severidades del API.
+var sigmaLevels = map[string]string{
+	"informational": "low",
+	"low":           "low",
+	"medium":        "medium",
+	"high":          "high",
+	"critical":      "critical",
+}
+
+// LoadSigmaRules importa las reglas Sigma de un archivo o directorio (recursivo). Solo se
+// compilan las reglas con logsource product: aws, service: cloudtrail; el resto, y las que
+// usan construcciones no soportadas, se listan en el reporte. Los identificadores deben ser
+// únicos.
+func LoadSigmaRules(path string) ([]*CompiledRule, *SigmaReport, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al acceder a las reglas Sigma en %s: %w", path, err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(file string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := filepath.Ext(file)
+			if !d.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("error al recorrer las reglas Sigma en %s: %w", path, err)
+		}
+	} else {
+		files = []string{path}
+	}
+	sort.Strings(files)
+
+	report := &SigmaReport{Loaded: []string{}, Unsupported: []SigmaUnsupported{}}
+	var rules []*CompiledRule
+	seen := map[string]string{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error al leer la regla Sigma %s: %w", file, err)
+		}
+		compiled, unsupportedRules := ParseSigma(data, file)
+		for _, rule := range compiled {
+			if previous, ok := seen[rule.ID]; ok {
+				return nil, nil, fmt.Errorf("regla Sigma %s duplicada en %s y %s", rule.ID, previous, file)
+			}
+			seen[rule.ID] = file
+			report.Loaded = append(report.Loaded, rule.ID)
+		}
+		rules = append(rules, compiled...)
+		report.Unsupported = append(report.Unsupported, unsupportedRules...)
+	}
+	return rules, report, nil
+}
+
+// ParseSigma compila los documentos Sigma contenidos en data. Devuelve las reglas
+// compiladas y las que no se pudieron importar.
+func ParseSigma(data []byte, source string) ([]*CompiledRule, []SigmaUnsupported) {
+	var (
+		rules       []*CompiledRule
+		unsupported []SigmaUnsupported
+	)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var sigma SigmaRule
+		err := decoder.Decode(&sigma)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			unsupported = append(unsupported, SigmaUnsupported{File: source, Reason: "YAML inválido: " + err.Error()})
+			break
+		}
+
+		rule, err := sigma.Compile(source)
+		if err != nil {
+			unsupported = append(unsupported, SigmaUnsupported{File: source, ID: sigma.ID, Title: sigma.Title, Reason: err.Error()})
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, unsupported
+}
+
+// Compile traduce la regla Sigma a una CompiledRule.
+func (s *SigmaRule) Compile(source string) (*CompiledRule, error) {
+	if status := strings.ToLower(s.Status); status == "deprecated" || status == "unsupported" {
+		return nil, unsupported("status %s", status)
+	}
+	if s.Action != "" {
+		return nil, unsupported("colecciones de reglas (action: %s)", s.Action)
+	}
+	if !strings.EqualFold(s.LogSource.Product, "aws") || !strings.EqualFold(s.LogSource.Service, "cloudtrail") {
+		return nil, unsupported("logsource %s/%s no corresponde a aws/cloudtrail", s.LogSource.Product, s.LogSource.Service)
+	}
+	if len(s.Detection) == 0 {
+		return nil, fmt.Errorf("la regla no define detection")
+	}
+
+	severity, ok := sigmaLevels[strings.ToLower(s.Level)]
+	if !ok {
+		severity = "medium"
+	}
+
+	if _, ok := s.Detection["timeframe"]; ok {
+		return nil, unsupported("timeframe")
+	}
+
+	searches := map[string]match.Matcher{}
+	for name, definition := range s.Detection {
+		if name == "condition" {
+			continue
+		}
+		matcher, err := compileSigmaSearch(definition)
+		if err != nil {
+			return nil, fmt.Errorf("búsqueda '%s': %w", name, err)
+		}
+		searches[name] = matcher
+	}
+
+	var conditions []string
+	switch condition := s.Detection["condition"].(type) {
+	case string:
+		conditions = []string{condition}
+	case []interface{}:
+		for _, c := range condition {
+			text, ok := c.(string)
+			if !ok {
+				return nil, fmt.Errorf("condition inválida")
+			}
+			conditions = append(conditions, text)
+		}
+	default:
+		return nil, fmt.Errorf("la regla no define condition")
+	}
+
+	var matchers match.AnyOf
+	for _, condition := range conditions {
+		matcher, err := parseSigmaCondition(condition, searches)
+		if err != nil {
+			return nil, fmt.Errorf("condition '%s': %w", condition, err)
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	id := s.ID
+	if id == "" {
+		id = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	}
+
+	var matcher match.Matcher = matchers
+	if len(matchers) == 1 {
+		matcher = matchers[0]
+	}
+
+	return &CompiledRule{
+		Rule: Rule{
+			ID:          id,
+			Name:        s.Title,
+			Description: s.Description,
+			Severity:    severity,
+			Tags:        s.Tags,
+		},
+		Source:  "sigma:" + source,
+		Matcher: matcher,
+	}, nil
+}
+
+// compileSigmaSearch compila un identificador de búsqueda: un mapa (AND de campos) o una
+// lista de mapas (OR). Las búsquedas por palabras clave no están soportadas.
+func compileSigmaSearch(definition interface{}) (match.Matcher, error) {
+	switch def := definition.(type) {
+	case map[string]interface{}:
+		return compileSigmaMap(def)
+	case []interface{}:
+		var matchers match.AnyOf
+		for _, item := range def {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, unsupported("búsqueda por palabras clave")
+			}
+			matcher, err := compileSigmaMap(m)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, matcher)
+		}
+		return matchers, nil
+	default:
+		return nil, unsupported("búsqueda por palabras clave")
+	}
+}
+
+func compileSigmaMap(m map[string]interface{}) (match.Matcher, error) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var matchers match.AllOf
+	for _, key := range keys {
+		parts := strings.Split(key, "|")
+		field := parts[0]
+		if field == "" {
+			return nil, unsupported("búsqueda por palabras clave")
+		}
+		if !IsKnownField(field) {
+			return nil, unsupported("campo '%s' no disponible en EnrichedEventRecord", field)
+		}
+		matcher, err := compileSigmaField(field, parts[1:], m[key])
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+// compileSigmaField traduce un campo con modificadores (contains, startswith, endswith,
+// re, cidr, all) y sus valores a un Matcher. Las cadenas se comparan sin distinguir
+// mayúsculas, como en Sigma, y los comodines * y ? se convierten en expresiones regulares.
+func compileSigmaField(field string, modifiers []string, raw interface{}) (match.Matcher, error) {
+	op := match.OpEquals
+	all := false
+	for _, modifier := range modifiers {
+		switch modifier {
+		case "contains":
+			op = match.OpContains
+		case "startswith":
+			op = match.OpStartsWith
+		case "endswith":
+			op = match.OpEndsWith
+		case "re":
+			op = match.OpRegex
+		case "cidr":
+			op = match.OpCIDR
+		case "all":
+			all = true
+		default:
+			return nil, unsupported("modificador '%s'", modifier)
+		}
+	}
+
+	var values []interface{}
+	if list, ok := raw.([]interface{}); ok {
+		values = list
+	} else {
+		values = []interface{}{raw}
+	}
+
+	var matchers []match.Matcher
+	for _, value := range values {
+		matcher, err := sigmaValueMatcher(field, op, value)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	if len(matchers) == 1 {
+		return matchers[0], nil
+	}
+	if all {
+		return match.AllOf(matchers), nil
+	}
+	return match.AnyOf(matchers), nil
+}
+
+func sigmaValueMatcher(field string, op match.Operator, value interface{}) (match.Matcher, error) {
+	if value == nil {
+		return match.NewFieldMatcher(field, match.OpExists, []string{"false"}, false)
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return nil, unsupported("valores anidados en '%s'", field)
+	}
+
+	text := match.ScalarString(value)
+	switch op {
+	case match.OpRegex:
+		return match.NewFieldMatcher(field, match.OpRegex, []string{text}, false)
+	case match.OpCIDR:
+		return match.NewFieldMatcher(field, match.OpCIDR, []string{text}, false)
+	}
+
+	if strings.ContainsAny(text, "*?") {
+		pattern := sigmaWildcardToRegex(text)
+		switch op {
+		case match.OpEquals:
+			pattern = "^" + pattern + "$"
+		case match.OpStartsWith:
+			pattern = "^" + pattern
+		case match.OpEndsWith:
+			pattern = pattern + "$"
+		}
+		return match.NewFieldMatcher(field, match.OpRegex, []string{pattern}, true)
+	}
+	return match.NewFieldMatcher(field, op, []string{text}, true)
+}
+
+// sigmaWildcardToRegex convierte los comodines de Sigma (*, ? y sus escapes \*, \?) a regex.
+func sigmaWildcardToRegex(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\' && i+1 < len(value) && (value[i+1] == '*' || value[i+1] == '?' || value[i+1] == '\\'):
+			b.WriteString(regexp.QuoteMeta(string(value[i+1])))
+			i++
+		case c == '*':
+			b.WriteString(".*")
+		case c == '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// sigmaConditionParser implementa la gramática de condiciones de Sigma:
+//
+//	expr   := term ("or" term)*
+//	term   := factor ("and" factor)*
+//	factor := "not" factor | "(" expr ")" | ("1"|"any"|"all") "of" (patrón|"them") | identificador
+type sigmaConditionParser struct {
+	tokens   []string
+	pos      int
+	searches map[string]match.Matcher
+}
+
+func tokenizeSigmaCondition(condition string) []string {
+	condition = strings.ReplaceAll(condition, "(", " ( ")
+	condition = strings.ReplaceAll(condition, ")", " ) ")
+	return strings.Fields(condition)
+}
+
+func parseSigmaCondition(condition string, searches map[string]match.Matcher) (match.Matcher, error) {
+	if strings.Contains(condition, "|") {
+		return nil, unsupported("agregaciones en la condición")
+	}
+	p := &sigmaConditionParser{tokens: tokenizeSigmaCondition(condition), searches: searches}
+	matcher, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("token inesperado '%s'", p.tokens[p.pos])
+	}
+	return matcher, nil
+}
+
+func (p *sigmaConditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return strings.ToLower(p.tokens[p.pos])
+	}
+	return ""
+}
+
+func (p *sigmaConditionParser) next() string {
+	token := ""
+	if p.pos < len(p.tokens) {
+		token = p.tokens[p.pos]
+		p.pos++
+	}
+	return token
+}
+
+func (p *sigmaConditionParser) expr() (match.Matcher, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	matchers := match.AnyOf{left}
+	for p.peek() == "or" {
+		p.next()
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, right)
+	}
+	if len(matchers) == 1 {
+		return left, nil
+	}
+	return matchers, nil
+}
+
+func (p *sigmaConditionParser) term() (match.Matcher, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	matchers := match.AllOf{left}
+	for p.peek() == "and" {
+		p.next()
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, right)
+	}
+	if len(matchers) == 1 {
+		return left, nil
+	}
+	return matchers, nil
+}
+
+func (p *sigmaConditionParser) factor() (match.Matcher, error) {
+	token := p.peek()
+	switch token {
+	case "":
+		return nil, fmt.Errorf("condición incompleta")
+	case "not":
+		p.next()
+		matcher, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return match.Not{Matcher: matcher}, nil
+	case "(":
+		p.next()
+		matcher, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("falta ')'")
+		}
+		return matcher, nil
+	case "1", "any", "all":
+		if p.pos+1 < len(p.tokens) && strings.ToLower(p.tokens[p.pos+1]) == "of" {
+			p.next()
+			p.next()
+			return p.quantifier(token, p.next())
+		}
+	}
+
+	name := p.next()
+	matcher, ok := p.searches[name]
+	if !ok {
+		return nil, fmt.Errorf("identificador '%s' no definido", name)
+	}
+	return matcher, nil
+}
+
+// quantifier resuelve "1 of patrón", "all of patrón" y sus variantes con "them".
+func (p *sigmaConditionParser) quantifier(kind, pattern string) (match.Matcher, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("falta el patrón después de 'of'")
+	}
+
+	names := make([]string, 0, len(p.searches))
+	for name := range p.searches {
+		if pattern == "them" {
+			if !strings.HasPrefix(name, "_") {
+				names = append(names, name)
+			}
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, name); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("ningún identificador coincide con '%s'", pattern)
+	}
+	sort.Strings(names)
+
+	matchers := make([]match.Matcher, 0, len(names))
+	for _, name := range names {
+		matchers = append(matchers, p.searches[name])
+	}
+	if kind == "all" {
+		return match.AllOf(matchers), nil
+	}
+	return match.AnyOf(matchers), nil
+}
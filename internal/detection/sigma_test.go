@@ -0,0 +1,58 @@
+package detection
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sigmaStopLogging = `title: CloudTrail detenido
+id: %ID%
+status: %STATUS%
+level: high
+logsource:
+  product: aws
+  service: cloudtrail
+detection:
+  selection:
+    eventSource: cloudtrail.amazonaws.com
+    eventName: StopLogging
+  condition: selection
+`
+
+func writeSigma(t *testing.T, dir, name, id, status string) {
+	t.Helper()
+	data := strings.NewReplacer("%ID%", id, "%STATUS%", status).Replace(sigmaStopLogging)
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadSigmaRulesSkipsDeprecated(t *testing.T) {
+	dir := t.TempDir()
+	writeSigma(t, dir, "a.yml", "rule-a", "stable")
+	writeSigma(t, dir, "b.yml", "rule-b", "deprecated")
+	writeSigma(t, dir, "c.yml", "rule-c", "unsupported")
+
+	rules, report, err := LoadSigmaRules(dir)
+	if err != nil {
+		t.Fatalf("LoadSigmaRules: %v", err)
+	}
+	if len(rules) != 1 || rules[0].ID != "rule-a" {
+		t.Fatalf("se cargaron %d reglas, se esperaba solo rule-a", len(rules))
+	}
+	if len(report.Unsupported) != 2 || report.Unsupported[0].ID != "rule-b" || report.Unsupported[1].ID != "rule-c" {
+		t.Errorf("reporte de no soportadas = %+v, se esperaban rule-b y rule-c", report.Unsupported)
+	}
+}
+
+func TestLoadSigmaRulesRejectsDuplicateIDs(t *testing.T) {
+	dir := t.TempDir()
+	writeSigma(t, dir, "a.yml", "rule-a", "stable")
+	writeSigma(t, dir, "b.yml", "rule-a", "test")
+
+	if _, _, err := LoadSigmaRules(dir); err == nil || !strings.Contains(err.Error(), "duplicada") {
+		t.Errorf("error = %v, se esperaba la regla duplicada", err)
+	}
+}
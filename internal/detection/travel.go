@@ -0,0 +1,277 @@
+package detection
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TravelRuleID es el id de regla de las detecciones de viaje imposible. Su estado se
+// persiste junto al de las correlaciones con este id.
+const TravelRuleID = "impossible-travel"
+
+// Valores por defecto de la detección de viaje imposible.
+const (
+	defaultTravelMaxSpeedKmh   = 1000.0 // Algo por encima de un vuelo comercial
+	defaultTravelMinDistanceKm = 500.0  // Por debajo la imprecisión de la geolocalización domina
+	defaultTravelWindow        = 24 * time.Hour
+	earthRadiusKm              = 6371.0
+)
+
+// maxTravelStates es la cantidad de ubicaciones a partir de la cual se purgan las que ya
+// superaron la ventana. Si no alcanza, se descartan las más antiguas hasta dejar
+// travelStatesAfterPurge, de modo que la purga no se repita en cada evento.
+const (
+	maxTravelStates        = 50000
+	travelStatesAfterPurge = maxTravelStates * 9 / 10
+)
+
+// TravelConfig configura la detección de viaje imposible.
+type TravelConfig struct {
+	MaxSpeedKmh   float64       // Velocidad a partir de la cual el desplazamiento se considera imposible
+	MinDistanceKm float64       // Distancia mínima entre ubicaciones para evaluar la velocidad
+	Window        time.Duration // Tiempo durante el que se recuerda la última ubicación del principal
+	Severity      string        // Severidad de las detecciones (high por defecto)
+	IgnoreCIDRs   []string      // Rangos de salida de VPN o corporativos que no representan una ubicación real
+}
+
+// TravelDetector compara la ubicación de eventos consecutivos de un mismo principal
+// (userIdentity.arn) y genera una detección cuando la velocidad necesaria para recorrer la
+// distancia entre ambos supera el máximo configurado.
+type TravelDetector struct {
+	config  TravelConfig
+	ignored []*net.IPNet
+
+	mu     sync.Mutex
+	states map[string]*models.CorrelationState // Última ubicación por principal
+	latest time.Time                           // Hora del evento más reciente visto, referencia de la purga
+}
+
+// NewTravelDetector valida la configuración y crea el detector.
+func NewTravelDetector(config TravelConfig) (*TravelDetector, error) {
+	if config.MaxSpeedKmh <= 0 {
+		config.MaxSpeedKmh = defaultTravelMaxSpeedKmh
+	}
+	if config.MinDistanceKm <= 0 {
+		config.MinDistanceKm = defaultTravelMinDistanceKm
+	}
+	if config.Window <= 0 {
+		config.Window = defaultTravelWindow
+	}
+	if config.Severity == "" {
+		config.Severity = models.SeverityHigh
+	}
+	config.Severity = strings.ToLower(config.Severity)
+	if _, ok := models.SeverityRank[config.Severity]; !ok {
+		return nil, fmt.Errorf("severidad '%s' no válida para viaje imposible", config.Severity)
+	}
+
+	detector := &TravelDetector{
+		config: config,
+		states: map[string]*models.CorrelationState{},
+	}
+	for _, cidr := range config.IgnoreCIDRs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("rango ignorado '%s' no válido para viaje imposible: %w", cidr, err)
+		}
+		detector.ignored = append(detector.ignored, network)
+	}
+	return detector, nil
+}
+
+// LoadStates restaura las últimas ubicaciones persistidas. Se ignoran los estados de otras reglas.
+func (d *TravelDetector) LoadStates(states []*models.CorrelationState) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	loaded := 0
+	for _, state := range states {
+		if state.RuleID != TravelRuleID || len(state.Events) == 0 {
+			continue
+		}
+		d.states[state.Key] = state
+		if state.Events[0].Time.After(d.latest) {
+			d.latest = state.Events[0].Time
+		}
+		loaded++
+	}
+	return loaded
+}
+
+// Evaluate compara la ubicación del evento con la última conocida del principal. Devuelve la
+// detección (o nil) y el estado actualizado a persistir (o nil si no cambió).
+func (d *TravelDetector) Evaluate(record *models.EnrichedEventRecord) (*models.Finding, *models.CorrelationState) {
+	principal := record.UserIdentity.Arn
+	if principal == "" || !record.Enrichment.HasCoordinates() || d.ignoredIP(record.SourceIPAddress) {
+		return nil, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if record.EventTime.After(d.latest) {
+		d.latest = record.EventTime
+	}
+	if len(d.states) >= maxTravelStates {
+		d.purgeExpired()
+	}
+
+	key := TravelRuleID + "#" + principal
+	current := models.CorrelationEvent{
+		EventID: record.ID,
+		Time:    record.EventTime,
+		Values: map[string]string{
+			"ip":        record.SourceIPAddress,
+			"country":   record.Enrichment.Country,
+			"city":      record.Enrichment.City,
+			"latitude":  strconv.FormatFloat(record.Enrichment.Latitude, 'f', -1, 64),
+			"longitude": strconv.FormatFloat(record.Enrichment.Longitude, 'f', -1, 64),
+		},
+	}
+
+	var finding *models.Finding
+	state := d.states[key]
+	if state != nil && record.EventTime.Sub(state.Events[0].Time) <= d.config.Window {
+		finding = d.compare(principal, state.Events[0], current, record)
+	}
+
+	// Los eventos que llegan desordenados se comparan pero no reemplazan a la última ubicación.
+	if state != nil && record.EventTime.Before(state.Events[0].Time) {
+		return finding, nil
+	}
+	state = &models.CorrelationState{
+		Key:       key,
+		RuleID:    TravelRuleID,
+		GroupKey:  "userIdentity.arn=" + principal,
+		Events:    []models.CorrelationEvent{current},
+		UpdatedAt: time.Now(),
+		ExpiresAt: record.EventTime.Add(d.config.Window),
+	}
+	d.states[key] = state
+	return finding, state
+}
+
+// compare calcula la velocidad entre ambas ubicaciones y genera la detección si la supera.
+func (d *TravelDetector) compare(principal string, previous, current models.CorrelationEvent, record *models.EnrichedEventRecord) *models.Finding {
+	prevLat, errLat := strconv.ParseFloat(previous.Values["latitude"], 64)
+	prevLon, errLon := strconv.ParseFloat(previous.Values["longitude"], 64)
+	if errLat != nil || errLon != nil {
+		return nil
+	}
+
+	distance := haversineKm(prevLat, prevLon, record.Enrichment.Latitude, record.Enrichment.Longitude)
+	if distance < d.config.MinDistanceKm {
+		return nil
+	}
+	elapsed := current.Time.Sub(previous.Time)
+	if elapsed < 0 {
+		elapsed = -elapsed
+	}
+	speed := math.Inf(1)
+	if elapsed > 0 {
+		speed = distance / elapsed.Hours()
+	}
+	if speed <= d.config.MaxSpeedKmh {
+		return nil
+	}
+
+	first, last := previous, current
+	if current.Time.Before(previous.Time) {
+		first, last = current, previous
+	}
+	now := time.Now()
+	return &models.Finding{
+		RuleID:   TravelRuleID,
+		RuleName: "Viaje imposible",
+		Description: fmt.Sprintf("%s generó eventos desde %s (%s) y %s (%s) con %s de diferencia: %.0f km a %s.",
+			principal, travelPlace(first), first.Values["ip"], travelPlace(last), last.Values["ip"],
+			elapsed.Round(time.Second), distance, travelSpeed(speed)),
+		Severity:  d.config.Severity,
+		GroupKey:  "userIdentity.arn=" + principal,
+		EventIDs:  []primitive.ObjectID{first.EventID, last.EventID},
+		FirstSeen: first.Time,
+		LastSeen:  last.Time,
+		CreatedAt: now,
+		Status:    models.FindingStatusOpen,
+		UpdatedAt: now,
+	}
+}
+
+// ignoredIP indica si la IP pertenece a un rango de salida de VPN o corporativo.
+func (d *TravelDetector) ignoredIP(value string) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return true // Servicios de AWS (p. ej. "ec2.amazonaws.com") no tienen ubicación
+	}
+	for _, network := range d.ignored {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// purgeExpired descarta las ubicaciones que ya superaron la ventana, tomando como referencia
+// el evento más reciente visto (los eventos pueden llegar con horas del pasado). Si quedan más
+// de travelStatesAfterPurge descarta las más antiguas. Se invoca con mu tomado.
+func (d *TravelDetector) purgeExpired() {
+	for key, state := range d.states {
+		if state.ExpiresAt.Before(d.latest) {
+			delete(d.states, key)
+		}
+	}
+
+	excess := len(d.states) - travelStatesAfterPurge
+	if excess <= 0 {
+		return
+	}
+	type stateAge struct {
+		key  string
+		last time.Time
+	}
+	states := make([]stateAge, 0, len(d.states))
+	for key, state := range d.states {
+		states = append(states, stateAge{key: key, last: state.Events[0].Time})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].last.Before(states[j].last) })
+	for _, state := range states[:excess] {
+		delete(d.states, state.key)
+	}
+}
+
+// haversineKm devuelve la distancia ortodrómica en kilómetros entre dos coordenadas.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func travelPlace(event models.CorrelationEvent) string {
+	if city := event.Values["city"]; city != "" {
+		return city + ", " + event.Values["country"]
+	}
+	return event.Values["country"]
+}
+
+func travelSpeed(speed float64) string {
+	if math.IsInf(speed, 1) {
+		return "velocidad infinita"
+	}
+	return fmt.Sprintf("%.0f km/h", speed)
+}
@@ -0,0 +1,54 @@
+package detection
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func travelRecord(principal, ip string, lat, lon float64, at time.Time) *models.EnrichedEventRecord {
+	record := &models.EnrichedEventRecord{ID: primitive.NewObjectID(), SourceIPAddress: ip}
+	record.UserIdentity.Arn = principal
+	record.EventTime = at
+	record.Enrichment.Latitude = lat
+	record.Enrichment.Longitude = lon
+	return record
+}
+
+// Con eventos históricos la purga se mide con la hora del evento más reciente: la última
+// ubicación sigue disponible y el mapa queda acotado descartando las más antiguas.
+func TestTravelPurgeHistoricalEvents(t *testing.T) {
+	detector, err := NewTravelDetector(TravelConfig{Window: time.Hour})
+	if err != nil {
+		t.Fatalf("NewTravelDetector: %v", err)
+	}
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	detector.Evaluate(travelRecord("arn:aws:iam::111111111111:user/expired", "192.0.2.1", 40.4, -3.7, base.Add(-2*time.Hour)))
+	for i := 1; i < maxTravelStates-1; i++ {
+		principal := fmt.Sprintf("arn:aws:iam::111111111111:user/filler-%d", i)
+		detector.Evaluate(travelRecord(principal, "192.0.2.1", 40.4, -3.7, base.Add(time.Duration(i)*time.Millisecond)))
+	}
+
+	principal := "arn:aws:iam::111111111111:user/alice"
+	if finding, _ := detector.Evaluate(travelRecord(principal, "192.0.2.10", 40.4, -3.7, base.Add(time.Minute))); finding != nil {
+		t.Fatalf("detección inesperada en el primer evento del principal")
+	}
+	finding, _ := detector.Evaluate(travelRecord(principal, "198.51.100.20", 40.7, -74.0, base.Add(2*time.Minute)))
+	if finding == nil {
+		t.Fatalf("no se detectó el viaje imposible con la ubicación anterior aún en la ventana")
+	}
+
+	if len(detector.states) > travelStatesAfterPurge {
+		t.Errorf("quedaron %d ubicaciones tras la purga, se esperaban como máximo %d", len(detector.states), travelStatesAfterPurge)
+	}
+	if _, ok := detector.states[TravelRuleID+"#arn:aws:iam::111111111111:user/expired"]; ok {
+		t.Errorf("no se purgó la ubicación expirada")
+	}
+	if _, ok := detector.states[TravelRuleID+"#arn:aws:iam::111111111111:user/filler-1"]; ok {
+		t.Errorf("no se descartó la ubicación más antigua")
+	}
+}
@@ -0,0 +1,245 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// bundledActionMapping es la clasificación de acciones incluida en el binario.
+//
+//go:embed action_mapping.yaml
+var bundledActionMapping []byte
+
+// ActionMapping clasifica los eventos de un eventSource, o de cualquiera si EventSource está
+// vacío. Los eventNames terminados en "*" se comparan por prefijo.
+type ActionMapping struct {
+	ID          string           `yaml:"id"`
+	EventSource string           `yaml:"event_source"`
+	EventNames  match.StringList `yaml:"event_names"`
+	Category    string           `yaml:"category"`  // Vacío no cambia la categoría
+	Sensitive   bool             `yaml:"sensitive"` // Marca la acción como sensible
+	Reason      string           `yaml:"reason"`    // Motivo de la marca
+	Disabled    bool             `yaml:"disabled"`  // Permite desactivar una acción incluida desde el archivo de sobrescritura
+}
+
+// ActionMappingSet es el formato del archivo de clasificación de acciones.
+type ActionMappingSet struct {
+	Prefixes           map[string]match.StringList `yaml:"prefixes"`            // Categoría -> prefijos de eventName
+	PermissionKeywords match.StringList            `yaml:"permission_keywords"` // Palabras que convierten una escritura en cambio de permisos
+	Actions            []ActionMapping             `yaml:"actions"`
+}
+
+type actionPrefix struct {
+	prefix   string
+	category string
+}
+
+// ActionEnricher clasifica cada evento como lectura, escritura, borrado, cambio de permisos o
+// acceso a datos, y marca las acciones sensibles para la seguridad.
+type ActionEnricher struct {
+	prefixes []actionPrefix // Del más largo al más corto
+	keywords []string
+	bySource map[string][]ActionMapping
+}
+
+// NewActionEnricher carga la clasificación incluida y, si se indica overridePath, la combina
+// con el archivo de sobrescritura: los prefijos de una categoría y las acciones con el mismo
+// id se reemplazan.
+func NewActionEnricher(overridePath string) (*ActionEnricher, error) {
+	set, err := ParseActionMapping(bundledActionMapping, "action_mapping.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	if overridePath != "" {
+		data, err := os.ReadFile(overridePath)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer la clasificación de acciones %s: %w", overridePath, err)
+		}
+		override, err := ParseActionMapping(data, overridePath)
+		if err != nil {
+			return nil, err
+		}
+		set = mergeActionMappings(set, override)
+	}
+
+	return newActionEnricher(set)
+}
+
+// ParseActionMapping decodifica un archivo de clasificación de acciones.
+func ParseActionMapping(data []byte, source string) (*ActionMappingSet, error) {
+	var set ActionMappingSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error al decodificar la clasificación de acciones %s: %w", source, err)
+	}
+	for i, action := range set.Actions {
+		if action.ID == "" {
+			return nil, fmt.Errorf("clasificación de acciones %s: la acción %d no tiene id", source, i)
+		}
+	}
+	return &set, nil
+}
+
+// mergeActionMappings aplica override sobre base conservando el orden de las acciones incluidas.
+func mergeActionMappings(base, override *ActionMappingSet) *ActionMappingSet {
+	merged := &ActionMappingSet{
+		Prefixes:           map[string]match.StringList{},
+		PermissionKeywords: base.PermissionKeywords,
+	}
+	for category, prefixes := range base.Prefixes {
+		merged.Prefixes[category] = prefixes
+	}
+	for category, prefixes := range override.Prefixes {
+		merged.Prefixes[category] = prefixes
+	}
+	if override.PermissionKeywords != nil {
+		merged.PermissionKeywords = override.PermissionKeywords
+	}
+
+	replaced := map[string]ActionMapping{}
+	for _, action := range override.Actions {
+		replaced[action.ID] = action
+	}
+	for _, action := range base.Actions {
+		if replacement, ok := replaced[action.ID]; ok {
+			action = replacement
+			delete(replaced, action.ID)
+		}
+		merged.Actions = append(merged.Actions, action)
+	}
+	for _, action := range override.Actions {
+		if _, ok := replaced[action.ID]; ok {
+			merged.Actions = append(merged.Actions, action)
+		}
+	}
+	return merged
+}
+
+func newActionEnricher(set *ActionMappingSet) (*ActionEnricher, error) {
+	enricher := &ActionEnricher{
+		keywords: set.PermissionKeywords,
+		bySource: map[string][]ActionMapping{},
+	}
+	for category, prefixes := range set.Prefixes {
+		if !models.ActionCategories[category] {
+			return nil, fmt.Errorf("clasificación de acciones: categoría de prefijos '%s' no válida", category)
+		}
+		for _, prefix := range prefixes {
+			enricher.prefixes = append(enricher.prefixes, actionPrefix{prefix: prefix, category: category})
+		}
+	}
+	sort.SliceStable(enricher.prefixes, func(i, j int) bool {
+		if len(enricher.prefixes[i].prefix) != len(enricher.prefixes[j].prefix) {
+			return len(enricher.prefixes[i].prefix) > len(enricher.prefixes[j].prefix)
+		}
+		return enricher.prefixes[i].prefix < enricher.prefixes[j].prefix
+	})
+
+	seen := map[string]bool{}
+	for _, action := range set.Actions {
+		if seen[action.ID] {
+			return nil, fmt.Errorf("acción %s duplicada", action.ID)
+		}
+		seen[action.ID] = true
+		if action.Disabled {
+			continue
+		}
+		if len(action.EventNames) == 0 {
+			return nil, fmt.Errorf("acción %s: se requiere event_names", action.ID)
+		}
+		if action.Category == "" && !action.Sensitive {
+			return nil, fmt.Errorf("acción %s: se requiere category o sensitive", action.ID)
+		}
+		if action.Category != "" && !models.ActionCategories[action.Category] {
+			return nil, fmt.Errorf("acción %s: categoría '%s' no válida", action.ID, action.Category)
+		}
+		enricher.bySource[action.EventSource] = append(enricher.bySource[action.EventSource], action)
+	}
+	return enricher, nil
+}
+
+// Name implementa services.Enricher.
+func (e *ActionEnricher) Name() string {
+	return "actions"
+}
+
+// Enrich guarda en record.Action la categoría de la acción y si es sensible. Las acciones del
+// eventSource del evento tienen prioridad sobre las que aplican a cualquier servicio.
+func (e *ActionEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	if record.EventName == "" {
+		return nil
+	}
+	action := &models.ActionInfo{}
+	for _, mappings := range [][]ActionMapping{e.bySource[record.EventSource], e.bySource[""]} {
+		for _, mapping := range mappings {
+			if !eventNameMatches(mapping.EventNames, record.EventName) {
+				continue
+			}
+			if action.Category == "" {
+				action.Category = mapping.Category
+			}
+			if mapping.Sensitive && !action.Sensitive {
+				action.Sensitive, action.Reason = true, mapping.Reason
+			}
+		}
+	}
+
+	if action.Category == "" {
+		action.Category = e.classify(record)
+	}
+	action.ReadOnly = action.Category == models.ActionRead || action.Category == models.ActionDataAccess
+	record.Action = action
+	return nil
+}
+
+// classify deduce la categoría del prefijo del eventName o, si no tiene uno conocido, del
+// campo readOnly de CloudTrail.
+func (e *ActionEnricher) classify(record *models.EnrichedEventRecord) string {
+	category := ""
+	for _, prefix := range e.prefixes {
+		if hasActionPrefix(record.EventName, prefix.prefix) {
+			category = prefix.category
+			break
+		}
+	}
+	if category == "" {
+		category = models.ActionWrite
+		if record.ReadOnly != nil && *record.ReadOnly {
+			category = models.ActionRead
+		}
+	}
+
+	switch category {
+	case models.ActionRead:
+		// Las lecturas de los eventos de datos leen el contenido, no la configuración
+		if record.EventCategory == "Data" {
+			return models.ActionDataAccess
+		}
+	case models.ActionWrite, models.ActionDelete:
+		for _, keyword := range e.keywords {
+			if strings.Contains(record.EventName, keyword) {
+				return models.ActionPermissionChange
+			}
+		}
+	}
+	return category
+}
+
+// hasActionPrefix indica si eventName empieza con el verbo prefix seguido de otra palabra
+// ("GetObject", pero no "Getaway") o es el verbo mismo.
+func hasActionPrefix(eventName, prefix string) bool {
+	if !strings.HasPrefix(eventName, prefix) {
+		return false
+	}
+	rest := eventName[len(prefix):]
+	return rest == "" || unicode.IsUpper(rune(rest[0])) || unicode.IsDigit(rune(rest[0]))
+}
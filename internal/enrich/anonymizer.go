@@ -0,0 +1,185 @@
+package enrich
+
+import (
+	"bufio"
+	"bytes"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Categorías de las listas de anonimizadores.
+const (
+	AnonymizerTor     = "tor"
+	AnonymizerVPN     = "vpn"
+	AnonymizerHosting = "hosting"
+)
+
+// AnonymizerLists son las rutas de las listas locales de cada categoría.
+type AnonymizerLists struct {
+	Tor     []string
+	VPN     []string
+	Hosting []string
+}
+
+func (l AnonymizerLists) byCategory() map[string][]string {
+	return map[string][]string{
+		AnonymizerTor:     l.Tor,
+		AnonymizerVPN:     l.VPN,
+		AnonymizerHosting: l.Hosting,
+	}
+}
+
+// AnonymizerEnricher marca en record.Enrichment si la IP de origen es un nodo de salida de
+// Tor, un anonimizador o VPN comercial o un proveedor de hosting, según listas locales de IP
+// y rangos CIDR. Las listas se recargan cuando cambian en disco.
+type AnonymizerEnricher struct {
+	lists map[string][]string
+
+	mu       sync.RWMutex
+	indexes  map[string]*threatIndex
+	modTimes map[string]time.Time
+
+	reload *PeriodicReload
+}
+
+// NewAnonymizerEnricher carga las listas indicadas. Debe haber al menos una.
+func NewAnonymizerEnricher(lists AnonymizerLists) (*AnonymizerEnricher, error) {
+	enricher := &AnonymizerEnricher{lists: map[string][]string{}, reload: NewPeriodicReload()}
+	for category, paths := range lists.byCategory() {
+		for _, path := range paths {
+			if path = strings.TrimSpace(path); path != "" {
+				enricher.lists[category] = append(enricher.lists[category], path)
+			}
+		}
+	}
+	if len(enricher.lists) == 0 {
+		return nil, fmt.Errorf("no se indicó ninguna lista de Tor, VPN o hosting")
+	}
+	if _, err := enricher.Reload(); err != nil {
+		return nil, err
+	}
+	return enricher, nil
+}
+
+// Reload vuelve a leer las listas si alguna cambió desde la última carga. Si la lectura
+// falla se conservan las listas anteriores.
+func (e *AnonymizerEnricher) Reload() (bool, error) {
+	var paths []string
+	for _, categoryPaths := range e.lists {
+		paths = append(paths, categoryPaths...)
+	}
+	modTimes, err := fileModTimes(paths, "la lista")
+	if err != nil {
+		return false, err
+	}
+	e.mu.RLock()
+	unchanged := sameModTimes(e.modTimes, modTimes)
+	e.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	indexes := map[string]*threatIndex{}
+	for category, paths := range e.lists {
+		index := newThreatIndex()
+		for _, path := range paths {
+			if err := readNetworkList(path, category, index); err != nil {
+				return false, err
+			}
+		}
+		index.cidrs.sortLengths()
+		indexes[category] = index
+	}
+
+	e.mu.Lock()
+	e.indexes = indexes
+	e.modTimes = modTimes
+	e.mu.Unlock()
+	logger.InfoLog.Printf("Listas de anonimizadores cargadas: %d de Tor, %d de VPN y %d de hosting.",
+		indexSize(indexes[AnonymizerTor]), indexSize(indexes[AnonymizerVPN]), indexSize(indexes[AnonymizerHosting]))
+	return true, nil
+}
+
+func indexSize(index *threatIndex) int {
+	if index == nil {
+		return 0
+	}
+	return index.count
+}
+
+// readNetworkList agrega al índice las IP y rangos CIDR de la lista, uno por línea. Admite
+// comentarios con "#" y el formato "ExitAddress <ip> <fecha>" de la lista de salidas de Tor.
+func readNetworkList(path, category string, index *threatIndex) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error al leer la lista %s: %w", path, err)
+	}
+	list := &Feed{Name: category, Path: path, Confidence: defaultFeedConfidence}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		value := fields[0]
+		if value == "ExitAddress" && len(fields) > 1 {
+			value = fields[1]
+		}
+		// Las listas solo contienen direcciones; se ignoran otras líneas del formato de Tor
+		if indicator := list.newIndicator(value, 0, ""); indicator != nil && indicator.Type != models.IndicatorDomain {
+			index.add(indicator)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error al leer la lista %s: %w", path, err)
+	}
+	return nil
+}
+
+// Start revisa las listas cada interval y las recarga si cambiaron. Con interval <= 0 no
+// se recargan.
+func (e *AnonymizerEnricher) Start(interval time.Duration) {
+	e.reload.Start(interval, "Error al recargar las listas de anonimizadores, se conservan las anteriores", func() error {
+		_, err := e.Reload()
+		return err
+	})
+}
+
+// Stop detiene la recarga periódica.
+func (e *AnonymizerEnricher) Stop() {
+	e.reload.Stop()
+}
+
+// Name implementa services.Enricher.
+func (e *AnonymizerEnricher) Name() string {
+	return "anonymizer"
+}
+
+// Enrich marca isTor, isVPN e isHosting según las listas que contienen la IP de origen.
+func (e *AnonymizerEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	ip := net.ParseIP(record.SourceIPAddress)
+	if ip == nil {
+		return nil
+	}
+
+	e.mu.RLock()
+	indexes := e.indexes
+	e.mu.RUnlock()
+
+	listed := func(category string) bool {
+		index := indexes[category]
+		return index != nil && len(index.lookupIP(ip)) > 0
+	}
+	record.Enrichment.IsTor = listed(AnonymizerTor)
+	record.Enrichment.IsVPN = listed(AnonymizerVPN)
+	record.Enrichment.IsHosting = listed(AnonymizerHosting)
+	return nil
+}
@@ -0,0 +1,171 @@
+package enrich
+
+import (
+	"bytes"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// asnRecord es el registro de las bases GeoLite2-ASN / GeoIP2-ISP en formato MMDB.
+type asnRecord struct {
+	Number       uint   `maxminddb:"autonomous_system_number"`
+	Organization string `maxminddb:"autonomous_system_organization"`
+}
+
+type asnEntry struct {
+	asn     string
+	org     string
+	network string
+}
+
+// ASNEnricher completa el sistema autónomo, su organización y el prefijo de la IP de origen a
+// partir de bases locales: archivos MMDB (GeoLite2-ASN) o tablas CSV de prefijos. Lo que
+// indique la base local reemplaza el ASN obtenido de ip-api.com.
+type ASNEnricher struct {
+	readers []*maxminddb.Reader
+	table   *prefixTable[*asnEntry]
+}
+
+// NewASNEnricher abre las bases indicadas. El formato se deduce de la extensión: .mmdb o
+// cualquier otra para CSV con columnas network, asn y organization.
+func NewASNEnricher(paths []string) (*ASNEnricher, error) {
+	enricher := &ASNEnricher{table: newPrefixTable[*asnEntry]()}
+	prefixes := 0
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		if strings.EqualFold(filepath.Ext(path), ".mmdb") {
+			reader, err := maxminddb.Open(path)
+			if err != nil {
+				enricher.Close()
+				return nil, fmt.Errorf("error al abrir la base ASN %s: %w", path, err)
+			}
+			enricher.readers = append(enricher.readers, reader)
+			continue
+		}
+		n, err := enricher.loadCSV(path)
+		if err != nil {
+			enricher.Close()
+			return nil, err
+		}
+		prefixes += n
+	}
+	if len(enricher.readers) == 0 && prefixes == 0 {
+		enricher.Close()
+		return nil, fmt.Errorf("no se indicó ninguna base ASN con datos")
+	}
+	enricher.table.sortLengths()
+	logger.InfoLog.Printf("Bases ASN cargadas: %d archivos MMDB y %d prefijos CSV.", len(enricher.readers), prefixes)
+	return enricher, nil
+}
+
+// loadCSV agrega los prefijos de una tabla CSV. Admite el formato GeoLite2-ASN-Blocks
+// (network, autonomous_system_number, autonomous_system_organization) o cualquier tabla con
+// encabezado network/prefix, asn y as_org/organization.
+func (e *ASNEnricher) loadCSV(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("error al leer la base ASN %s: %w", path, err)
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := map[string]int{"network": 0, "asn": 1, "org": 2}
+	count := 0
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error al leer la base ASN %s: %w", path, err)
+		}
+		if first && net.ParseIP(strings.Split(record[0], "/")[0]) == nil {
+			for i, name := range record {
+				switch strings.ToLower(strings.TrimSpace(name)) {
+				case "network", "prefix", "cidr":
+					columns["network"] = i
+				case "asn", "autonomous_system_number":
+					columns["asn"] = i
+				case "as_org", "organization", "org", "autonomous_system_organization":
+					columns["org"] = i
+				}
+			}
+			continue
+		}
+
+		column := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		_, network, err := net.ParseCIDR(column("network"))
+		if err != nil {
+			return 0, fmt.Errorf("base ASN %s: prefijo '%s' no válido", path, column("network"))
+		}
+		asn := models.NormalizeASN(column("asn"))
+		if asn == "" {
+			return 0, fmt.Errorf("base ASN %s: ASN '%s' no válido para %s", path, column("asn"), network)
+		}
+		e.table.add(network, &asnEntry{asn: asn, org: column("org"), network: network.String()})
+		count++
+	}
+	return count, nil
+}
+
+// Close cierra las bases MMDB.
+func (e *ASNEnricher) Close() {
+	for _, reader := range e.readers {
+		reader.Close()
+	}
+}
+
+// Name implementa services.Enricher.
+func (e *ASNEnricher) Name() string {
+	return "asn"
+}
+
+// Enrich guarda el ASN, la organización y el prefijo de la IP de origen. Las tablas CSV se
+// consultan antes que las bases MMDB, de modo que sirven para corregir o ampliar estas.
+func (e *ASNEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	ip := net.ParseIP(record.SourceIPAddress)
+	if ip == nil {
+		return nil
+	}
+
+	if entries := e.table.lookup(ip); len(entries) > 0 {
+		record.Enrichment.ASN = entries[0].asn
+		record.Enrichment.ASOrg = entries[0].org
+		record.Enrichment.ASNetwork = entries[0].network
+		return nil
+	}
+
+	for _, reader := range e.readers {
+		var result asnRecord
+		network, ok, err := reader.LookupNetwork(ip, &result)
+		if err != nil {
+			return fmt.Errorf("error al buscar %s en la base ASN: %w", record.SourceIPAddress, err)
+		}
+		if ok && result.Number != 0 {
+			record.Enrichment.ASN = fmt.Sprintf("AS%d", result.Number)
+			record.Enrichment.ASOrg = result.Organization
+			record.Enrichment.ASNetwork = network.String()
+			return nil
+		}
+	}
+	return nil
+}
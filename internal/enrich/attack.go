@@ -0,0 +1,232 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// bundledAttackMapping es el mapeo ATT&CK incluido en el binario.
+//
+//go:embed attack_mapping.yaml
+var bundledAttackMapping []byte
+
+// AttackTechnique describe una técnica de ATT&CK y las tácticas a las que pertenece.
+type AttackTechnique struct {
+	Name    string   `yaml:"name"`
+	Tactics []string `yaml:"tactics"`
+}
+
+// AttackMapping asocia eventos de un eventSource a técnicas de ATT&CK. Los eventNames
+// terminados en "*" se comparan por prefijo y Match permite exigir condiciones adicionales
+// sobre el evento (por ejemplo sobre requestParameters).
+type AttackMapping struct {
+	ID          string            `yaml:"id"`
+	EventSource string            `yaml:"event_source"`
+	EventNames  match.StringList  `yaml:"event_names"`
+	Techniques  []string          `yaml:"techniques"`
+	Match       *match.MatchBlock `yaml:"match"`
+	Disabled    bool              `yaml:"disabled"` // Permite desactivar un mapeo incluido desde el archivo de sobrescritura
+}
+
+// AttackMappingSet es el formato del archivo de mapeo.
+type AttackMappingSet struct {
+	Techniques map[string]AttackTechnique `yaml:"techniques"`
+	Mappings   []AttackMapping            `yaml:"mappings"`
+}
+
+type compiledAttackMapping struct {
+	AttackMapping
+	matcher match.Matcher
+}
+
+// AttackEnricher etiqueta cada evento con las técnicas y tácticas de ATT&CK for Cloud que
+// le corresponden según el mapeo.
+type AttackEnricher struct {
+	techniques map[string]AttackTechnique
+	bySource   map[string][]*compiledAttackMapping
+}
+
+// NewAttackEnricher carga el mapeo incluido y, si se indica overridePath, lo combina con
+// el archivo de sobrescritura: las técnicas y los mapeos con el mismo id se reemplazan.
+func NewAttackEnricher(overridePath string) (*AttackEnricher, error) {
+	set, err := ParseAttackMapping(bundledAttackMapping, "attack_mapping.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	if overridePath != "" {
+		data, err := os.ReadFile(overridePath)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer el mapeo ATT&CK %s: %w", overridePath, err)
+		}
+		override, err := ParseAttackMapping(data, overridePath)
+		if err != nil {
+			return nil, err
+		}
+		set = mergeAttackMappings(set, override)
+	}
+
+	return newAttackEnricher(set)
+}
+
+// ParseAttackMapping decodifica un archivo de mapeo ATT&CK.
+func ParseAttackMapping(data []byte, source string) (*AttackMappingSet, error) {
+	var set AttackMappingSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error al decodificar el mapeo ATT&CK %s: %w", source, err)
+	}
+	for i, mapping := range set.Mappings {
+		if mapping.ID == "" {
+			return nil, fmt.Errorf("mapeo ATT&CK %s: el mapeo %d no tiene id", source, i)
+		}
+	}
+	return &set, nil
+}
+
+// mergeAttackMappings aplica override sobre base conservando el orden de los mapeos incluidos.
+func mergeAttackMappings(base, override *AttackMappingSet) *AttackMappingSet {
+	merged := &AttackMappingSet{Techniques: map[string]AttackTechnique{}}
+	for id, technique := range base.Techniques {
+		merged.Techniques[id] = technique
+	}
+	for id, technique := range override.Techniques {
+		merged.Techniques[id] = technique
+	}
+
+	replaced := map[string]AttackMapping{}
+	for _, mapping := range override.Mappings {
+		replaced[mapping.ID] = mapping
+	}
+	for _, mapping := range base.Mappings {
+		if replacement, ok := replaced[mapping.ID]; ok {
+			mapping = replacement
+			delete(replaced, mapping.ID)
+		}
+		merged.Mappings = append(merged.Mappings, mapping)
+	}
+	for _, mapping := range override.Mappings {
+		if _, ok := replaced[mapping.ID]; ok {
+			merged.Mappings = append(merged.Mappings, mapping)
+		}
+	}
+	return merged
+}
+
+func newAttackEnricher(set *AttackMappingSet) (*AttackEnricher, error) {
+	enricher := &AttackEnricher{
+		techniques: set.Techniques,
+		bySource:   map[string][]*compiledAttackMapping{},
+	}
+	seen := map[string]bool{}
+	for _, mapping := range set.Mappings {
+		if seen[mapping.ID] {
+			return nil, fmt.Errorf("mapeo ATT&CK %s duplicado", mapping.ID)
+		}
+		seen[mapping.ID] = true
+		if mapping.Disabled {
+			continue
+		}
+		if mapping.EventSource == "" || len(mapping.EventNames) == 0 || len(mapping.Techniques) == 0 {
+			return nil, fmt.Errorf("mapeo ATT&CK %s: se requieren event_source, event_names y techniques", mapping.ID)
+		}
+		for _, id := range mapping.Techniques {
+			if _, ok := set.Techniques[id]; !ok {
+				return nil, fmt.Errorf("mapeo ATT&CK %s: técnica %s no definida", mapping.ID, id)
+			}
+		}
+
+		compiled := &compiledAttackMapping{AttackMapping: mapping}
+		if mapping.Match != nil {
+			matcher, err := mapping.Match.Compile()
+			if err != nil {
+				return nil, fmt.Errorf("mapeo ATT&CK %s: %w", mapping.ID, err)
+			}
+			compiled.matcher = matcher
+		}
+		enricher.bySource[mapping.EventSource] = append(enricher.bySource[mapping.EventSource], compiled)
+	}
+	return enricher, nil
+}
+
+// Name implementa services.Enricher.
+func (e *AttackEnricher) Name() string {
+	return "attack"
+}
+
+// Techniques devuelve las técnicas conocidas por el enriquecedor.
+func (e *AttackEnricher) Techniques() map[string]AttackTechnique {
+	return e.techniques
+}
+
+// Enrich guarda en record.Attack las técnicas de los mapeos que coinciden y sus tácticas.
+func (e *AttackEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	mappings := e.bySource[record.EventSource]
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	var doc match.Document
+	techniques := map[string]bool{}
+	tactics := map[string]bool{}
+	for _, mapping := range mappings {
+		if !eventNameMatches(mapping.EventNames, record.EventName) {
+			continue
+		}
+		if mapping.matcher != nil {
+			if doc == nil {
+				var err error
+				if doc, err = match.NewDocument(record); err != nil {
+					return err
+				}
+			}
+			if !mapping.matcher.Match(doc) {
+				continue
+			}
+		}
+		for _, id := range mapping.Techniques {
+			techniques[id] = true
+			for _, tactic := range e.techniques[id].Tactics {
+				tactics[tactic] = true
+			}
+		}
+	}
+
+	if len(techniques) == 0 {
+		return nil
+	}
+	record.Attack = &models.AttackTags{
+		Techniques: sortedKeys(techniques),
+		Tactics:    sortedKeys(tactics),
+	}
+	return nil
+}
+
+func eventNameMatches(names []string, eventName string) bool {
+	for _, name := range names {
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			if strings.HasPrefix(eventName, prefix) {
+				return true
+			}
+		} else if name == eventName {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,140 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// awsServicePrincipalSuffixes son los sufijos de los principales de servicio que CloudTrail
+// registra como sourceIPAddress cuando un servicio actúa en nombre del usuario.
+var awsServicePrincipalSuffixes = []string{".amazonaws.com", ".amazonaws.com.cn", ".amazon.com"}
+
+// awsInternalSource es el sourceIPAddress de las llamadas internas de AWS.
+const awsInternalSource = "AWS Internal"
+
+// awsGenericService es el servicio con el que ip-ranges.json publica todos los rangos de
+// Amazon, además de repetirlos con el servicio específico.
+const awsGenericService = "AMAZON"
+
+// awsIPRanges es el formato de https://ip-ranges.amazonaws.com/ip-ranges.json.
+type awsIPRanges struct {
+	SyncToken  string `json:"syncToken"`
+	CreateDate string `json:"createDate"`
+	Prefixes   []struct {
+		IPPrefix string `json:"ip_prefix"`
+		Region   string `json:"region"`
+		Service  string `json:"service"`
+	} `json:"prefixes"`
+	IPv6Prefixes []struct {
+		IPv6Prefix string `json:"ipv6_prefix"`
+		Region     string `json:"region"`
+		Service    string `json:"service"`
+	} `json:"ipv6_prefixes"`
+}
+
+type awsRange struct {
+	prefix  string
+	region  string
+	service string
+}
+
+// AWSSourceEnricher reconoce los orígenes propios de AWS: los principales de servicio
+// (ec2.amazonaws.com, "AWS Internal") y las IP publicadas en ip-ranges.json. Se registra como
+// clasificador para que esos orígenes no se geolocalicen.
+type AWSSourceEnricher struct {
+	ranges *prefixTable[*awsRange]
+}
+
+// NewAWSSourceEnricher carga ip-ranges.json desde ipRangesPath. Sin ruta solo se reconocen
+// los principales de servicio.
+func NewAWSSourceEnricher(ipRangesPath string) (*AWSSourceEnricher, error) {
+	enricher := &AWSSourceEnricher{ranges: newPrefixTable[*awsRange]()}
+	if ipRangesPath == "" {
+		return enricher, nil
+	}
+
+	data, err := os.ReadFile(ipRangesPath)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer los rangos de IP de AWS %s: %w", ipRangesPath, err)
+	}
+	var ranges awsIPRanges
+	if err := json.Unmarshal(data, &ranges); err != nil {
+		return nil, fmt.Errorf("error al decodificar los rangos de IP de AWS %s: %w", ipRangesPath, err)
+	}
+
+	add := func(prefix, region, service string) error {
+		_, network, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return fmt.Errorf("rango de IP de AWS '%s' no válido: %w", prefix, err)
+		}
+		enricher.ranges.add(network, &awsRange{prefix: network.String(), region: region, service: service})
+		return nil
+	}
+	for _, prefix := range ranges.Prefixes {
+		if err := add(prefix.IPPrefix, prefix.Region, prefix.Service); err != nil {
+			return nil, err
+		}
+	}
+	for _, prefix := range ranges.IPv6Prefixes {
+		if err := add(prefix.IPv6Prefix, prefix.Region, prefix.Service); err != nil {
+			return nil, err
+		}
+	}
+	enricher.ranges.sortLengths()
+	logger.InfoLog.Printf("Rangos de IP de AWS cargados: %d rangos (syncToken %s).", len(ranges.Prefixes)+len(ranges.IPv6Prefixes), ranges.SyncToken)
+	return enricher, nil
+}
+
+// Name implementa services.Enricher.
+func (e *AWSSourceEnricher) Name() string {
+	return "aws_source"
+}
+
+// Enrich guarda en record.Enrichment.AWS el servicio y la región de AWS del origen.
+func (e *AWSSourceEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	source := strings.TrimSpace(record.SourceIPAddress)
+	if ip := net.ParseIP(source); ip != nil {
+		if match := e.lookup(ip); match != nil {
+			record.Enrichment.AWS = &models.AWSSource{Service: match.service, Region: match.region, Prefix: match.prefix}
+		}
+		return nil
+	}
+
+	if source == awsInternalSource {
+		record.Enrichment.AWS = &models.AWSSource{Service: source, Region: record.AwsRegion, Principal: true}
+	} else if isAWSServicePrincipal(source) {
+		record.Enrichment.AWS = &models.AWSSource{Service: strings.ToLower(source), Region: record.AwsRegion, Principal: true}
+	}
+	return nil
+}
+
+// lookup devuelve el rango más específico que contiene la IP, prefiriendo un servicio
+// concreto (EC2, S3...) sobre el genérico AMAZON.
+func (e *AWSSourceEnricher) lookup(ip net.IP) *awsRange {
+	matches := e.ranges.lookup(ip)
+	for _, match := range matches {
+		if match.service != awsGenericService {
+			return match
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches[0]
+}
+
+func isAWSServicePrincipal(source string) bool {
+	source = strings.ToLower(source)
+	for _, suffix := range awsServicePrincipalSuffixes {
+		if strings.HasSuffix(source, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,304 @@
+package enrich
+
+import (
+	"bufio"
+	"bytes"
+	"cloudtrail-enrichment-api-golang/models"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formatos de feed de indicadores admitidos.
+const (
+	FeedPlain = "plain" // Un indicador por línea; "#" inicia un comentario
+	FeedCSV   = "csv"   // Columnas indicator, type, confidence y description (con encabezado)
+	FeedSTIX  = "stix"  // Bundle STIX 2.1 con objetos indicator o ipv4-addr/ipv6-addr/domain-name
+)
+
+// defaultFeedConfidence es la confianza de los indicadores cuando ni el feed ni el indicador la indican.
+const defaultFeedConfidence = 50
+
+// Feed es la configuración de un feed local de indicadores.
+type Feed struct {
+	Name       string `yaml:"name"`
+	Path       string `yaml:"path"`
+	Format     string `yaml:"format"`     // plain, csv o stix; por defecto se deduce de la extensión
+	Confidence int    `yaml:"confidence"` // Confianza por defecto de los indicadores del feed (0-100)
+	Enabled    *bool  `yaml:"enabled"`
+}
+
+// FeedSet es el formato del archivo de feeds.
+type FeedSet struct {
+	Feeds []Feed `yaml:"feeds"`
+}
+
+// Indicator es un indicador de un feed: una IP, un rango CIDR o un dominio.
+type Indicator struct {
+	Value       string
+	Type        string
+	Feed        string
+	Confidence  int
+	Description string
+}
+
+// LoadFeeds lee y valida el archivo de feeds. Los feeds deshabilitados se omiten.
+func LoadFeeds(path string) ([]*Feed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el archivo de feeds %s: %w", path, err)
+	}
+	var set FeedSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error al decodificar el archivo de feeds %s: %w", path, err)
+	}
+
+	feeds := make([]*Feed, 0, len(set.Feeds))
+	names := map[string]bool{}
+	for i := range set.Feeds {
+		feed := &set.Feeds[i]
+		if feed.Enabled != nil && !*feed.Enabled {
+			continue
+		}
+		if feed.Name == "" || feed.Path == "" {
+			return nil, fmt.Errorf("feed %d: se requieren name y path", i)
+		}
+		if names[feed.Name] {
+			return nil, fmt.Errorf("feed %s duplicado", feed.Name)
+		}
+		names[feed.Name] = true
+		if feed.Format == "" {
+			feed.Format = formatFromExtension(feed.Path)
+		}
+		switch feed.Format {
+		case FeedPlain, FeedCSV, FeedSTIX:
+		default:
+			return nil, fmt.Errorf("feed %s: formato '%s' no válido", feed.Name, feed.Format)
+		}
+		if feed.Confidence == 0 {
+			feed.Confidence = defaultFeedConfidence
+		}
+		if feed.Confidence < 0 || feed.Confidence > 100 {
+			return nil, fmt.Errorf("feed %s: confianza %d fuera de rango", feed.Name, feed.Confidence)
+		}
+		feeds = append(feeds, feed)
+	}
+	return feeds, nil
+}
+
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FeedCSV
+	case ".json":
+		return FeedSTIX
+	default:
+		return FeedPlain
+	}
+}
+
+// ReadIndicators lee los indicadores del feed desde disco.
+func (f *Feed) ReadIndicators() ([]*Indicator, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el feed %s: %w", f.Name, err)
+	}
+	switch f.Format {
+	case FeedCSV:
+		return f.parseCSV(data)
+	case FeedSTIX:
+		return f.parseSTIX(data)
+	default:
+		return f.parsePlain(data)
+	}
+}
+
+// newIndicator clasifica el valor como IP, CIDR o dominio. Devuelve nil si no es ninguno.
+func (f *Feed) newIndicator(value string, confidence int, description string) *Indicator {
+	value = strings.ToLower(strings.TrimSpace(value))
+	indicator := &Indicator{Value: value, Feed: f.Name, Confidence: confidence, Description: description}
+	if confidence <= 0 || confidence > 100 {
+		indicator.Confidence = f.Confidence
+	}
+
+	switch {
+	case net.ParseIP(value) != nil:
+		indicator.Type = models.IndicatorIP
+		indicator.Value = net.ParseIP(value).String()
+	case strings.Contains(value, "/"):
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil
+		}
+		indicator.Type = models.IndicatorCIDR
+		indicator.Value = network.String()
+	case isDomain(value):
+		indicator.Type = models.IndicatorDomain
+		indicator.Value = strings.TrimSuffix(value, ".")
+	default:
+		return nil
+	}
+	return indicator
+}
+
+func (f *Feed) parsePlain(data []byte) ([]*Indicator, error) {
+	var indicators []*Indicator
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if indicator := f.newIndicator(fields[0], 0, ""); indicator != nil {
+			indicators = append(indicators, indicator)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer el feed %s: %w", f.Name, err)
+	}
+	return indicators, nil
+}
+
+func (f *Feed) parseCSV(data []byte) ([]*Indicator, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := map[string]int{"indicator": 0, "confidence": -1, "description": -1}
+	var indicators []*Indicator
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error al leer el feed CSV %s: %w", f.Name, err)
+		}
+		if first && isCSVHeader(record) {
+			for i, name := range record {
+				name = strings.ToLower(strings.TrimSpace(name))
+				if name == "value" || name == "ioc" {
+					name = "indicator"
+				}
+				columns[name] = i
+			}
+			continue
+		}
+
+		column := func(name string) string {
+			if i, ok := columns[name]; ok && i >= 0 && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		confidence, _ := strconv.Atoi(column("confidence"))
+		if indicator := f.newIndicator(column("indicator"), confidence, column("description")); indicator != nil {
+			indicators = append(indicators, indicator)
+		}
+	}
+	return indicators, nil
+}
+
+// isCSVHeader indica si la primera fila es un encabezado y no un indicador.
+func isCSVHeader(record []string) bool {
+	for _, name := range record {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "indicator", "value", "ioc":
+			return true
+		}
+	}
+	return false
+}
+
+// stixObject contiene los campos usados de los objetos de un bundle STIX 2.1.
+type stixObject struct {
+	Type        string     `json:"type"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Pattern     string     `json:"pattern"`
+	PatternType string     `json:"pattern_type"`
+	Confidence  int        `json:"confidence"`
+	Revoked     bool       `json:"revoked"`
+	ValidUntil  *time.Time `json:"valid_until"`
+	Value       string     `json:"value"`
+}
+
+// stixPatternValue extrae los valores comparados por igualdad en un patrón STIX, p. ej.
+// [ipv4-addr:value = '203.0.113.7'] OR [domain-name:value = 'evil.example'].
+var stixPatternValue = regexp.MustCompile(`(ipv4-addr|ipv6-addr|domain-name|url):value\s*=\s*'((?:[^'\\]|\\.)*)'`)
+
+func (f *Feed) parseSTIX(data []byte) ([]*Indicator, error) {
+	var bundle struct {
+		Type    string       `json:"type"`
+		Objects []stixObject `json:"objects"`
+	}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("error al decodificar el bundle STIX %s: %w", f.Name, err)
+	}
+	if bundle.Type != "bundle" {
+		return nil, fmt.Errorf("el feed %s no es un bundle STIX", f.Name)
+	}
+
+	now := time.Now()
+	var indicators []*Indicator
+	for _, object := range bundle.Objects {
+		switch object.Type {
+		case "indicator":
+			if object.Revoked || (object.ValidUntil != nil && object.ValidUntil.Before(now)) {
+				continue
+			}
+			if object.PatternType != "" && object.PatternType != "stix" {
+				continue
+			}
+			description := object.Name
+			if description == "" {
+				description = object.Description
+			}
+			for _, match := range stixPatternValue.FindAllStringSubmatch(object.Pattern, -1) {
+				value := strings.ReplaceAll(match[2], `\'`, `'`)
+				if match[1] == "url" {
+					value = hostFromURL(value)
+				}
+				if indicator := f.newIndicator(value, object.Confidence, description); indicator != nil {
+					indicators = append(indicators, indicator)
+				}
+			}
+		case "ipv4-addr", "ipv6-addr", "domain-name":
+			if indicator := f.newIndicator(object.Value, 0, ""); indicator != nil {
+				indicators = append(indicators, indicator)
+			}
+		}
+	}
+	return indicators, nil
+}
+
+// domainPattern acepta nombres de dominio con al menos dos etiquetas.
+var domainPattern = regexp.MustCompile(`^([a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}\.?$`)
+
+func isDomain(value string) bool {
+	return len(value) <= 253 && domainPattern.MatchString(value)
+}
+
+// hostFromURL devuelve el host de una URL, o el valor original si no tiene esquema.
+func hostFromURL(value string) string {
+	if _, rest, ok := strings.Cut(value, "://"); ok {
+		value = rest
+	}
+	value, _, _ = strings.Cut(value, "/")
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		return host
+	}
+	return value
+}
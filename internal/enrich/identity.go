@@ -0,0 +1,178 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"strings"
+)
+
+// ssoRolePrefix es el prefijo de los roles que IAM Identity Center crea para cada conjunto de
+// permisos. En sus sesiones el nombre de sesión es el usuario de Identity Center.
+const ssoRolePrefix = "AWSReservedSSO_"
+
+// anonymousPrincipal es el principalId de las solicitudes sin firmar.
+const anonymousPrincipal = "ANONYMOUS_PRINCIPAL"
+
+// ParseIdentity descompone userIdentity en cuenta, tipo de principal, rol, sesión y actor.
+// Usa el ARN cuando está presente y, si no, el tipo, el principalId y la cuenta. Devuelve nil
+// si el evento no trae identidad.
+func ParseIdentity(userIdentity models.UserIdentity) *models.Identity {
+	if userIdentity == (models.UserIdentity{SessionContext: userIdentity.SessionContext}) {
+		return nil
+	}
+	identity := &models.Identity{PrincipalType: models.PrincipalUnknown}
+	parsePrincipalID(userIdentity.PrincipalID, identity)
+
+	if arn, ok := models.ParseARN(userIdentity.Arn); ok {
+		parseIdentityARN(arn, identity)
+	} else {
+		parseIdentityType(userIdentity, identity)
+	}
+
+	if identity.Account == "" && identity.PrincipalType != models.PrincipalAnonymous {
+		identity.Account = userIdentity.AccountID
+	}
+	if userIdentity.Type == "Root" {
+		identity.PrincipalType, identity.IsRoot = models.PrincipalRoot, true
+	}
+	if identity.Actor == "" {
+		identity.Actor = identityActor(identity)
+	}
+	return identity
+}
+
+// parsePrincipalID toma el identificador único y el nombre de sesión del principalId:
+// "AIDAEXAMPLE" en usuarios y "AROAEXAMPLE:sesion" en roles asumidos. En la cuenta raíz y en
+// los usuarios federados el principalId empieza con el número de cuenta y no se usa.
+func parsePrincipalID(principalID string, identity *models.Identity) {
+	uniqueID, session, _ := strings.Cut(principalID, ":")
+	if !isUniqueID(uniqueID) {
+		return
+	}
+	identity.UniqueID = uniqueID
+	identity.SessionName = session
+}
+
+// parseIdentityARN interpreta los ARN de identidad de IAM y STS.
+func parseIdentityARN(arn models.ARN, identity *models.Identity) {
+	identity.Partition, identity.Account = arn.Partition, arn.Account
+	resourceType, resourceID := arn.ResourceType(), arn.ResourceID()
+
+	switch {
+	case arn.Service == "iam" && arn.Resource == "root":
+		identity.PrincipalType, identity.IsRoot = models.PrincipalRoot, true
+		identity.Actor = arn.String()
+	case arn.Service == "iam" && (resourceType == "user" || resourceType == "role"):
+		// La ruta no forma parte del actor: el nombre es único en la cuenta
+		identity.Path, identity.Name = splitIAMPath(resourceID)
+		identity.PrincipalType = resourceType
+		if resourceType == "role" {
+			identity.Role = identity.Name
+			identity.IsSSO = strings.HasPrefix(identity.Name, ssoRolePrefix)
+		}
+		identity.Actor = iamARN(arn, resourceType+"/"+identity.Name)
+	case arn.Service == "sts" && resourceType == "assumed-role":
+		role, session, _ := strings.Cut(resourceID, "/")
+		identity.PrincipalType = models.PrincipalAssumedRole
+		identity.Name, identity.Role, identity.SessionName = role, role, session
+		identity.IsSSO = strings.HasPrefix(role, ssoRolePrefix)
+		if identity.IsSSO {
+			// La sesión de un rol de Identity Center es la persona, que es el actor
+			identity.Actor = arn.String()
+		} else {
+			// El nombre de sesión suele cambiar en cada llamada (botocore-session-..., i-...):
+			// el actor estable es el rol
+			identity.Actor = iamARN(arn, "role/"+role)
+		}
+	case arn.Service == "sts" && resourceType == "federated-user":
+		identity.PrincipalType, identity.Name = models.PrincipalFederatedUser, resourceID
+		identity.Actor = arn.String()
+	default:
+		identity.Actor = arn.String()
+	}
+}
+
+// parseIdentityType interpreta las identidades sin ARN: servicios de AWS, solicitudes anónimas
+// y principales de otras cuentas.
+func parseIdentityType(userIdentity models.UserIdentity, identity *models.Identity) {
+	switch {
+	case userIdentity.Type == "AWSService":
+		identity.PrincipalType = models.PrincipalService
+		identity.Name = strings.ToLower(userIdentity.InvokedBy)
+	case userIdentity.PrincipalID == anonymousPrincipal || userIdentity.Type == "Anonymous":
+		identity.PrincipalType = models.PrincipalAnonymous
+	case userIdentity.Type == "IAMUser" && userIdentity.UserName != "":
+		identity.PrincipalType, identity.Name = models.PrincipalUser, userIdentity.UserName
+	case strings.HasPrefix(identity.UniqueID, "AROA"):
+		// Principal de otra cuenta: el prefijo del identificador único indica el tipo
+		identity.PrincipalType = models.PrincipalAssumedRole
+	case strings.HasPrefix(identity.UniqueID, "AIDA"):
+		identity.PrincipalType = models.PrincipalUser
+	}
+}
+
+// identityActor compone el actor de las identidades cuyo ARN no lo determinó.
+func identityActor(identity *models.Identity) string {
+	switch {
+	case identity.PrincipalType == models.PrincipalService:
+		return identity.Name
+	case identity.PrincipalType == models.PrincipalAnonymous:
+		return models.PrincipalAnonymous
+	case identity.Account == "":
+		return identity.UniqueID
+	case identity.PrincipalType == models.PrincipalRoot:
+		return "arn:aws:iam::" + identity.Account + ":root"
+	case identity.PrincipalType == models.PrincipalUser && identity.Name != "":
+		return "arn:aws:iam::" + identity.Account + ":user/" + identity.Name
+	case identity.UniqueID != "":
+		// Principal de otra cuenta sin ARN: el identificador único no cambia entre sesiones
+		return identity.Account + ":" + identity.UniqueID
+	}
+	return ""
+}
+
+// splitIAMPath separa "admins/ops/alice" en la ruta "/admins/ops/" y el nombre "alice".
+func splitIAMPath(resourceID string) (string, string) {
+	i := strings.LastIndex(resourceID, "/")
+	if i < 0 {
+		return "", resourceID
+	}
+	return "/" + resourceID[:i+1], resourceID[i+1:]
+}
+
+// iamARN compone el ARN de IAM del recurso indicado en la cuenta del ARN original.
+func iamARN(arn models.ARN, resource string) string {
+	return models.ARN{Partition: arn.Partition, Service: "iam", Account: arn.Account, Resource: resource}.String()
+}
+
+// isUniqueID indica si el valor es un identificador único de IAM (AIDA..., AROA..., AKIA...).
+func isUniqueID(value string) bool {
+	if len(value) < 16 || value[0] != 'A' {
+		return false
+	}
+	for _, c := range value {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// IdentityEnricher guarda en record.Identity la identidad descompuesta.
+type IdentityEnricher struct{}
+
+// NewIdentityEnricher crea el enriquecedor de identidades.
+func NewIdentityEnricher() *IdentityEnricher {
+	return &IdentityEnricher{}
+}
+
+// Name implementa services.Enricher.
+func (e *IdentityEnricher) Name() string {
+	return "identity"
+}
+
+// Enrich implementa services.Enricher.
+func (e *IdentityEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	record.Identity = ParseIdentity(record.UserIdentity)
+	return nil
+}
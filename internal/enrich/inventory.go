@@ -0,0 +1,382 @@
+package enrich
+
+import (
+	"bytes"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// awsAccountID reconoce los números de cuenta de AWS.
+var awsAccountID = regexp.MustCompile(`^\d{12}$`)
+
+// inventoryFile es el formato YAML del inventario.
+type inventoryFile struct {
+	Accounts  []inventoryFileEntry `yaml:"accounts"`
+	Resources []inventoryFileEntry `yaml:"resources"`
+}
+
+type inventoryFileEntry struct {
+	ID                 string            `yaml:"id"`  // Número de cuenta
+	ARN                string            `yaml:"arn"` // ARN del recurso; admite "*" final
+	Name               string            `yaml:"name"`
+	Environment        string            `yaml:"environment"`
+	Team               string            `yaml:"team"`
+	DataClassification string            `yaml:"data_classification"`
+	Labels             map[string]string `yaml:"labels"`
+}
+
+// LoadInventoryFile lee un inventario en YAML (listas accounts y resources) o CSV (con
+// encabezado). El formato se deduce de la extensión.
+func LoadInventoryFile(path string) ([]*models.InventoryItem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el inventario %s: %w", path, err)
+	}
+
+	var items []*models.InventoryItem
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		items, err = parseInventoryYAML(data)
+	case ".csv":
+		items, err = parseInventoryCSV(data)
+	default:
+		return nil, fmt.Errorf("inventario %s: formato no admitido, se espera .yaml, .yml o .csv", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("inventario %s: %w", path, err)
+	}
+
+	seen := map[string]bool{}
+	for _, item := range items {
+		if err := ValidateInventoryItem(item); err != nil {
+			return nil, fmt.Errorf("inventario %s: %w", path, err)
+		}
+		if seen[item.Kind+"|"+item.Key] {
+			return nil, fmt.Errorf("inventario %s: %s %s duplicado", path, item.Kind, item.Key)
+		}
+		seen[item.Kind+"|"+item.Key] = true
+	}
+	return items, nil
+}
+
+func parseInventoryYAML(data []byte) ([]*models.InventoryItem, error) {
+	var file inventoryFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error al decodificar el YAML: %w", err)
+	}
+	items := make([]*models.InventoryItem, 0, len(file.Accounts)+len(file.Resources))
+	for _, entry := range file.Accounts {
+		items = append(items, entry.item(models.InventoryKindAccount, entry.ID))
+	}
+	for _, entry := range file.Resources {
+		items = append(items, entry.item(models.InventoryKindResource, entry.ARN))
+	}
+	return items, nil
+}
+
+func (e inventoryFileEntry) item(kind, key string) *models.InventoryItem {
+	return &models.InventoryItem{
+		Kind:               kind,
+		Key:                strings.TrimSpace(key),
+		Name:               e.Name,
+		Environment:        e.Environment,
+		Team:               e.Team,
+		DataClassification: e.DataClassification,
+		Labels:             e.Labels,
+	}
+}
+
+// parseInventoryCSV lee un CSV con encabezado. La clave está en la columna key, account_id o
+// arn; la columna kind es opcional y, si falta, se deduce de la clave. Las columnas que no son
+// name, environment, team ni data_classification se guardan como etiquetas.
+func parseInventoryCSV(data []byte) ([]*models.InventoryItem, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el CSV: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var items []*models.InventoryItem
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error al leer el CSV: %w", err)
+		}
+
+		item := &models.InventoryItem{}
+		for i, column := range header {
+			value := strings.TrimSpace(record[i])
+			switch column {
+			case "kind":
+				item.Kind = strings.ToLower(value)
+			case "key", "account_id", "arn":
+				if value != "" {
+					item.Key = value
+				}
+			case "name":
+				item.Name = value
+			case "environment":
+				item.Environment = value
+			case "team":
+				item.Team = value
+			case "data_classification":
+				item.DataClassification = value
+			default:
+				if value != "" {
+					if item.Labels == nil {
+						item.Labels = map[string]string{}
+					}
+					item.Labels[column] = value
+				}
+			}
+		}
+		if item.Kind == "" {
+			item.Kind = inventoryKind(item.Key)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// inventoryKind deduce el tipo de elemento a partir de su clave.
+func inventoryKind(key string) string {
+	if awsAccountID.MatchString(key) {
+		return models.InventoryKindAccount
+	}
+	return models.InventoryKindResource
+}
+
+// ValidateInventoryItem comprueba el tipo y la clave de un elemento del inventario: un número de
+// cuenta de 12 dígitos o un ARN, que puede terminar en "*".
+func ValidateInventoryItem(item *models.InventoryItem) error {
+	if !models.InventoryKinds[item.Kind] {
+		return fmt.Errorf("tipo '%s' no válido para %s: se admite account o resource", item.Kind, item.Key)
+	}
+	switch item.Kind {
+	case models.InventoryKindAccount:
+		if !awsAccountID.MatchString(item.Key) {
+			return fmt.Errorf("cuenta '%s' no válida: se esperan 12 dígitos", item.Key)
+		}
+	case models.InventoryKindResource:
+		if _, ok := models.ParseARN(strings.TrimSuffix(item.Key, "*")); !ok {
+			return fmt.Errorf("ARN '%s' no válido", item.Key)
+		}
+	}
+	return nil
+}
+
+// inventoryIndex indexa el inventario por número de cuenta y por ARN. Los ARN terminados en
+// "*" se guardan como prefijos, del más largo al más corto.
+type inventoryIndex struct {
+	accounts  map[string]*models.InventoryItem
+	resources map[string]*models.InventoryItem
+	prefixes  []*models.InventoryItem
+}
+
+func newInventoryIndex(items []*models.InventoryItem) *inventoryIndex {
+	index := &inventoryIndex{
+		accounts:  map[string]*models.InventoryItem{},
+		resources: map[string]*models.InventoryItem{},
+	}
+	for _, item := range items {
+		switch {
+		case item.Kind == models.InventoryKindAccount:
+			index.accounts[item.Key] = item
+		case strings.HasSuffix(item.Key, "*"):
+			index.prefixes = append(index.prefixes, item)
+		default:
+			index.resources[item.Key] = item
+		}
+	}
+	sort.SliceStable(index.prefixes, func(i, j int) bool {
+		return len(index.prefixes[i].Key) > len(index.prefixes[j].Key)
+	})
+	return index
+}
+
+// resource devuelve el elemento del ARN exacto o, si no hay, el del prefijo más largo que lo abarca.
+func (x *inventoryIndex) resource(arn string) *models.InventoryItem {
+	if item, ok := x.resources[arn]; ok {
+		return item
+	}
+	for _, item := range x.prefixes {
+		if strings.HasPrefix(arn, strings.TrimSuffix(item.Key, "*")) {
+			return item
+		}
+	}
+	return nil
+}
+
+// InventoryEnricher etiqueta cada evento con la metadata del inventario de su cuenta y de sus
+// recursos. Trabaja sobre una copia en memoria que el servicio de inventario reemplaza con Load
+// cada vez que el inventario cambia.
+type InventoryEnricher struct {
+	mu    sync.RWMutex
+	index *inventoryIndex
+}
+
+// NewInventoryEnricher crea el enriquecedor con el inventario vacío.
+func NewInventoryEnricher() *InventoryEnricher {
+	return &InventoryEnricher{index: newInventoryIndex(nil)}
+}
+
+// Load reemplaza el inventario en memoria.
+func (e *InventoryEnricher) Load(items []*models.InventoryItem) {
+	index := newInventoryIndex(items)
+	e.mu.Lock()
+	e.index = index
+	e.mu.Unlock()
+}
+
+// Name implementa services.Enricher.
+func (e *InventoryEnricher) Name() string {
+	return "inventory"
+}
+
+// Enrich busca en el inventario la cuenta del principal y los ARN del evento.
+func (e *InventoryEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	e.mu.RLock()
+	index := e.index
+	e.mu.RUnlock()
+
+	labels := &models.InventoryLabels{}
+	account := index.accounts[eventAccountID(record)]
+	var resources []*models.InventoryItem
+	for _, arn := range eventARNs(record) {
+		if item := index.resource(arn); item != nil {
+			resources = append(resources, item)
+			labels.Resources = append(labels.Resources, models.InventoryResourceLabels{
+				ARN:                arn,
+				Name:               item.Name,
+				Environment:        item.Environment,
+				Team:               item.Team,
+				DataClassification: item.DataClassification,
+			})
+		}
+	}
+	if account == nil && len(resources) == 0 {
+		record.Inventory = nil
+		return nil
+	}
+
+	// Los recursos son más específicos que la cuenta: sus valores tienen prioridad
+	if account != nil {
+		labels.AccountName = account.Name
+		resources = append(resources, account)
+	}
+	for i := len(resources) - 1; i >= 0; i-- {
+		for key, value := range resources[i].Labels {
+			if labels.Labels == nil {
+				labels.Labels = map[string]string{}
+			}
+			labels.Labels[key] = value
+		}
+	}
+	for _, item := range resources {
+		labels.Environment = firstNonEmpty(labels.Environment, item.Environment)
+		labels.Team = firstNonEmpty(labels.Team, item.Team)
+		labels.DataClassification = firstNonEmpty(labels.DataClassification, item.DataClassification)
+	}
+	record.Inventory = labels
+	return nil
+}
+
+// eventAccountID devuelve la cuenta del principal del evento.
+func eventAccountID(record *models.EnrichedEventRecord) string {
+	if record.UserIdentity.AccountID != "" {
+		return record.UserIdentity.AccountID
+	}
+	if record.Identity != nil {
+		return record.Identity.Account
+	}
+	return ""
+}
+
+// eventARNs devuelve, sin repetir, los ARN de los recursos del evento. Si el enriquecedor de
+// recursos no los obtuvo, usa los ARN presentes en requestParameters y el ARN del bucket de
+// requestParameters.bucketName.
+func eventARNs(record *models.EnrichedEventRecord) []string {
+	var arns []string
+	if len(record.Resources) > 0 {
+		for _, resource := range record.Resources {
+			if resource.ARN != "" {
+				arns = append(arns, resource.ARN)
+			}
+		}
+		return arns
+	}
+	seen := map[string]bool{}
+	add := func(arn string) {
+		if !seen[arn] {
+			seen[arn] = true
+			arns = append(arns, arn)
+		}
+	}
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			if _, ok := models.ParseARN(v); ok {
+				add(strings.TrimSpace(v))
+			}
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				walk(v[key])
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	if bucket, ok := record.RequestParameters.Other["bucketName"].(string); ok && bucket != "" {
+		add("arn:" + eventPartition(record) + ":s3:::" + bucket)
+	}
+	walk(record.RequestParameters.Other)
+	return arns
+}
+
+// eventPartition devuelve la partición del principal del evento o "aws" si no se conoce.
+func eventPartition(record *models.EnrichedEventRecord) string {
+	if record.Identity != nil && record.Identity.Partition != "" {
+		return record.Identity.Partition
+	}
+	return "aws"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// privateNetworks son los rangos de uso interno: RFC 1918, CGNAT (RFC 6598) e IPv6 ULA.
+var privateNetworks = mustParseCIDRs(
+	"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+)
+
+// reservedNetworks son los rangos especiales que no se enrutan en Internet (RFC 6890).
+var reservedNetworks = mustParseCIDRs(
+	"0.0.0.0/8", "127.0.0.0/8", "169.254.0.0/16", "192.0.0.0/24", "192.0.2.0/24",
+	"198.18.0.0/15", "198.51.100.0/24", "203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4",
+	"::/128", "::1/128", "100::/64", "2001:db8::/32", "fe80::/10", "ff00::/8",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// NetworkClassifier clasifica la IP de origen como pública, privada, reservada o de endpoint
+// de VPC y le asigna la sede de los rangos internos configurados. Se registra como el primer
+// clasificador para que las direcciones que no son públicas nunca se envíen a ip-api.com.
+type NetworkClassifier struct {
+	sites *prefixTable[string]
+}
+
+// NewNetworkClassifier valida los rangos internos, indexados por CIDR con el nombre de la sede
+// u oficina como valor. Si varios rangos contienen una IP gana el más específico.
+func NewNetworkClassifier(sites map[string]string) (*NetworkClassifier, error) {
+	classifier := &NetworkClassifier{sites: newPrefixTable[string]()}
+	for cidr, site := range sites {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("rango interno '%s' no válido: %w", cidr, err)
+		}
+		if site = strings.TrimSpace(site); site == "" {
+			return nil, fmt.Errorf("el rango interno '%s' no tiene sede", cidr)
+		}
+		classifier.sites.add(network, site)
+	}
+	classifier.sites.sortLengths()
+	return classifier, nil
+}
+
+// Name implementa services.Enricher.
+func (c *NetworkClassifier) Name() string {
+	return "network"
+}
+
+// Enrich guarda en record.Enrichment el tipo de red y la sede de la IP de origen. Los orígenes
+// que no son IP (principales de servicio) no se clasifican.
+func (c *NetworkClassifier) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	ip := net.ParseIP(strings.TrimSpace(record.SourceIPAddress))
+	if ip == nil {
+		return nil
+	}
+
+	record.Enrichment.NetworkType = ClassifyIP(ip)
+	if record.Enrichment.NetworkType == models.NetworkPrivate && record.VpcEndpointID != "" {
+		record.Enrichment.NetworkType = models.NetworkVPCEndpoint
+	}
+	if sites := c.sites.lookup(ip); len(sites) > 0 {
+		record.Enrichment.Site = sites[0]
+	}
+	return nil
+}
+
+// ClassifyIP devuelve models.NetworkPrivate, models.NetworkReserved o models.NetworkPublic.
+func ClassifyIP(ip net.IP) string {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return models.NetworkPrivate
+		}
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return models.NetworkReserved
+		}
+	}
+	return models.NetworkPublic
+}
@@ -0,0 +1,133 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// prefixTable busca los rangos CIDR que contienen una IP sin recorrerlos todos: guarda un mapa
+// por longitud de prefijo con la red enmascarada como clave, de modo que una búsqueda cuesta
+// un acceso por longitud distinta.
+type prefixTable[T any] struct {
+	byLength  map[int]map[string][]T // Longitud de prefijo (IPv6 con 128+) -> red -> valores
+	v4Lengths []int
+	v6Lengths []int
+}
+
+func newPrefixTable[T any]() *prefixTable[T] {
+	return &prefixTable[T]{byLength: map[int]map[string][]T{}}
+}
+
+func (t *prefixTable[T]) add(network *net.IPNet, value T) {
+	ones, bits := network.Mask.Size()
+	key := ones
+	if bits == 128 {
+		key += 128
+	}
+	if t.byLength[key] == nil {
+		t.byLength[key] = map[string][]T{}
+		if bits == 128 {
+			t.v6Lengths = append(t.v6Lengths, ones)
+		} else {
+			t.v4Lengths = append(t.v4Lengths, ones)
+		}
+	}
+	t.byLength[key][network.String()] = append(t.byLength[key][network.String()], value)
+}
+
+// sortLengths ordena las longitudes de prefijo de mayor a menor. Se llama una vez cargados
+// todos los rangos.
+func (t *prefixTable[T]) sortLengths() {
+	sort.Sort(sort.Reverse(sort.IntSlice(t.v4Lengths)))
+	sort.Sort(sort.Reverse(sort.IntSlice(t.v6Lengths)))
+}
+
+// lookup devuelve los valores de los rangos que contienen ip, de los más específicos a los
+// más amplios.
+func (t *prefixTable[T]) lookup(ip net.IP) []T {
+	var matches []T
+	lengths, bits, offset := t.v6Lengths, 128, 128
+	if v4 := ip.To4(); v4 != nil {
+		ip, lengths, bits, offset = v4, t.v4Lengths, 32, 0
+	}
+	for _, ones := range lengths {
+		mask := net.CIDRMask(ones, bits)
+		network := &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+		matches = append(matches, t.byLength[ones+offset][network.String()]...)
+	}
+	return matches
+}
+
+// PeriodicReload ejecuta una recarga en segundo plano cada cierto intervalo hasta que se
+// detiene. La comparten los enriquecedores que releen archivos y el servicio de inventario.
+type PeriodicReload struct {
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewPeriodicReload crea una recarga periódica detenida.
+func NewPeriodicReload() *PeriodicReload {
+	return &PeriodicReload{stop: make(chan struct{})}
+}
+
+// Start llama a reload cada interval. Los errores se registran con el prefijo what y se
+// conserva el estado anterior. Con interval <= 0 no se recarga.
+func (p *PeriodicReload) Start(interval time.Duration, what string, reload func() error) {
+	if interval <= 0 {
+		return
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				if err := reload(); err != nil {
+					logger.ErrorLog.Printf("%s: %v", what, err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop detiene la recarga y espera a que termine la que esté en curso.
+func (p *PeriodicReload) Stop() {
+	close(p.stop)
+	p.wg.Wait()
+}
+
+// fileModTimes devuelve la fecha de modificación de cada archivo. kind nombra el tipo de
+// archivo en los errores ("la lista", "el feed").
+func fileModTimes(paths []string, kind string) (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer %s %s: %w", kind, path, err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// sameModTimes indica si ningún archivo cambió, apareció o desapareció desde la carga anterior.
+func sameModTimes(previous, current map[string]time.Time) bool {
+	if previous == nil || len(previous) != len(current) {
+		return false
+	}
+	for path, modTime := range current {
+		if !previous[path].Equal(modTime) {
+			return false
+		}
+	}
+	return true
+}
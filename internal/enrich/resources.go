@@ -0,0 +1,441 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ResourceScope es la partición, la región y la cuenta del evento, con las que se componen los
+// ARN de los recursos que los parámetros nombran sin ARN.
+type ResourceScope struct {
+	Partition string
+	Region    string
+	Account   string
+}
+
+// ARN compone el ARN de un recurso de la cuenta del evento. Los recursos de IAM son globales y
+// no llevan región. Devuelve "" si no se conoce la cuenta.
+func (s ResourceScope) ARN(service, resource string) string {
+	if s.Account == "" {
+		return ""
+	}
+	region := s.Region
+	if service == "iam" {
+		region = ""
+	}
+	return models.ARN{Partition: s.Partition, Service: service, Region: region, Account: s.Account, Resource: resource}.String()
+}
+
+// ResourceExtractor obtiene los recursos propios de un servicio a partir de los parámetros de
+// la solicitud y de la respuesta del evento.
+type ResourceExtractor func(record *models.EnrichedEventRecord, scope ResourceScope) []models.Resource
+
+// resourceServiceNames son los nombres de servicio de los tipos de CloudFormation que no se
+// obtienen pasando el nombre del ARN a mayúsculas.
+var resourceServiceNames = map[string]string{
+	"lambda":         "Lambda",
+	"dynamodb":       "DynamoDB",
+	"logs":           "Logs",
+	"cloudtrail":     "CloudTrail",
+	"secretsmanager": "SecretsManager",
+	"events":         "Events",
+	"states":         "StepFunctions",
+}
+
+// resourceTypes son los tipos de CloudFormation por servicio y tipo de recurso del ARN que no
+// se deducen directamente del ARN. La clave "servicio:" corresponde a los ARN sin tipo.
+var resourceTypes = map[string]string{
+	"s3:":                   "AWS::S3::Bucket",
+	"sqs:":                  "AWS::SQS::Queue",
+	"sns:":                  "AWS::SNS::Topic",
+	"iam:policy":            "AWS::IAM::ManagedPolicy",
+	"iam:mfa":               "AWS::IAM::VirtualMFADevice",
+	"ec2:vpc":               "AWS::EC2::VPC",
+	"rds:db":                "AWS::RDS::DBInstance",
+	"rds:cluster":           "AWS::RDS::DBCluster",
+	"logs:log-group":        "AWS::Logs::LogGroup",
+	"states:stateMachine":   "AWS::StepFunctions::StateMachine",
+	"elasticloadbalancing:": "AWS::ElasticLoadBalancingV2::LoadBalancer",
+}
+
+// resourceFromARN devuelve el recurso de un ARN con el tipo deducido de su servicio y tipo de
+// recurso. Los ARN con comodines, como los de las políticas, no son recursos.
+func resourceFromARN(value string) (models.Resource, bool) {
+	arn, ok := models.ParseARN(value)
+	if !ok || strings.Contains(arn.Resource, "*") || arn.Resource == "" {
+		return models.Resource{}, false
+	}
+	resourceType, resourceID := arn.ResourceType(), arn.ResourceID()
+	resource := models.Resource{ARN: arn.String(), ID: resourceID}
+	if awsAccountID.MatchString(arn.Account) {
+		// Las políticas administradas por AWS tienen "aws" en lugar de la cuenta
+		resource.AccountID = arn.Account
+	}
+
+	switch {
+	case arn.Service == "s3" && resourceType != "":
+		// En S3 lo que sigue al bucket es la clave del objeto, no un tipo
+		resource.Type, resource.ID = "AWS::S3::Object", arn.Resource
+		return resource, true
+	case arn.Service == "iam" && resourceType == "root":
+		return models.Resource{}, false
+	case arn.Service == "iam":
+		_, resource.ID = splitIAMPath(resourceID)
+	}
+
+	if resourceType, ok := resourceTypes[arn.Service+":"+resourceType]; ok {
+		resource.Type = resourceType
+		return resource, true
+	}
+	service, ok := resourceServiceNames[arn.Service]
+	if !ok {
+		service = strings.ToUpper(arn.Service)
+	}
+	resource.Type = "AWS::" + service
+	if resourceType != "" {
+		resource.Type += "::" + camelCase(resourceType)
+	}
+	return resource, true
+}
+
+// camelCase convierte "security-group" en "SecurityGroup".
+func camelCase(value string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// resourceField es un parámetro que nombra un recurso de un servicio. prefix es lo que
+// antecede al nombre en el recurso del ARN ("role/", "function:"); si está vacío el recurso se
+// guarda solo con su identificador, porque su ARN no se puede componer a partir del nombre.
+type resourceField struct {
+	key      string
+	typ      string
+	prefix   string
+	topLevel bool // Solo se busca en el primer nivel, para claves genéricas como "name"
+}
+
+// fieldExtractor busca los parámetros indicados en la solicitud y en la respuesta, en
+// cualquier nivel salvo que el campo sea de primer nivel. Los valores pueden ser un texto o una
+// lista de textos; si el valor ya es un ARN se usa tal cual.
+func fieldExtractor(service string, fields ...resourceField) ResourceExtractor {
+	return func(record *models.EnrichedEventRecord, scope ResourceScope) []models.Resource {
+		var resources []models.Resource
+		for _, field := range fields {
+			for _, value := range parameterValues(record, field.key, field.topLevel) {
+				if resource, ok := resourceFromARN(value); ok {
+					resources = append(resources, resource)
+					continue
+				}
+				resource := models.Resource{Type: field.typ, ID: value, AccountID: scope.Account}
+				if field.prefix != "" {
+					resource.ARN = scope.ARN(service, field.prefix+value)
+				}
+				resources = append(resources, resource)
+			}
+		}
+		return resources
+	}
+}
+
+// parameterValues devuelve los valores de texto de la clave en requestParameters y
+// responseElements.
+func parameterValues(record *models.EnrichedEventRecord, key string, topLevel bool) []string {
+	var values []string
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if text, ok := item.(string); ok {
+					collect(text)
+				}
+			}
+		}
+	}
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if found, ok := v[key]; ok {
+				collect(found)
+			}
+			if topLevel {
+				return
+			}
+			for _, k := range parameterKeys(v) {
+				walk(v[k])
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(record.RequestParameters.Other)
+	walk(record.ResponseElements.Other)
+	return values
+}
+
+func parameterKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// s3Resources devuelve el bucket y, si el evento nombra una clave, el objeto.
+func s3Resources(record *models.EnrichedEventRecord, scope ResourceScope) []models.Resource {
+	bucket, _ := record.RequestParameters.Other["bucketName"].(string)
+	if bucket == "" {
+		return nil
+	}
+	resources := []models.Resource{{
+		Type: "AWS::S3::Bucket",
+		ARN:  "arn:" + scope.Partition + ":s3:::" + bucket,
+		ID:   bucket,
+	}}
+	if key, _ := record.RequestParameters.Other["key"].(string); key != "" {
+		resources = append(resources, models.Resource{
+			Type: "AWS::S3::Object",
+			ARN:  "arn:" + scope.Partition + ":s3:::" + bucket + "/" + key,
+			ID:   bucket + "/" + key,
+		})
+	}
+	return resources
+}
+
+// kmsResources interpreta keyId, que puede ser un ARN, un alias o el identificador de la clave.
+func kmsResources(record *models.EnrichedEventRecord, scope ResourceScope) []models.Resource {
+	var resources []models.Resource
+	for _, value := range parameterValues(record, "keyId", false) {
+		if resource, ok := resourceFromARN(value); ok {
+			resources = append(resources, resource)
+			continue
+		}
+		resource := models.Resource{Type: "AWS::KMS::Key", ID: value, AccountID: scope.Account}
+		if strings.HasPrefix(value, "alias/") {
+			resource.Type = "AWS::KMS::Alias"
+			resource.ARN = scope.ARN("kms", value)
+		} else {
+			resource.ARN = scope.ARN("kms", "key/"+value)
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// sqsResources convierte queueUrl (https://sqs.region.amazonaws.com/cuenta/cola) en el ARN de la cola.
+func sqsResources(record *models.EnrichedEventRecord, scope ResourceScope) []models.Resource {
+	var resources []models.Resource
+	for _, value := range parameterValues(record, "queueUrl", true) {
+		parsed, err := url.Parse(value)
+		if err != nil {
+			continue
+		}
+		account, name, ok := strings.Cut(strings.Trim(parsed.Path, "/"), "/")
+		if !ok || !awsAccountID.MatchString(account) || name == "" {
+			continue
+		}
+		region := scope.Region
+		if host := strings.Split(parsed.Host, "."); len(host) > 2 && host[0] == "sqs" {
+			region = host[1]
+		}
+		arn := models.ARN{Partition: scope.Partition, Service: "sqs", Region: region, Account: account, Resource: name}
+		resources = append(resources, models.Resource{Type: "AWS::SQS::Queue", ARN: arn.String(), ID: name, AccountID: account})
+	}
+	return resources
+}
+
+// ssmResources devuelve los parámetros de Parameter Store de los eventos GetParameter(s),
+// PutParameter, DeleteParameter(s)... En el resto de eventos de SSM "name" es un documento.
+func ssmResources(record *models.EnrichedEventRecord, scope ResourceScope) []models.Resource {
+	if !strings.Contains(record.EventName, "Parameter") {
+		return nil
+	}
+	var resources []models.Resource
+	for _, key := range []string{"name", "names"} {
+		for _, value := range parameterValues(record, key, true) {
+			if resource, ok := resourceFromARN(value); ok {
+				resources = append(resources, resource)
+				continue
+			}
+			resource := "parameter/" + strings.TrimPrefix(value, "/")
+			resources = append(resources, models.Resource{Type: "AWS::SSM::Parameter", ARN: scope.ARN("ssm", resource), ID: value, AccountID: scope.Account})
+		}
+	}
+	return resources
+}
+
+// ec2InstanceResources devuelve las instancias de los instancesSet tipados de la solicitud y de
+// la respuesta.
+func ec2InstanceResources(record *models.EnrichedEventRecord, scope ResourceScope) []models.Resource {
+	var ids []string
+	for _, item := range record.RequestParameters.InstancesSet.Items {
+		ids = append(ids, item.InstanceID)
+	}
+	for _, item := range record.ResponseElements.InstancesSet.Items {
+		ids = append(ids, item.InstanceID)
+	}
+	var resources []models.Resource
+	for _, id := range ids {
+		if id != "" {
+			resources = append(resources, models.Resource{Type: "AWS::EC2::Instance", ARN: scope.ARN("ec2", "instance/"+id), ID: id, AccountID: scope.Account})
+		}
+	}
+	return resources
+}
+
+// ResourceEnricher guarda en resources los recursos que tocó el evento: los que CloudTrail ya
+// informa, los que reconocen los extractores registrados para el servicio del evento y
+// cualquier ARN presente en los parámetros de la solicitud o de la respuesta.
+type ResourceEnricher struct {
+	extractors map[string][]ResourceExtractor
+}
+
+// NewResourceEnricher crea el enriquecedor con los extractores de los servicios más comunes.
+func NewResourceEnricher() *ResourceEnricher {
+	e := &ResourceEnricher{extractors: map[string][]ResourceExtractor{}}
+	e.Register("s3.amazonaws.com", s3Resources)
+	e.Register("iam.amazonaws.com", fieldExtractor("iam",
+		resourceField{key: "userName", typ: "AWS::IAM::User", prefix: "user/"},
+		resourceField{key: "roleName", typ: "AWS::IAM::Role", prefix: "role/"},
+		resourceField{key: "groupName", typ: "AWS::IAM::Group", prefix: "group/"},
+		resourceField{key: "instanceProfileName", typ: "AWS::IAM::InstanceProfile", prefix: "instance-profile/"},
+	))
+	e.Register("kms.amazonaws.com", kmsResources)
+	e.Register("ec2.amazonaws.com", ec2InstanceResources)
+	e.Register("ec2.amazonaws.com", fieldExtractor("ec2",
+		resourceField{key: "instanceId", typ: "AWS::EC2::Instance", prefix: "instance/"},
+		resourceField{key: "groupId", typ: "AWS::EC2::SecurityGroup", prefix: "security-group/"},
+		resourceField{key: "vpcId", typ: "AWS::EC2::VPC", prefix: "vpc/"},
+		resourceField{key: "subnetId", typ: "AWS::EC2::Subnet", prefix: "subnet/"},
+		resourceField{key: "volumeId", typ: "AWS::EC2::Volume", prefix: "volume/"},
+		resourceField{key: "snapshotId", typ: "AWS::EC2::Snapshot", prefix: "snapshot/"},
+		resourceField{key: "imageId", typ: "AWS::EC2::Image", prefix: "image/"},
+		resourceField{key: "networkInterfaceId", typ: "AWS::EC2::NetworkInterface", prefix: "network-interface/"},
+	))
+	e.Register("lambda.amazonaws.com", fieldExtractor("lambda",
+		resourceField{key: "functionName", typ: "AWS::Lambda::Function", prefix: "function:", topLevel: true},
+	))
+	e.Register("dynamodb.amazonaws.com", fieldExtractor("dynamodb",
+		resourceField{key: "tableName", typ: "AWS::DynamoDB::Table", prefix: "table/", topLevel: true},
+	))
+	e.Register("sqs.amazonaws.com", sqsResources)
+	e.Register("logs.amazonaws.com", fieldExtractor("logs",
+		resourceField{key: "logGroupName", typ: "AWS::Logs::LogGroup", prefix: "log-group:", topLevel: true},
+	))
+	e.Register("cloudtrail.amazonaws.com", fieldExtractor("cloudtrail",
+		resourceField{key: "name", typ: "AWS::CloudTrail::Trail", prefix: "trail/", topLevel: true},
+	))
+	e.Register("rds.amazonaws.com", fieldExtractor("rds",
+		resourceField{key: "dBInstanceIdentifier", typ: "AWS::RDS::DBInstance", prefix: "db:", topLevel: true},
+	))
+	// El ARN de un secreto termina en un sufijo aleatorio: con el nombre solo se guarda el identificador
+	e.Register("secretsmanager.amazonaws.com", fieldExtractor("secretsmanager",
+		resourceField{key: "secretId", typ: "AWS::SecretsManager::Secret", topLevel: true},
+	))
+	e.Register("ssm.amazonaws.com", ssmResources)
+	return e
+}
+
+// Register agrega un extractor para los eventos de eventSource (p. ej. "s3.amazonaws.com").
+// Un servicio puede tener varios extractores.
+func (e *ResourceEnricher) Register(eventSource string, extractor ResourceExtractor) {
+	e.extractors[eventSource] = append(e.extractors[eventSource], extractor)
+}
+
+// Name implementa services.Enricher.
+func (e *ResourceEnricher) Name() string {
+	return "resources"
+}
+
+// Enrich reemplaza resources por la lista normalizada y sin repetir de los recursos del evento.
+func (e *ResourceEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	scope := ResourceScope{
+		Partition: eventPartition(record),
+		Region:    record.AwsRegion,
+		Account:   firstNonEmpty(record.RecipientAccountID, eventAccountID(record)),
+	}
+
+	var resources []models.Resource
+	seen := map[string]int{}
+	add := func(resource models.Resource) {
+		if resource.Type == "" || (resource.ARN == "" && resource.ID == "") {
+			return
+		}
+		key := resource.ARN
+		if key == "" {
+			key = resource.Type + "|" + resource.ID
+		}
+		if i, ok := seen[key]; ok {
+			// Se conserva el primero, completando lo que le falte
+			resources[i].ID = firstNonEmpty(resources[i].ID, resource.ID)
+			resources[i].AccountID = firstNonEmpty(resources[i].AccountID, resource.AccountID)
+			return
+		}
+		seen[key] = len(resources)
+		resources = append(resources, resource)
+	}
+
+	// Los recursos que informa CloudTrail traen el tipo correcto; se completan con el identificador
+	for _, resource := range record.Resources {
+		if parsed, ok := resourceFromARN(resource.ARN); ok {
+			resource.ARN = parsed.ARN
+			resource.ID = firstNonEmpty(resource.ID, parsed.ID)
+			resource.Type = firstNonEmpty(resource.Type, parsed.Type)
+			resource.AccountID = firstNonEmpty(resource.AccountID, parsed.AccountID)
+		}
+		add(resource)
+	}
+	for _, extractor := range e.extractors[record.EventSource] {
+		for _, resource := range extractor(record, scope) {
+			add(resource)
+		}
+	}
+	for _, arn := range parameterARNs(record) {
+		if resource, ok := resourceFromARN(arn); ok {
+			add(resource)
+		}
+	}
+
+	record.Resources = resources
+	return nil
+}
+
+// parameterARNs devuelve los ARN presentes en cualquier nivel de requestParameters y de
+// responseElements.
+func parameterARNs(record *models.EnrichedEventRecord) []string {
+	var arns []string
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			if _, ok := models.ParseARN(v); ok {
+				arns = append(arns, strings.TrimSpace(v))
+			}
+		case map[string]interface{}:
+			for _, key := range parameterKeys(v) {
+				walk(v[key])
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(record.RequestParameters.Other)
+	walk(record.ResponseElements.Other)
+	return arns
+}
@@ -0,0 +1,283 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Valores por defecto del enriquecedor de DNS inverso.
+const (
+	defaultReverseDNSWorkers     = 8
+	defaultReverseDNSQueueSize   = 1024
+	defaultReverseDNSTimeout     = 2 * time.Second
+	defaultReverseDNSCacheTTL    = time.Hour
+	defaultReverseDNSNegativeTTL = 5 * time.Minute
+	defaultReverseDNSCacheSize   = 100000
+)
+
+// Resolver resuelve nombres PTR y directos. *net.Resolver lo implementa; las pruebas pueden
+// usar un resolver en memoria.
+type Resolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// ReverseDNSOptions configura el enriquecedor de DNS inverso. Los valores cero usan los valores
+// por defecto.
+type ReverseDNSOptions struct {
+	Resolver         Resolver      // Resolver a usar; si es nil se usa Server o el del sistema
+	Server           string        // Servidor DNS "host:puerto"; vacío usa el del sistema
+	Workers          int           // Consultas simultáneas
+	QueueSize        int           // Consultas pendientes; si la cola está llena la IP no se resuelve
+	Timeout          time.Duration // Tiempo máximo de cada consulta (PTR y confirmación directa)
+	CacheTTL         time.Duration // Vigencia de los nombres resueltos
+	NegativeCacheTTL time.Duration // Vigencia de las IP sin nombre o con error
+	CacheSize        int           // Cantidad máxima de IP en caché
+	StoreUnconfirmed bool          // Guarda también los nombres cuya resolución directa no devuelve la IP
+}
+
+// reverseDNSEntry es el resultado de una IP en la caché. done se cierra al terminar la
+// consulta, de modo que la misma entrada sirve para esperar una consulta en curso.
+type reverseDNSEntry struct {
+	hostname  string
+	confirmed bool
+	expires   time.Time
+	done      chan struct{}
+}
+
+func (e *reverseDNSEntry) finished() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReverseDNSEnricher guarda en enrichment.hostname el nombre PTR del sourceIPAddress público. Las
+// consultas las hace un grupo acotado de workers; Prefetch encola las IP de todo el lote al
+// recibirlo, de modo que cada registro solo espera lo que falte de su consulta. Un nombre se
+// considera confirmado si su resolución directa incluye la IP; por defecto solo se guardan los
+// confirmados, porque el dueño de la IP controla el PTR y puede declarar cualquier nombre.
+type ReverseDNSEnricher struct {
+	resolver Resolver
+	options  ReverseDNSOptions
+
+	mu    sync.Mutex
+	cache map[string]*reverseDNSEntry
+
+	jobs chan string
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewReverseDNSEnricher crea el enriquecedor. Los workers se inician con Start.
+func NewReverseDNSEnricher(options ReverseDNSOptions) *ReverseDNSEnricher {
+	if options.Workers <= 0 {
+		options.Workers = defaultReverseDNSWorkers
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultReverseDNSQueueSize
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultReverseDNSTimeout
+	}
+	if options.CacheTTL <= 0 {
+		options.CacheTTL = defaultReverseDNSCacheTTL
+	}
+	if options.NegativeCacheTTL <= 0 {
+		options.NegativeCacheTTL = defaultReverseDNSNegativeTTL
+	}
+	if options.CacheSize <= 0 {
+		options.CacheSize = defaultReverseDNSCacheSize
+	}
+
+	resolver := options.Resolver
+	if resolver == nil && options.Server != "" {
+		server := options.Server
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &ReverseDNSEnricher{
+		resolver: resolver,
+		options:  options,
+		cache:    make(map[string]*reverseDNSEntry),
+		jobs:     make(chan string, options.QueueSize),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start inicia los workers.
+func (e *ReverseDNSEnricher) Start() {
+	for i := 0; i < e.options.Workers; i++ {
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+			for {
+				select {
+				case <-e.stop:
+					return
+				case ip := <-e.jobs:
+					e.resolve(ip)
+				}
+			}
+		}()
+	}
+}
+
+// Stop detiene los workers. Las consultas pendientes se descartan.
+func (e *ReverseDNSEnricher) Stop() {
+	close(e.stop)
+	e.wg.Wait()
+}
+
+// Name implementa services.Enricher.
+func (e *ReverseDNSEnricher) Name() string {
+	return "reverse_dns"
+}
+
+// Prefetch implementa services.Prefetcher: encola la consulta de cada IP pública que no esté
+// en caché. Usa la misma clasificación que NetworkClassifier, que aún no se aplicó al lote.
+func (e *ReverseDNSEnricher) Prefetch(ctx context.Context, sourceIPs []string) {
+	for _, source := range sourceIPs {
+		if ip := net.ParseIP(source); ip != nil && ClassifyIP(ip) == models.NetworkPublic {
+			e.lookup(ip.String())
+		}
+	}
+}
+
+// Enrich espera como máximo Timeout el resultado de la consulta del sourceIPAddress. Si no
+// llega a tiempo el evento se guarda sin nombre, y la consulta sigue en curso para los
+// siguientes eventos de la misma IP. Los orígenes que no son públicos no se resuelven.
+func (e *ReverseDNSEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	if record.Enrichment.NetworkType != models.NetworkPublic {
+		return nil
+	}
+	ip := net.ParseIP(record.SourceIPAddress)
+	if ip == nil {
+		return nil
+	}
+	entry := e.lookup(ip.String())
+
+	timer := time.NewTimer(e.options.Timeout)
+	defer timer.Stop()
+	select {
+	case <-entry.done:
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if entry.hostname != "" && (entry.confirmed || e.options.StoreUnconfirmed) {
+		record.Enrichment.Hostname = entry.hostname
+		record.Enrichment.HostnameConfirmed = entry.confirmed
+	}
+	return nil
+}
+
+// lookup devuelve la entrada de la IP y, si no hay una vigente ni en curso, encola la consulta.
+func (e *ReverseDNSEnricher) lookup(ip string) *reverseDNSEntry {
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if entry, ok := e.cache[ip]; ok && (!entry.finished() || now.Before(entry.expires)) {
+		return entry
+	}
+	if len(e.cache) >= e.options.CacheSize {
+		e.evict(now)
+	}
+
+	entry := &reverseDNSEntry{done: make(chan struct{})}
+	select {
+	case e.jobs <- ip:
+		e.cache[ip] = entry
+	default:
+		// Cola llena: la IP queda sin resolver y se reintenta en el próximo evento
+		logger.ErrorLog.Printf("Cola de DNS inverso llena, se omite la resolución de %s", ip)
+		close(entry.done)
+	}
+	return entry
+}
+
+// evict elimina las entradas vencidas y, si la caché sigue llena, cualquier entrada terminada.
+// Debe llamarse con e.mu tomado.
+func (e *ReverseDNSEnricher) evict(now time.Time) {
+	for ip, entry := range e.cache {
+		if entry.finished() && !now.Before(entry.expires) {
+			delete(e.cache, ip)
+		}
+	}
+	for ip, entry := range e.cache {
+		if len(e.cache) < e.options.CacheSize {
+			return
+		}
+		if entry.finished() {
+			delete(e.cache, ip)
+		}
+	}
+}
+
+// resolve consulta el PTR de la IP y confirma cada nombre con su resolución directa.
+func (e *ReverseDNSEnricher) resolve(ip string) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.options.Timeout)
+	defer cancel()
+
+	hostname, confirmed, err := reverseLookup(ctx, e.resolver, ip)
+	var dnsErr *net.DNSError
+	if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		logger.ErrorLog.Printf("Error en la resolución inversa de %s: %v", ip, err)
+	}
+
+	ttl := e.options.CacheTTL
+	if hostname == "" {
+		ttl = e.options.NegativeCacheTTL
+	}
+
+	e.mu.Lock()
+	entry, ok := e.cache[ip]
+	if ok && !entry.finished() {
+		entry.hostname, entry.confirmed = hostname, confirmed
+		entry.expires = time.Now().Add(ttl)
+		close(entry.done)
+	}
+	e.mu.Unlock()
+}
+
+// reverseLookup devuelve el primer nombre PTR confirmado o, si ninguno lo está, el primero.
+func reverseLookup(ctx context.Context, resolver Resolver, ip string) (string, bool, error) {
+	names, err := resolver.LookupAddr(ctx, ip)
+	if err != nil || len(names) == 0 {
+		return "", false, err
+	}
+	target := net.ParseIP(ip)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSuffix(name, "."))
+		addrs, err := resolver.LookupHost(ctx, name)
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if target.Equal(net.ParseIP(addr)) {
+				return name, true, nil
+			}
+		}
+	}
+	return strings.ToLower(strings.TrimSuffix(names[0], ".")), false, nil
+}
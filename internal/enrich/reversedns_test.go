@@ -0,0 +1,213 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init()
+	os.Exit(m.Run())
+}
+
+// memoryResolver es un Resolver en memoria. Las IP y nombres sin entrada devuelven un error
+// "no encontrado" como el de net.Resolver.
+type memoryResolver struct {
+	ptr   map[string][]string // IP -> nombres PTR
+	hosts map[string][]string // Nombre -> IP
+	block chan struct{}       // Si no es nil, LookupAddr espera a que se cierre
+
+	mu        sync.Mutex
+	addrCalls map[string]int
+}
+
+func newMemoryResolver() *memoryResolver {
+	return &memoryResolver{ptr: map[string][]string{}, hosts: map[string][]string{}, addrCalls: map[string]int{}}
+}
+
+func (r *memoryResolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	r.mu.Lock()
+	r.addrCalls[addr]++
+	r.mu.Unlock()
+	if r.block != nil {
+		<-r.block
+	}
+	if names, ok := r.ptr[addr]; ok {
+		return names, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: addr, IsNotFound: true}
+}
+
+func (r *memoryResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func (r *memoryResolver) calls(addr string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.addrCalls[addr]
+}
+
+func publicRecord(ip string) *models.EnrichedEventRecord {
+	record := &models.EnrichedEventRecord{SourceIPAddress: ip}
+	record.Enrichment.NetworkType = models.NetworkPublic
+	return record
+}
+
+func startReverseDNS(t *testing.T, options ReverseDNSOptions) *ReverseDNSEnricher {
+	t.Helper()
+	enricher := NewReverseDNSEnricher(options)
+	enricher.Start()
+	t.Cleanup(enricher.Stop)
+	return enricher
+}
+
+func TestReverseDNSForwardConfirmation(t *testing.T) {
+	resolver := newMemoryResolver()
+	resolver.ptr["8.8.8.8"] = []string{"Spoofed.Example.", "dns.google."}
+	resolver.hosts["spoofed.example"] = []string{"1.2.3.4"}
+	resolver.hosts["dns.google"] = []string{"8.8.4.4", "8.8.8.8"}
+	enricher := startReverseDNS(t, ReverseDNSOptions{Resolver: resolver})
+
+	record := publicRecord("8.8.8.8")
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if record.Enrichment.Hostname != "dns.google" || !record.Enrichment.HostnameConfirmed {
+		t.Errorf("hostname = %q confirmado = %v, se esperaba dns.google confirmado",
+			record.Enrichment.Hostname, record.Enrichment.HostnameConfirmed)
+	}
+}
+
+func TestReverseDNSUnconfirmed(t *testing.T) {
+	resolver := newMemoryResolver()
+	resolver.ptr["1.1.1.1"] = []string{"bank.example."}
+	resolver.hosts["bank.example"] = []string{"9.9.9.9"}
+
+	record := publicRecord("1.1.1.1")
+	enricher := startReverseDNS(t, ReverseDNSOptions{Resolver: resolver})
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if record.Enrichment.Hostname != "" {
+		t.Errorf("se guardó el nombre sin confirmar %q", record.Enrichment.Hostname)
+	}
+
+	record = publicRecord("1.1.1.1")
+	enricher = startReverseDNS(t, ReverseDNSOptions{Resolver: resolver, StoreUnconfirmed: true})
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if record.Enrichment.Hostname != "bank.example" || record.Enrichment.HostnameConfirmed {
+		t.Errorf("con StoreUnconfirmed: hostname = %q confirmado = %v, se esperaba bank.example sin confirmar",
+			record.Enrichment.Hostname, record.Enrichment.HostnameConfirmed)
+	}
+}
+
+func TestReverseDNSNegativeCache(t *testing.T) {
+	resolver := newMemoryResolver()
+	enricher := startReverseDNS(t, ReverseDNSOptions{Resolver: resolver, NegativeCacheTTL: time.Hour})
+
+	for i := 0; i < 3; i++ {
+		record := publicRecord("9.9.9.9")
+		if err := enricher.Enrich(context.Background(), record); err != nil {
+			t.Fatalf("Enrich: %v", err)
+		}
+		if record.Enrichment.Hostname != "" {
+			t.Errorf("hostname = %q para una IP sin PTR", record.Enrichment.Hostname)
+		}
+	}
+	if calls := resolver.calls("9.9.9.9"); calls != 1 {
+		t.Errorf("se consultó el PTR %d veces, se esperaba 1 por la caché negativa", calls)
+	}
+}
+
+func TestReverseDNSQueueFull(t *testing.T) {
+	resolver := newMemoryResolver()
+	resolver.ptr["8.8.8.8"] = []string{"dns.google."}
+	resolver.hosts["dns.google"] = []string{"8.8.8.8"}
+	// Sin workers iniciados la primera IP ocupa la única posición de la cola
+	enricher := NewReverseDNSEnricher(ReverseDNSOptions{Resolver: resolver, QueueSize: 1, Timeout: time.Second})
+	enricher.Prefetch(context.Background(), []string{"1.1.1.1"})
+
+	start := time.Now()
+	record := publicRecord("8.8.8.8")
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Enrich esperó %v con la cola llena", elapsed)
+	}
+	if record.Enrichment.Hostname != "" {
+		t.Errorf("hostname = %q sin haber consultado", record.Enrichment.Hostname)
+	}
+
+	// La IP descartada no queda en caché: se resuelve en cuanto hay lugar en la cola
+	enricher.Start()
+	defer enricher.Stop()
+	if err := enricher.Enrich(context.Background(), publicRecord("1.1.1.1")); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	record = publicRecord("8.8.8.8")
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if record.Enrichment.Hostname != "dns.google" {
+		t.Errorf("hostname = %q tras liberar la cola, se esperaba dns.google", record.Enrichment.Hostname)
+	}
+}
+
+func TestReverseDNSEnrichTimeout(t *testing.T) {
+	resolver := newMemoryResolver()
+	resolver.ptr["8.8.8.8"] = []string{"dns.google."}
+	resolver.hosts["dns.google"] = []string{"8.8.8.8"}
+	resolver.block = make(chan struct{})
+	enricher := startReverseDNS(t, ReverseDNSOptions{Resolver: resolver, Timeout: 200 * time.Millisecond})
+
+	record := publicRecord("8.8.8.8")
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if record.Enrichment.Hostname != "" {
+		t.Errorf("hostname = %q antes de terminar la consulta", record.Enrichment.Hostname)
+	}
+
+	// La consulta sigue en curso y su resultado sirve a los eventos siguientes
+	close(resolver.block)
+	record = publicRecord("8.8.8.8")
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if record.Enrichment.Hostname != "dns.google" {
+		t.Errorf("hostname = %q tras terminar la consulta, se esperaba dns.google", record.Enrichment.Hostname)
+	}
+	if calls := resolver.calls("8.8.8.8"); calls != 1 {
+		t.Errorf("se consultó el PTR %d veces, se esperaba 1", calls)
+	}
+}
+
+func TestReverseDNSSkipsNonPublic(t *testing.T) {
+	resolver := newMemoryResolver()
+	resolver.ptr["10.0.0.5"] = []string{"ip-10-0-0-5.ec2.internal."}
+	resolver.hosts["ip-10-0-0-5.ec2.internal"] = []string{"10.0.0.5"}
+	enricher := startReverseDNS(t, ReverseDNSOptions{Resolver: resolver})
+
+	enricher.Prefetch(context.Background(), []string{"10.0.0.5"})
+	record := &models.EnrichedEventRecord{SourceIPAddress: "10.0.0.5"}
+	record.Enrichment.NetworkType = models.NetworkPrivate
+	if err := enricher.Enrich(context.Background(), record); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if record.Enrichment.Hostname != "" || resolver.calls("10.0.0.5") != 0 {
+		t.Errorf("se resolvió la IP privada: hostname = %q, consultas = %d", record.Enrichment.Hostname, resolver.calls("10.0.0.5"))
+	}
+}
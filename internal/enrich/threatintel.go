@@ -0,0 +1,237 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// threatIndex indexa los indicadores para buscarlos sin recorrer los feeds: las IP por valor
+// exacto, los CIDR en una prefixTable y los dominios por nombre, de modo que también
+// coincidan sus subdominios.
+type threatIndex struct {
+	ips     map[string][]*Indicator
+	cidrs   *prefixTable[*Indicator]
+	domains map[string][]*Indicator
+	count   int
+}
+
+func newThreatIndex() *threatIndex {
+	return &threatIndex{
+		ips:     map[string][]*Indicator{},
+		cidrs:   newPrefixTable[*Indicator](),
+		domains: map[string][]*Indicator{},
+	}
+}
+
+func (idx *threatIndex) add(indicator *Indicator) {
+	idx.count++
+	switch indicator.Type {
+	case models.IndicatorIP:
+		idx.ips[indicator.Value] = append(idx.ips[indicator.Value], indicator)
+	case models.IndicatorDomain:
+		idx.domains[indicator.Value] = append(idx.domains[indicator.Value], indicator)
+	case models.IndicatorCIDR:
+		_, network, _ := net.ParseCIDR(indicator.Value)
+		idx.cidrs.add(network, indicator)
+	}
+}
+
+// lookupIP devuelve los indicadores de IP y de los rangos que contienen ip, de los más
+// específicos a los más amplios.
+func (idx *threatIndex) lookupIP(ip net.IP) []*Indicator {
+	return append(append([]*Indicator{}, idx.ips[ip.String()]...), idx.cidrs.lookup(ip)...)
+}
+
+// lookupDomain devuelve los indicadores del dominio y de sus dominios padre.
+func (idx *threatIndex) lookupDomain(domain string) []*Indicator {
+	var matches []*Indicator
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	for domain != "" {
+		matches = append(matches, idx.domains[domain]...)
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok || !strings.Contains(parent, ".") {
+			break
+		}
+		domain = parent
+	}
+	return matches
+}
+
+// ThreatIntelEnricher compara la IP de origen y los parámetros de la solicitud de cada evento
+// con indicadores de feeds locales (listas planas, CSV y bundles STIX 2.1) y guarda las
+// coincidencias en record.ThreatIntel. Los feeds se recargan cuando cambian en disco.
+type ThreatIntelEnricher struct {
+	feedsPath string
+
+	mu       sync.RWMutex
+	index    *threatIndex
+	modTimes map[string]time.Time
+
+	reload *PeriodicReload
+}
+
+// NewThreatIntelEnricher carga los feeds listados en feedsPath. A diferencia de las recargas
+// periódicas, un error en la carga inicial se devuelve.
+func NewThreatIntelEnricher(feedsPath string) (*ThreatIntelEnricher, error) {
+	enricher := &ThreatIntelEnricher{feedsPath: feedsPath, reload: NewPeriodicReload()}
+	if _, err := enricher.Reload(); err != nil {
+		return nil, err
+	}
+	return enricher, nil
+}
+
+// Reload vuelve a leer los feeds si el archivo de feeds o alguno de ellos cambió desde la
+// última carga. Si la lectura falla se conservan los indicadores anteriores.
+func (e *ThreatIntelEnricher) Reload() (bool, error) {
+	feeds, err := LoadFeeds(e.feedsPath)
+	if err != nil {
+		return false, err
+	}
+	base := filepath.Dir(e.feedsPath)
+	for _, feed := range feeds {
+		if !filepath.IsAbs(feed.Path) {
+			feed.Path = filepath.Join(base, feed.Path)
+		}
+	}
+
+	modTimes, err := fileModTimes(append([]string{e.feedsPath}, feedPaths(feeds)...), "el feed")
+	if err != nil {
+		return false, err
+	}
+	e.mu.RLock()
+	unchanged := sameModTimes(e.modTimes, modTimes)
+	e.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	index := newThreatIndex()
+	for _, feed := range feeds {
+		indicators, err := feed.ReadIndicators()
+		if err != nil {
+			return false, err
+		}
+		for _, indicator := range indicators {
+			index.add(indicator)
+		}
+	}
+	index.cidrs.sortLengths()
+
+	e.mu.Lock()
+	e.index = index
+	e.modTimes = modTimes
+	e.mu.Unlock()
+	logger.InfoLog.Printf("Feeds de inteligencia de amenazas cargados: %d feeds, %d indicadores.", len(feeds), index.count)
+	return true, nil
+}
+
+func feedPaths(feeds []*Feed) []string {
+	paths := make([]string, len(feeds))
+	for i, feed := range feeds {
+		paths[i] = feed.Path
+	}
+	return paths
+}
+
+// Start revisa los feeds cada interval y los recarga si cambiaron. Con interval <= 0 no
+// se recargan.
+func (e *ThreatIntelEnricher) Start(interval time.Duration) {
+	e.reload.Start(interval, "Error al recargar los feeds de inteligencia de amenazas, se conservan los anteriores", func() error {
+		_, err := e.Reload()
+		return err
+	})
+}
+
+// Stop detiene la recarga periódica.
+func (e *ThreatIntelEnricher) Stop() {
+	e.reload.Stop()
+}
+
+// Name implementa services.Enricher.
+func (e *ThreatIntelEnricher) Name() string {
+	return "threat_intel"
+}
+
+// Enrich busca en los feeds la IP de origen (o el host, si el evento lo origina un servicio
+// de AWS) y cada IP, rango, dominio o URL presente en requestParameters.
+func (e *ThreatIntelEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	e.mu.RLock()
+	index := e.index
+	e.mu.RUnlock()
+
+	matcher := &threatMatcher{index: index, seen: map[string]bool{}}
+	matcher.check("sourceIPAddress", record.SourceIPAddress)
+	matcher.walk("requestParameters", record.RequestParameters.Other)
+
+	record.ThreatIntel = matcher.matches
+	return nil
+}
+
+// threatMatcher acumula las coincidencias de un evento sin repetir indicador y campo.
+type threatMatcher struct {
+	index   *threatIndex
+	seen    map[string]bool
+	matches []models.ThreatMatch
+}
+
+func (m *threatMatcher) walk(field string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		m.check(field, v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			m.walk(field+"."+key, v[key])
+		}
+	case []interface{}:
+		for _, item := range v {
+			m.walk(field, item)
+		}
+	}
+}
+
+func (m *threatMatcher) check(field, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" || len(value) > 2048 {
+		return
+	}
+
+	var indicators []*Indicator
+	if ip := net.ParseIP(value); ip != nil {
+		indicators = m.index.lookupIP(ip)
+	} else if ip, _, err := net.ParseCIDR(value); err == nil {
+		indicators = m.index.lookupIP(ip)
+	} else if host := strings.ToLower(hostFromURL(value)); net.ParseIP(host) != nil {
+		indicators = m.index.lookupIP(net.ParseIP(host))
+	} else if isDomain(host) {
+		indicators = m.index.lookupDomain(host)
+	}
+
+	for _, indicator := range indicators {
+		key := field + "|" + indicator.Feed + "|" + indicator.Value
+		if m.seen[key] {
+			continue
+		}
+		m.seen[key] = true
+		m.matches = append(m.matches, models.ThreatMatch{
+			Indicator:   indicator.Value,
+			Type:        indicator.Type,
+			Field:       field,
+			Value:       value,
+			Feed:        indicator.Feed,
+			Confidence:  indicator.Confidence,
+			Description: indicator.Description,
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package enrich
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"regexp"
+	"strings"
+)
+
+// uaComment extrae los comentarios entre paréntesis de un user agent, p. ej. "(go1.19; linux; amd64)".
+var uaComment = regexp.MustCompile(`\(([^)]*)\)`)
+
+// uaGoVersion reconoce la versión de Go en los comentarios de aws-sdk-go ("go1.19").
+var uaGoVersion = regexp.MustCompile(`^go(\d+(\.\d+)*)$`)
+
+// uaMetadataTokens son los prefijos de los user agents de los SDK modernos ("os/linux#5.10",
+// "lang/python#3.11", "md/GOOS#linux") que describen el entorno y no la herramienta.
+var uaMetadataTokens = map[string]bool{
+	"ua": true, "os": true, "lang": true, "md": true, "api": true, "cfg": true, "exec-env": true,
+	"exe": true, "prompt": true, "command": true, "m": true, "app": true, "ft": true, "sid": true,
+	"vendor": true, "apn": true, "hashicorp": true,
+}
+
+// Herramientas reconocidas por nombre de producto, en orden de prioridad: una llamada de
+// Terraform también lleva el token de aws-sdk-go, pero la herramienta es Terraform.
+var uaTools = []struct {
+	product  string
+	tool     string
+	category string
+}{
+	{"terraform", "terraform", models.UserAgentIaC},
+	{"terraform-provider-aws", "terraform", models.UserAgentIaC},
+	{"pulumi", "pulumi", models.UserAgentIaC},
+	{"aws-cdk", "aws-cdk", models.UserAgentIaC},
+	{"cdk", "aws-cdk", models.UserAgentIaC},
+	{"ansible", "ansible", models.UserAgentIaC},
+	{"crossplane", "crossplane", models.UserAgentIaC},
+	{"aws-cli", "aws-cli", models.UserAgentCLI},
+	{"aws-sam-cli", "aws-sam-cli", models.UserAgentCLI},
+	{"awspowershell", "aws-powershell", models.UserAgentCLI},
+	{"aws-tools-powershell", "aws-powershell", models.UserAgentCLI},
+	{"ec2-api-tools", "ec2-api-tools", models.UserAgentCLI},
+	{"boto3", "boto3", models.UserAgentSDK},
+	{"botocore", "botocore", models.UserAgentSDK},
+}
+
+// Nombres de producto de los runtimes, normalizados.
+var uaRuntimes = map[string]string{
+	"python":         "python",
+	"java":           "java",
+	"ruby":           "ruby",
+	"php":            "php",
+	"go":             "go",
+	"js":             "nodejs",
+	"nodejs":         "nodejs",
+	"node":           "nodejs",
+	".net_core":      ".net",
+	".net_framework": ".net",
+	"dotnet":         ".net",
+}
+
+type uaProduct struct {
+	name    string // En minúsculas
+	version string
+}
+
+// ParseUserAgent descompone un user agent de CloudTrail. Devuelve nil si está vacío.
+func ParseUserAgent(userAgent string) *models.UserAgentInfo {
+	userAgent = strings.TrimSpace(strings.Trim(strings.TrimSpace(userAgent), "[]"))
+	if userAgent == "" {
+		return nil
+	}
+	info := &models.UserAgentInfo{Category: models.UserAgentUnknown}
+	lower := strings.ToLower(userAgent)
+
+	var comments []string
+	for _, match := range uaComment.FindAllStringSubmatch(userAgent, -1) {
+		comments = append(comments, match[1])
+	}
+	products := parseUAProducts(uaComment.ReplaceAllString(userAgent, " "), info)
+	for _, comment := range comments {
+		parseUAComment(comment, info)
+	}
+
+	switch {
+	case lower == "aws internal" || strings.HasPrefix(lower, "aws-internal") || hasUAProduct(products, "coral"):
+		info.Category, info.Tool = models.UserAgentAWSInternal, "aws-internal"
+	case lower == "cloudformation.amazonaws.com":
+		info.Category, info.Tool = models.UserAgentIaC, "cloudformation"
+	case isConsoleUserAgent(lower, products):
+		info.Category, info.Tool = models.UserAgentConsole, "console"
+	case strings.HasPrefix(lower, "mozilla/"):
+		info.Category, info.Tool = models.UserAgentConsole, "browser"
+	case isAWSServicePrincipal(lower):
+		info.Category, info.Tool = models.UserAgentAWSInternal, lower
+	default:
+		setUATool(info, products)
+	}
+
+	for _, product := range products {
+		if strings.HasPrefix(product.name, "aws-sdk-") {
+			info.SDK, info.SDKVersion = product.name, product.version
+			break
+		}
+	}
+	if info.SDK == "" {
+		if version, ok := uaProductVersion(products, "botocore"); ok {
+			info.SDK, info.SDKVersion = "botocore", version
+		}
+	}
+	if info.Category == models.UserAgentUnknown && info.SDK != "" {
+		info.Category, info.Tool, info.Version = models.UserAgentSDK, info.SDK, info.SDKVersion
+	}
+	return info
+}
+
+// parseUAProducts separa los tokens "producto/versión" y toma de los metadatos ("os/linux#5.10",
+// "lang/python#3.11") el sistema operativo y el runtime.
+func parseUAProducts(userAgent string, info *models.UserAgentInfo) []uaProduct {
+	var products []uaProduct
+	fields := strings.Fields(userAgent)
+	for i, field := range fields {
+		name, version, hasVersion := strings.Cut(field, "/")
+		name = strings.ToLower(name)
+
+		if !hasVersion {
+			// "ec2-api-tools 1.6.12.2" y plataformas como "x86_64-linux"
+			if i+1 < len(fields) && name == "ec2-api-tools" {
+				products = append(products, uaProduct{name: name, version: fields[i+1]})
+			} else if os := normalizeUAOS(name); os != "" && strings.Contains(name, "-") {
+				setUAOS(info, os)
+			}
+			continue
+		}
+
+		if uaMetadataTokens[name] {
+			key, value, _ := strings.Cut(version, "#")
+			key = strings.ToLower(key)
+			switch {
+			case name == "os":
+				setUAOS(info, normalizeUAOS(key))
+			case name == "lang":
+				setUARuntime(info, key, value)
+			case name == "md" && key == "goos":
+				setUAOS(info, normalizeUAOS(value))
+			case name == "md" && key == "nodejs":
+				setUARuntime(info, key, value)
+			}
+			continue
+		}
+
+		if os := normalizeUAOS(name); os != "" {
+			setUAOS(info, os)
+			// aws-sdk-nodejs v2 informa la plataforma con la versión de Node: "linux/v18.16.0"
+			if strings.HasPrefix(version, "v") {
+				setUARuntime(info, "nodejs", version)
+			}
+			continue
+		}
+		if runtime, ok := uaRuntimes[name]; ok {
+			setUARuntime(info, runtime, version)
+			continue
+		}
+		products = append(products, uaProduct{name: name, version: version})
+	}
+	return products
+}
+
+// parseUAComment toma el runtime y el sistema operativo de comentarios como
+// "(go1.19; linux; amd64)" o "(Windows NT 10.0; Win64; x64)".
+func parseUAComment(comment string, info *models.UserAgentInfo) {
+	for _, part := range strings.Split(comment, ";") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if match := uaGoVersion.FindStringSubmatch(part); match != nil {
+			setUARuntime(info, "go", match[1])
+			continue
+		}
+		setUAOS(info, normalizeUAOS(part))
+	}
+}
+
+func normalizeUAOS(value string) string {
+	value = strings.ToLower(value)
+	switch {
+	case value == "":
+		return ""
+	case strings.Contains(value, "android"):
+		return "android"
+	case strings.Contains(value, "iphone"), strings.Contains(value, "ipad"), value == "ios":
+		return "ios"
+	case strings.Contains(value, "windows"), strings.HasPrefix(value, "win"):
+		return "windows"
+	case strings.Contains(value, "darwin"), strings.Contains(value, "mac"):
+		return "macos"
+	case strings.Contains(value, "linux"):
+		return "linux"
+	case strings.Contains(value, "freebsd"):
+		return "freebsd"
+	}
+	return ""
+}
+
+func setUAOS(info *models.UserAgentInfo, os string) {
+	if info.OS == "" && os != "" {
+		info.OS = os
+	}
+}
+
+func setUARuntime(info *models.UserAgentInfo, runtime, version string) {
+	if normalized, ok := uaRuntimes[strings.ToLower(runtime)]; ok {
+		runtime = normalized
+	}
+	if info.Runtime == "" {
+		info.Runtime = strings.ToLower(runtime)
+	}
+	if info.Runtime == strings.ToLower(runtime) && info.RuntimeVersion == "" {
+		info.RuntimeVersion = strings.TrimPrefix(version, "v")
+	}
+}
+
+// setUATool asigna la herramienta de mayor prioridad presente en el user agent o, si no se
+// reconoce ninguna, el primer producto con categoría unknown.
+func setUATool(info *models.UserAgentInfo, products []uaProduct) {
+	for _, known := range uaTools {
+		if version, ok := uaProductVersion(products, known.product); ok {
+			info.Category, info.Tool, info.Version = known.category, known.tool, version
+			return
+		}
+	}
+	if len(products) > 0 && !strings.HasPrefix(products[0].name, "aws-sdk-") {
+		info.Tool, info.Version = products[0].name, products[0].version
+	}
+}
+
+func isConsoleUserAgent(lower string, products []uaProduct) bool {
+	if lower == "signin.amazonaws.com" || strings.HasPrefix(lower, "console.") || strings.HasSuffix(lower, "console.amazonaws.com") {
+		return true
+	}
+	for _, product := range products {
+		if strings.HasSuffix(product.name, "console") {
+			return true
+		}
+	}
+	return false
+}
+
+func uaProductVersion(products []uaProduct, name string) (string, bool) {
+	for _, product := range products {
+		if product.name == name {
+			return product.version, true
+		}
+	}
+	return "", false
+}
+
+func hasUAProduct(products []uaProduct, name string) bool {
+	_, ok := uaProductVersion(products, name)
+	return ok
+}
+
+// UserAgentEnricher guarda en record.UserAgentInfo el user agent descompuesto.
+type UserAgentEnricher struct{}
+
+// NewUserAgentEnricher crea el enriquecedor de user agents.
+func NewUserAgentEnricher() *UserAgentEnricher {
+	return &UserAgentEnricher{}
+}
+
+// Name implementa services.Enricher.
+func (e *UserAgentEnricher) Name() string {
+	return "user_agent"
+}
+
+// Enrich implementa services.Enricher.
+func (e *UserAgentEnricher) Enrich(ctx context.Context, record *models.EnrichedEventRecord) error {
+	record.UserAgentInfo = ParseUserAgent(record.UserAgent)
+	return nil
+}
@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Valores por defecto de los límites cuando la configuración no los define. La complejidad
+// por defecto admite una página de maxListLimit eventos con hasta 20 campos escalares.
+const (
+	DefaultMaxDepth      = 8
+	DefaultMaxComplexity = 25000
+)
+
+// ErrQueryTooComplex se devuelve cuando una consulta supera los límites de profundidad o complejidad.
+var ErrQueryTooComplex = errors.New("la consulta GraphQL supera los límites permitidos")
+
+// Limits agrupa los límites que se validan antes de ejecutar una consulta.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// limitAnalyzer recorre el AST calculando profundidad y complejidad.
+// Cada campo cuesta 1 y el costo de la selección de un campo con argumento "limit"
+// se multiplica por ese límite, ya que devuelve hasta esa cantidad de elementos.
+type limitAnalyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+// CheckLimits analiza la consulta y devuelve ErrQueryTooComplex si supera los límites.
+// Los errores de sintaxis se dejan para la ejecución, que los reporta en formato GraphQL.
+func CheckLimits(query string, variables map[string]interface{}, limits Limits) error {
+	if limits.MaxDepth <= 0 {
+		limits.MaxDepth = DefaultMaxDepth
+	}
+	if limits.MaxComplexity <= 0 {
+		limits.MaxComplexity = DefaultMaxComplexity
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+
+	analyzer := &limitAnalyzer{
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+		visiting:  map[string]bool{},
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			analyzer.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		depth, complexity := analyzer.selectionSet(operation.SelectionSet, 0)
+		if depth > limits.MaxDepth {
+			return fmt.Errorf("%w: profundidad %d (máximo %d)", ErrQueryTooComplex, depth, limits.MaxDepth)
+		}
+		if complexity > limits.MaxComplexity {
+			return fmt.Errorf("%w: complejidad %d (máximo %d)", ErrQueryTooComplex, complexity, limits.MaxComplexity)
+		}
+	}
+	return nil
+}
+
+// selectionSet devuelve la profundidad máxima y la complejidad acumulada de una selección.
+func (a *limitAnalyzer) selectionSet(set *ast.SelectionSet, depth int) (int, int) {
+	if set == nil {
+		return depth, 0
+	}
+
+	maxDepth, complexity := depth, 0
+	for _, selection := range set.Selections {
+		var d, c int
+		switch node := selection.(type) {
+		case *ast.Field:
+			d, c = a.selectionSet(node.SelectionSet, depth+1)
+			c = 1 + c*a.multiplier(node)
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(node.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			name := node.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || a.visiting[name] {
+				continue
+			}
+			a.visiting[name] = true
+			d, c = a.selectionSet(fragment.SelectionSet, depth)
+			a.visiting[name] = false
+		}
+		if d > maxDepth {
+			maxDepth = d
+		}
+		complexity += c
+	}
+	return maxDepth, complexity
+}
+
+// multiplier devuelve el argumento "limit" del campo (literal o variable), o 1 si no lo tiene.
+// Se acota a maxListLimit, que es el máximo que aplica el repositorio.
+func (a *limitAnalyzer) multiplier(field *ast.Field) int {
+	n := a.rawMultiplier(field)
+	if n > maxListLimit {
+		return maxListLimit
+	}
+	return n
+}
+
+func (a *limitAnalyzer) rawMultiplier(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := a.variables[value.Name.Value].(type) {
+			case float64:
+				if n > 0 {
+					return int(n)
+				}
+			case int:
+				if n > 0 {
+					return n
+				}
+			}
+		}
+		return defaultListLimit
+	}
+	if field.Name.Value == "events" || field.Name.Value == "eventStats" {
+		return defaultListLimit
+	}
+	return 1
+}
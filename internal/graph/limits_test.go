@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// La página máxima con algunos campos escalares debe caber en la complejidad por defecto.
+func TestCheckLimitsMaxPageAccepted(t *testing.T) {
+	queries := map[string]string{
+		"un campo":     `{ events(limit: 1000) { eventName } }`,
+		"20 campos":    `{ events(limit: 1000) { ` + strings.Repeat("eventName ", 20) + `} }`,
+		"por variable": `query($n: Int) { events(limit: $n) { eventName eventTime sourceIPAddress } }`,
+	}
+	for name, query := range queries {
+		if err := CheckLimits(query, map[string]interface{}{"n": float64(1000)}, Limits{}); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestCheckLimitsRejectsOversizedPage(t *testing.T) {
+	query := `{ events(limit: 1000) { ` + strings.Repeat("eventName ", 30) + `} }`
+	if err := CheckLimits(query, nil, Limits{}); !errors.Is(err, ErrQueryTooComplex) {
+		t.Errorf("error = %v, se esperaba ErrQueryTooComplex", err)
+	}
+}
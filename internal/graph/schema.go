@@ -0,0 +1,488 @@
+package graph
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Límites de las consultas de listas: el valor por defecto cuando no se indica "limit"
+// y el máximo que aplica el repositorio de eventos.
+const (
+	defaultListLimit = 10
+	maxListLimit     = 1000
+)
+
+var userIdentityType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UserIdentity",
+	Fields: graphql.Fields{
+		"type":        &graphql.Field{Type: graphql.String},
+		"principalId": &graphql.Field{Type: graphql.String},
+		"arn":         &graphql.Field{Type: graphql.String},
+		"accessKeyId": &graphql.Field{Type: graphql.String},
+		"accountId":   &graphql.Field{Type: graphql.String},
+		"userName":    &graphql.Field{Type: graphql.String},
+		"invokedBy":   &graphql.Field{Type: graphql.String},
+	},
+})
+
+var identityType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Identity",
+	Fields: graphql.Fields{
+		"principalType": &graphql.Field{Type: graphql.String},
+		"actor":         &graphql.Field{Type: graphql.String},
+		"partition":     &graphql.Field{Type: graphql.String},
+		"account":       &graphql.Field{Type: graphql.String},
+		"name":          &graphql.Field{Type: graphql.String},
+		"path":          &graphql.Field{Type: graphql.String},
+		"role":          &graphql.Field{Type: graphql.String},
+		"sessionName":   &graphql.Field{Type: graphql.String},
+		"uniqueId":      &graphql.Field{Type: graphql.String},
+		"isRoot":        &graphql.Field{Type: graphql.Boolean},
+		"isSSO":         &graphql.Field{Type: graphql.Boolean},
+	},
+})
+
+var awsSourceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AWSSource",
+	Fields: graphql.Fields{
+		"service":   &graphql.Field{Type: graphql.String},
+		"region":    &graphql.Field{Type: graphql.String},
+		"prefix":    &graphql.Field{Type: graphql.String},
+		"principal": &graphql.Field{Type: graphql.Boolean},
+	},
+})
+
+var enrichmentType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Enrichment",
+	Fields: graphql.Fields{
+		"country":           &graphql.Field{Type: graphql.String},
+		"region":            &graphql.Field{Type: graphql.String},
+		"subregion":         &graphql.Field{Type: graphql.String},
+		"city":              &graphql.Field{Type: graphql.String},
+		"latitude":          &graphql.Field{Type: graphql.Float},
+		"longitude":         &graphql.Field{Type: graphql.Float},
+		"asn":               &graphql.Field{Type: graphql.String},
+		"asOrg":             &graphql.Field{Type: graphql.String},
+		"asNetwork":         &graphql.Field{Type: graphql.String},
+		"isTor":             &graphql.Field{Type: graphql.Boolean},
+		"isVPN":             &graphql.Field{Type: graphql.Boolean},
+		"isHosting":         &graphql.Field{Type: graphql.Boolean},
+		"aws":               &graphql.Field{Type: awsSourceType},
+		"networkType":       &graphql.Field{Type: graphql.String},
+		"site":              &graphql.Field{Type: graphql.String},
+		"hostname":          &graphql.Field{Type: graphql.String},
+		"hostnameConfirmed": &graphql.Field{Type: graphql.Boolean},
+	},
+})
+
+var inventoryResourceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "InventoryResource",
+	Fields: graphql.Fields{
+		"arn":                &graphql.Field{Type: graphql.String},
+		"name":               &graphql.Field{Type: graphql.String},
+		"environment":        &graphql.Field{Type: graphql.String},
+		"team":               &graphql.Field{Type: graphql.String},
+		"dataClassification": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var inventoryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Inventory",
+	Fields: graphql.Fields{
+		"accountName":        &graphql.Field{Type: graphql.String},
+		"environment":        &graphql.Field{Type: graphql.String},
+		"team":               &graphql.Field{Type: graphql.String},
+		"dataClassification": &graphql.Field{Type: graphql.String},
+		"resources":          &graphql.Field{Type: graphql.NewList(inventoryResourceType)},
+	},
+})
+
+var actionInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ActionInfo",
+	Fields: graphql.Fields{
+		"category":  &graphql.Field{Type: graphql.String},
+		"readOnly":  &graphql.Field{Type: graphql.Boolean},
+		"sensitive": &graphql.Field{Type: graphql.Boolean},
+		"reason":    &graphql.Field{Type: graphql.String},
+	},
+})
+
+var resourceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Resource",
+	Fields: graphql.Fields{
+		"type":      &graphql.Field{Type: graphql.String},
+		"arn":       &graphql.Field{Type: graphql.String},
+		"id":        &graphql.Field{Type: graphql.String},
+		"accountId": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var userAgentInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UserAgentInfo",
+	Fields: graphql.Fields{
+		"category":       &graphql.Field{Type: graphql.String},
+		"tool":           &graphql.Field{Type: graphql.String},
+		"version":        &graphql.Field{Type: graphql.String},
+		"sdk":            &graphql.Field{Type: graphql.String},
+		"sdkVersion":     &graphql.Field{Type: graphql.String},
+		"runtime":        &graphql.Field{Type: graphql.String},
+		"runtimeVersion": &graphql.Field{Type: graphql.String},
+		"os":             &graphql.Field{Type: graphql.String},
+	},
+})
+
+var riskFactorType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RiskFactor",
+	Fields: graphql.Fields{
+		"name":   &graphql.Field{Type: graphql.String},
+		"weight": &graphql.Field{Type: graphql.Int},
+		"detail": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var riskScoreType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RiskScore",
+	Fields: graphql.Fields{
+		"score":   &graphql.Field{Type: graphql.Int},
+		"factors": &graphql.Field{Type: graphql.NewList(riskFactorType)},
+	},
+})
+
+var attackTagsType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AttackTags",
+	Fields: graphql.Fields{
+		"techniques": &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"tactics":    &graphql.Field{Type: graphql.NewList(graphql.String)},
+	},
+})
+
+var threatMatchType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ThreatMatch",
+	Fields: graphql.Fields{
+		"indicator":   &graphql.Field{Type: graphql.String},
+		"type":        &graphql.Field{Type: graphql.String},
+		"field":       &graphql.Field{Type: graphql.String},
+		"value":       &graphql.Field{Type: graphql.String},
+		"feed":        &graphql.Field{Type: graphql.String},
+		"confidence":  &graphql.Field{Type: graphql.Int},
+		"description": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var eventSortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "EventSortField",
+	Values: graphql.EnumValueConfigMap{
+		models.EventSortEventTime: &graphql.EnumValueConfig{Value: models.EventSortEventTime},
+		models.EventSortRisk:      &graphql.EnumValueConfig{Value: models.EventSortRisk},
+	},
+})
+
+var instanceItemType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "InstanceItem",
+	Fields: graphql.Fields{
+		"instanceId": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var instanceStateType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "InstanceState",
+	Fields: graphql.Fields{
+		"code": &graphql.Field{Type: graphql.Int},
+		"name": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var responseInstanceItemType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ResponseInstanceItem",
+	Fields: graphql.Fields{
+		"instanceId":    &graphql.Field{Type: graphql.String},
+		"currentState":  &graphql.Field{Type: instanceStateType},
+		"previousState": &graphql.Field{Type: instanceStateType},
+	},
+})
+
+var requestParametersType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RequestParameters",
+	Fields: graphql.Fields{
+		"instances": &graphql.Field{
+			Type: graphql.NewList(instanceItemType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				params, _ := p.Source.(models.RequestParameters)
+				return params.InstancesSet.Items, nil
+			},
+		},
+	},
+})
+
+var responseElementsType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ResponseElements",
+	Fields: graphql.Fields{
+		"instances": &graphql.Field{
+			Type: graphql.NewList(responseInstanceItemType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				elements, _ := p.Source.(models.ResponseElements)
+				return elements.InstancesSet.Items, nil
+			},
+		},
+	},
+})
+
+var enrichedEventRecordType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "EnrichedEventRecord",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				record, _ := p.Source.(*models.EnrichedEventRecord)
+				if record == nil {
+					return nil, nil
+				}
+				return record.ID.Hex(), nil
+			},
+		},
+		"eventVersion":       &graphql.Field{Type: graphql.String},
+		"userIdentity":       &graphql.Field{Type: userIdentityType},
+		"identity":           &graphql.Field{Type: identityType},
+		"inventory":          &graphql.Field{Type: inventoryType},
+		"eventTime":          &graphql.Field{Type: graphql.DateTime},
+		"eventSource":        &graphql.Field{Type: graphql.String},
+		"eventName":          &graphql.Field{Type: graphql.String},
+		"awsRegion":          &graphql.Field{Type: graphql.String},
+		"sourceIPAddress":    &graphql.Field{Type: graphql.String},
+		"userAgent":          &graphql.Field{Type: graphql.String},
+		"userAgentInfo":      &graphql.Field{Type: userAgentInfoType},
+		"requestParameters":  &graphql.Field{Type: requestParametersType},
+		"responseElements":   &graphql.Field{Type: responseElementsType},
+		"enrichment":         &graphql.Field{Type: enrichmentType},
+		"errorCode":          &graphql.Field{Type: graphql.String},
+		"errorMessage":       &graphql.Field{Type: graphql.String},
+		"vpcEndpointId":      &graphql.Field{Type: graphql.String},
+		"recipientAccountId": &graphql.Field{Type: graphql.String},
+		"resources":          &graphql.Field{Type: graphql.NewList(resourceType)},
+		"readOnly":           &graphql.Field{Type: graphql.Boolean},
+		"eventCategory":      &graphql.Field{Type: graphql.String},
+		"action":             &graphql.Field{Type: actionInfoType},
+		"risk":               &graphql.Field{Type: riskScoreType},
+		"attack":             &graphql.Field{Type: attackTagsType},
+		"threatIntel":        &graphql.Field{Type: graphql.NewList(threatMatchType)},
+	},
+})
+
+var aggregateBucketType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AggregateBucket",
+	Fields: graphql.Fields{
+		"key":   &graphql.Field{Type: graphql.String},
+		"count": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var eventFilterInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "EventFilterInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"eventName":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"eventSource":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"awsRegion":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"sourceIPAddress":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"userName":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"accountId":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"actor":              &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"identityAccount":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"identityRole":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"sessionName":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"principalType":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"isRoot":             &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		"accountName":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"environment":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"team":               &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"dataClassification": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"actionCategory":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"readOnly":           &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		"sensitive":          &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		"resourceArn":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"resourceType":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"resourceId":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"country":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"asn":                &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"asOrg":              &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"uaTool":             &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"uaCategory":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"uaSDK":              &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"networkType":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"site":               &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"hostname":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"from":               &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		"to":                 &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		"isTor":              &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		"isVPN":              &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		"isHosting":          &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		"minRisk":            &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"sortBy":             &graphql.InputObjectFieldConfig{Type: eventSortEnum},
+	},
+})
+
+// groupByEnum se construye a partir de models.AggregationFields para que cualquier campo
+// agregable nuevo quede expuesto automáticamente.
+func groupByEnum() *graphql.Enum {
+	names := make([]string, 0, len(models.AggregationFields))
+	for name := range models.AggregationFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := graphql.EnumValueConfigMap{}
+	for _, name := range names {
+		values[name] = &graphql.EnumValueConfig{Value: name}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name:   "EventGroupByField",
+		Values: values,
+	})
+}
+
+// filterFromArgs convierte el argumento "filter" y la paginación en un models.EventFilter.
+func filterFromArgs(args map[string]interface{}) *models.EventFilter {
+	filter := &models.EventFilter{}
+
+	if input, ok := args["filter"].(map[string]interface{}); ok {
+		filter.EventName, _ = input["eventName"].(string)
+		filter.EventSource, _ = input["eventSource"].(string)
+		filter.AwsRegion, _ = input["awsRegion"].(string)
+		filter.SourceIPAddress, _ = input["sourceIPAddress"].(string)
+		filter.UserName, _ = input["userName"].(string)
+		filter.AccountID, _ = input["accountId"].(string)
+		filter.Actor, _ = input["actor"].(string)
+		filter.IdentityAccount, _ = input["identityAccount"].(string)
+		filter.IdentityRole, _ = input["identityRole"].(string)
+		filter.SessionName, _ = input["sessionName"].(string)
+		filter.PrincipalType, _ = input["principalType"].(string)
+		filter.AccountName, _ = input["accountName"].(string)
+		filter.Environment, _ = input["environment"].(string)
+		filter.Team, _ = input["team"].(string)
+		filter.DataClassification, _ = input["dataClassification"].(string)
+		filter.ActionCategory, _ = input["actionCategory"].(string)
+		filter.ResourceARN, _ = input["resourceArn"].(string)
+		filter.ResourceType, _ = input["resourceType"].(string)
+		filter.ResourceID, _ = input["resourceId"].(string)
+		filter.Country, _ = input["country"].(string)
+		filter.ASN, _ = input["asn"].(string)
+		filter.ASOrg, _ = input["asOrg"].(string)
+		filter.UATool, _ = input["uaTool"].(string)
+		filter.UACategory, _ = input["uaCategory"].(string)
+		filter.UASDK, _ = input["uaSDK"].(string)
+		filter.NetworkType, _ = input["networkType"].(string)
+		filter.Site, _ = input["site"].(string)
+		filter.Hostname, _ = input["hostname"].(string)
+		if from, ok := input["from"].(time.Time); ok {
+			filter.From = &from
+		}
+		if to, ok := input["to"].(time.Time); ok {
+			filter.To = &to
+		}
+		if isRoot, ok := input["isRoot"].(bool); ok {
+			filter.IsRoot = &isRoot
+		}
+		if readOnly, ok := input["readOnly"].(bool); ok {
+			filter.ReadOnly = &readOnly
+		}
+		if sensitive, ok := input["sensitive"].(bool); ok {
+			filter.Sensitive = &sensitive
+		}
+		if isTor, ok := input["isTor"].(bool); ok {
+			filter.IsTor = &isTor
+		}
+		if isVPN, ok := input["isVPN"].(bool); ok {
+			filter.IsVPN = &isVPN
+		}
+		if isHosting, ok := input["isHosting"].(bool); ok {
+			filter.IsHosting = &isHosting
+		}
+		if minRisk, ok := input["minRisk"].(int); ok {
+			filter.MinRisk = int64(minRisk)
+		}
+		filter.SortBy, _ = input["sortBy"].(string)
+	}
+
+	if limit, ok := args["limit"].(int); ok {
+		filter.Limit = int64(limit)
+	}
+	if skip, ok := args["skip"].(int); ok {
+		filter.Skip = int64(skip)
+	}
+	return filter
+}
+
+// NewSchema construye el esquema GraphQL de los eventos enriquecidos sobre el EnrichmentService.
+func NewSchema(service services.EnrichmentService) (graphql.Schema, error) {
+	filterArg := &graphql.ArgumentConfig{Type: eventFilterInputType}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type:        graphql.NewList(enrichedEventRecordType),
+				Description: "Eventos enriquecidos que cumplen con el filtro, ordenados por eventTime (o por riesgo con sortBy) descendente.",
+				Args: graphql.FieldConfigArgument{
+					"filter": filterArg,
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultListLimit},
+					"skip":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return service.QueryEvents(p.Context, filterFromArgs(p.Args))
+				},
+			},
+			"event": &graphql.Field{
+				Type:        enrichedEventRecordType,
+				Description: "Evento enriquecido por identificador.",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, _ := p.Args["id"].(string)
+					record, err := service.GetEvent(p.Context, id)
+					if errors.Is(err, services.ErrEventNotFound) {
+						return nil, nil
+					}
+					return record, err
+				},
+			},
+			"eventCount": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "Cantidad de eventos que cumplen con el filtro.",
+				Args: graphql.FieldConfigArgument{
+					"filter": filterArg,
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return service.CountEvents(p.Context, filterFromArgs(p.Args))
+				},
+			},
+			"eventStats": &graphql.Field{
+				Type:        graphql.NewList(aggregateBucketType),
+				Description: "Eventos agrupados por un campo, ordenados por cantidad descendente.",
+				Args: graphql.FieldConfigArgument{
+					"groupBy": &graphql.ArgumentConfig{Type: graphql.NewNonNull(groupByEnum())},
+					"filter":  filterArg,
+					"limit":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultListLimit},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					groupBy, _ := p.Args["groupBy"].(string)
+					filter := filterFromArgs(p.Args)
+					limit := filter.Limit
+					filter.Limit = 0
+					return service.AggregateEvents(p.Context, filter, groupBy, limit)
+				},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		return graphql.Schema{}, fmt.Errorf("error al construir el esquema GraphQL: %w", err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,74 @@
+package grpcserver
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/middleware"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/token"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authenticate extrae el token Bearer de la metadata "authorization", lo valida con
+// token.JWTService y devuelve un contexto con los claims bajo middleware.UserClaimsKey,
+// igual que AuthTokenMiddleware en la API HTTP.
+func authenticate(ctx context.Context, jwtService *token.JWTService) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata no proporcionada")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "encabezado de autorización no proporcionado")
+	}
+
+	headerParts := strings.Split(values[0], " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+		return nil, status.Error(codes.Unauthenticated, "formato de token inválido")
+	}
+
+	userClaims, err := jwtService.ValidJWTToken(ctx, headerParts[1])
+	if err != nil {
+		logger.ErrorLog.Printf("Validación de token gRPC fallida: %v", err)
+		return nil, status.Error(codes.Unauthenticated, "autenticación fallida: "+err.Error())
+	}
+
+	return context.WithValue(ctx, middleware.UserClaimsKey, userClaims), nil
+}
+
+// UnaryAuthInterceptor valida el JWT en las llamadas unarias.
+func UnaryAuthInterceptor(jwtService *token.JWTService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, jwtService)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor valida el JWT al abrir cada stream.
+func StreamAuthInterceptor(jwtService *token.JWTService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), jwtService)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream sustituye el contexto del stream por el que contiene los claims del usuario.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
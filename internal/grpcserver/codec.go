@@ -0,0 +1,42 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// CodecName es el subtipo de contenido opcional para clientes que prefieren JSON
+// (grpc.CallContentSubtype(CodecName)), es decir "application/grpc+json". Por defecto el
+// servicio usa protobuf.
+const CodecName = "json"
+
+// jsonCodec serializa los mensajes de enrichment.proto con protojson, usando los mismos
+// nombres de campos que CloudTrail y la API REST.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("codec json: %T no es un mensaje protobuf", v)
+	}
+	return protojson.Marshal(message)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("codec json: %T no es un mensaje protobuf", v)
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, message)
+}
+
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,332 @@
+package grpcserver
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/grpcserver/enrichmentpb"
+	"cloudtrail-enrichment-api-golang/models"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conversiones entre los mensajes de enrichment.proto y los modelos de la API. requestParameters
+// y responseElements viajan como google.protobuf.Struct y se convierten pasando por JSON, de modo
+// que conservan los parámetros sin tipar igual que en la API REST.
+
+// eventFromProto convierte un lote recibido por Ingest en un models.Event.
+func eventFromProto(in *enrichmentpb.Event) (*models.Event, error) {
+	event := &models.Event{}
+	// Records es un slice de un struct anónimo: se dimensiona sin nombrar el tipo
+	event.Records = slices.Grow(event.Records, len(in.GetRecords()))[:len(in.GetRecords())]
+	for i, record := range in.GetRecords() {
+		out := &event.Records[i]
+		out.EventVersion = record.GetEventVersion()
+		out.UserIdentity = userIdentityFromProto(record.GetUserIdentity())
+		out.EventTime = timeFromProto(record.GetEventTime())
+		out.EventSource = record.GetEventSource()
+		out.EventName = record.GetEventName()
+		out.AwsRegion = record.GetAwsRegion()
+		out.SourceIPAddress = record.GetSourceIpAddress()
+		out.UserAgent = record.GetUserAgent()
+		if err := structToModel(record.GetRequestParameters(), &out.RequestParameters); err != nil {
+			return nil, fmt.Errorf("registro %d: requestParameters: %w", i, err)
+		}
+		if err := structToModel(record.GetResponseElements(), &out.ResponseElements); err != nil {
+			return nil, fmt.Errorf("registro %d: responseElements: %w", i, err)
+		}
+		if data := record.GetAdditionalEventData(); data != nil {
+			out.AdditionalEventData = &models.AdditionalEventData{MFAUsed: data.GetMfaUsed()}
+		}
+		out.ErrorCode = record.GetErrorCode()
+		out.ErrorMessage = record.GetErrorMessage()
+		out.VpcEndpointID = record.GetVpcEndpointId()
+		out.RecipientAccountID = record.GetRecipientAccountId()
+		out.ReadOnly = record.ReadOnly
+		out.EventCategory = record.GetEventCategory()
+		for _, resource := range record.GetResources() {
+			out.Resources = append(out.Resources, models.Resource{
+				Type:      resource.GetType(),
+				ARN:       resource.GetArn(),
+				ID:        resource.GetId(),
+				AccountID: resource.GetAccountId(),
+			})
+		}
+	}
+	return event, nil
+}
+
+func userIdentityFromProto(in *enrichmentpb.UserIdentity) models.UserIdentity {
+	identity := models.UserIdentity{
+		Type:        in.GetType(),
+		PrincipalID: in.GetPrincipalId(),
+		Arn:         in.GetArn(),
+		AccessKeyID: in.GetAccessKeyId(),
+		AccountID:   in.GetAccountId(),
+		UserName:    in.GetUserName(),
+		InvokedBy:   in.GetInvokedBy(),
+	}
+	if session := in.GetSessionContext(); session != nil {
+		identity.SessionContext = &models.SessionContext{Attributes: models.SessionAttributes{
+			MfaAuthenticated: session.GetAttributes().GetMfaAuthenticated(),
+			CreationDate:     session.GetAttributes().GetCreationDate(),
+		}}
+	}
+	return identity
+}
+
+// filterFromProto convierte el filtro de Query en un models.EventFilter.
+func filterFromProto(in *enrichmentpb.EventFilter) *models.EventFilter {
+	filter := &models.EventFilter{
+		EventName:          in.GetEventName(),
+		EventSource:        in.GetEventSource(),
+		AwsRegion:          in.GetAwsRegion(),
+		SourceIPAddress:    in.GetSourceIpAddress(),
+		UserName:           in.GetUserName(),
+		AccountID:          in.GetAccountId(),
+		Actor:              in.GetActor(),
+		IdentityAccount:    in.GetIdentityAccount(),
+		IdentityRole:       in.GetIdentityRole(),
+		SessionName:        in.GetSessionName(),
+		PrincipalType:      in.GetPrincipalType(),
+		IsRoot:             in.IsRoot,
+		AccountName:        in.GetAccountName(),
+		Environment:        in.GetEnvironment(),
+		Team:               in.GetTeam(),
+		DataClassification: in.GetDataClassification(),
+		ActionCategory:     in.GetActionCategory(),
+		ReadOnly:           in.ReadOnly,
+		Sensitive:          in.Sensitive,
+		ResourceARN:        in.GetResourceArn(),
+		ResourceType:       in.GetResourceType(),
+		ResourceID:         in.GetResourceId(),
+		Country:            in.GetCountry(),
+		ASN:                in.GetAsn(),
+		ASOrg:              in.GetAsOrg(),
+		UATool:             in.GetUaTool(),
+		UACategory:         in.GetUaCategory(),
+		UASDK:              in.GetUaSdk(),
+		NetworkType:        in.GetNetworkType(),
+		Site:               in.GetSite(),
+		Hostname:           in.GetHostname(),
+		IsTor:              in.IsTor,
+		IsVPN:              in.IsVpn,
+		IsHosting:          in.IsHosting,
+		MinRisk:            in.GetMinRisk(),
+		SortBy:             in.GetSortBy(),
+		Limit:              in.GetLimit(),
+		Skip:               in.GetSkip(),
+	}
+	if in.GetFrom() != nil {
+		from := in.GetFrom().AsTime()
+		filter.From = &from
+	}
+	if in.GetTo() != nil {
+		to := in.GetTo().AsTime()
+		filter.To = &to
+	}
+	return filter
+}
+
+// recordToProto convierte un evento guardado en el mensaje que transmite Query.
+func recordToProto(in *models.EnrichedEventRecord) (*enrichmentpb.EnrichedEventRecord, error) {
+	out := &enrichmentpb.EnrichedEventRecord{
+		EventVersion:       in.EventVersion,
+		UserIdentity:       userIdentityToProto(in.UserIdentity),
+		EventTime:          timestamppb.New(in.EventTime),
+		EventSource:        in.EventSource,
+		EventName:          in.EventName,
+		AwsRegion:          in.AwsRegion,
+		SourceIpAddress:    in.SourceIPAddress,
+		UserAgent:          in.UserAgent,
+		Enrichment:         enrichmentToProto(in.Enrichment),
+		ErrorCode:          in.ErrorCode,
+		ErrorMessage:       in.ErrorMessage,
+		VpcEndpointId:      in.VpcEndpointID,
+		RecipientAccountId: in.RecipientAccountID,
+		ReadOnly:           in.ReadOnly,
+		EventCategory:      in.EventCategory,
+	}
+	if !in.ID.IsZero() {
+		out.Id = in.ID.Hex()
+	}
+
+	var err error
+	if out.RequestParameters, err = modelToStruct(in.RequestParameters); err != nil {
+		return nil, fmt.Errorf("requestParameters: %w", err)
+	}
+	if out.ResponseElements, err = modelToStruct(in.ResponseElements); err != nil {
+		return nil, fmt.Errorf("responseElements: %w", err)
+	}
+	if in.AdditionalEventData != nil {
+		out.AdditionalEventData = &enrichmentpb.AdditionalEventData{MfaUsed: in.AdditionalEventData.MFAUsed}
+	}
+	for _, resource := range in.Resources {
+		out.Resources = append(out.Resources, &enrichmentpb.Resource{
+			Type:      resource.Type,
+			Arn:       resource.ARN,
+			Id:        resource.ID,
+			AccountId: resource.AccountID,
+		})
+	}
+
+	if in.Risk != nil {
+		out.Risk = &enrichmentpb.RiskScore{Score: int32(in.Risk.Score)}
+		for _, factor := range in.Risk.Factors {
+			out.Risk.Factors = append(out.Risk.Factors, &enrichmentpb.RiskFactor{Name: factor.Name, Weight: int32(factor.Weight), Detail: factor.Detail})
+		}
+	}
+	if in.Attack != nil {
+		out.Attack = &enrichmentpb.AttackTags{Techniques: in.Attack.Techniques, Tactics: in.Attack.Tactics}
+	}
+	for _, match := range in.ThreatIntel {
+		out.ThreatIntel = append(out.ThreatIntel, &enrichmentpb.ThreatMatch{
+			Indicator:   match.Indicator,
+			Type:        match.Type,
+			Field:       match.Field,
+			Value:       match.Value,
+			Feed:        match.Feed,
+			Confidence:  int32(match.Confidence),
+			Description: match.Description,
+		})
+	}
+	if ua := in.UserAgentInfo; ua != nil {
+		out.UserAgentInfo = &enrichmentpb.UserAgentInfo{
+			Category:       ua.Category,
+			Tool:           ua.Tool,
+			Version:        ua.Version,
+			Sdk:            ua.SDK,
+			SdkVersion:     ua.SDKVersion,
+			Runtime:        ua.Runtime,
+			RuntimeVersion: ua.RuntimeVersion,
+			Os:             ua.OS,
+		}
+	}
+	if identity := in.Identity; identity != nil {
+		out.Identity = &enrichmentpb.Identity{
+			PrincipalType: identity.PrincipalType,
+			Actor:         identity.Actor,
+			Partition:     identity.Partition,
+			Account:       identity.Account,
+			Name:          identity.Name,
+			Path:          identity.Path,
+			Role:          identity.Role,
+			SessionName:   identity.SessionName,
+			UniqueId:      identity.UniqueID,
+			IsRoot:        identity.IsRoot,
+			IsSso:         identity.IsSSO,
+		}
+	}
+	if action := in.Action; action != nil {
+		out.Action = &enrichmentpb.ActionInfo{
+			Category:  action.Category,
+			ReadOnly:  action.ReadOnly,
+			Sensitive: action.Sensitive,
+			Reason:    action.Reason,
+		}
+	}
+	if inventory := in.Inventory; inventory != nil {
+		out.Inventory = &enrichmentpb.InventoryLabels{
+			AccountName:        inventory.AccountName,
+			Environment:        inventory.Environment,
+			Team:               inventory.Team,
+			DataClassification: inventory.DataClassification,
+			Labels:             inventory.Labels,
+		}
+		for _, resource := range inventory.Resources {
+			out.Inventory.Resources = append(out.Inventory.Resources, &enrichmentpb.InventoryResourceLabels{
+				Arn:                resource.ARN,
+				Name:               resource.Name,
+				Environment:        resource.Environment,
+				Team:               resource.Team,
+				DataClassification: resource.DataClassification,
+			})
+		}
+	}
+	return out, nil
+}
+
+func userIdentityToProto(in models.UserIdentity) *enrichmentpb.UserIdentity {
+	identity := &enrichmentpb.UserIdentity{
+		Type:        in.Type,
+		PrincipalId: in.PrincipalID,
+		Arn:         in.Arn,
+		AccessKeyId: in.AccessKeyID,
+		AccountId:   in.AccountID,
+		UserName:    in.UserName,
+		InvokedBy:   in.InvokedBy,
+	}
+	if in.SessionContext != nil {
+		identity.SessionContext = &enrichmentpb.SessionContext{Attributes: &enrichmentpb.SessionAttributes{
+			MfaAuthenticated: in.SessionContext.Attributes.MfaAuthenticated,
+			CreationDate:     in.SessionContext.Attributes.CreationDate,
+		}}
+	}
+	return identity
+}
+
+func enrichmentToProto(in models.EnrichmentData) *enrichmentpb.EnrichmentData {
+	enrichment := &enrichmentpb.EnrichmentData{
+		Country:           in.Country,
+		Region:            in.Region,
+		Subregion:         in.Subregion,
+		City:              in.City,
+		Asn:               in.ASN,
+		AsOrg:             in.ASOrg,
+		AsNetwork:         in.ASNetwork,
+		Latitude:          in.Latitude,
+		Longitude:         in.Longitude,
+		IsTor:             in.IsTor,
+		IsVpn:             in.IsVPN,
+		IsHosting:         in.IsHosting,
+		NetworkType:       in.NetworkType,
+		Site:              in.Site,
+		Hostname:          in.Hostname,
+		HostnameConfirmed: in.HostnameConfirmed,
+	}
+	if in.AWS != nil {
+		enrichment.Aws = &enrichmentpb.AWSSource{
+			Service:   in.AWS.Service,
+			Region:    in.AWS.Region,
+			Prefix:    in.AWS.Prefix,
+			Principal: in.AWS.Principal,
+		}
+	}
+	return enrichment
+}
+
+func timeFromProto(in *timestamppb.Timestamp) time.Time {
+	if in == nil {
+		return time.Time{}
+	}
+	return in.AsTime()
+}
+
+// structToModel decodifica un Struct en un modelo con su UnmarshalJSON. Un Struct nulo deja el
+// modelo vacío.
+func structToModel(in *structpb.Struct, target interface{}) error {
+	if in == nil {
+		return nil
+	}
+	data, err := protojson.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// modelToStruct codifica un modelo con su MarshalJSON como Struct.
+func modelToStruct(in interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
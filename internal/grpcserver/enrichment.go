@@ -0,0 +1,108 @@
+package grpcserver
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/grpcserver/enrichmentpb"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/services"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queryPageSize es la cantidad de eventos que se leen del repositorio por página en Query.
+const queryPageSize int64 = 500
+
+// EnrichmentServer implementa el servicio gRPC de enrichment.proto reutilizando el
+// EnrichmentService de la API REST.
+type EnrichmentServer struct {
+	enrichmentpb.UnimplementedEnrichmentServiceServer
+
+	service services.EnrichmentService
+}
+
+// NewEnrichmentServer crea una nueva instancia de EnrichmentServer.
+func NewEnrichmentServer(service services.EnrichmentService) *EnrichmentServer {
+	return &EnrichmentServer{service: service}
+}
+
+// Ingest recibe un stream de lotes (mismo formato que POST /v1/enrichment), enriquece y
+// persiste cada lote, y al cerrar el stream devuelve un IngestSummary.
+func (s *EnrichmentServer) Ingest(stream grpc.ClientStreamingServer[enrichmentpb.Event, enrichmentpb.IngestSummary]) error {
+	summary := &enrichmentpb.IngestSummary{}
+
+	for {
+		batch, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			logger.InfoLog.Printf("Ingesta gRPC finalizada: %d lotes, %d registros enriquecidos.", summary.Batches, summary.Enriched)
+			return stream.SendAndClose(summary)
+		}
+		if err != nil {
+			return err
+		}
+
+		summary.Batches++
+		summary.Received += int64(len(batch.GetRecords()))
+		if len(batch.GetRecords()) == 0 {
+			continue
+		}
+
+		event, err := eventFromProto(batch)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "lote %d inválido: %v", summary.Batches, err)
+		}
+		enriched, err := s.service.EnrichEvent(stream.Context(), event)
+		if err != nil {
+			logger.ErrorLog.Printf("Error al enriquecer el lote gRPC %d: %v", summary.Batches, err)
+			return status.Errorf(codes.Internal, "error al procesar el lote %d: %v", summary.Batches, err)
+		}
+		summary.Enriched += int64(len(enriched))
+	}
+}
+
+// Query recibe un EventFilter y transmite los eventos que lo cumplen, paginando
+// internamente sobre el repositorio. Si el filtro no define Limit se transmiten todos.
+func (s *EnrichmentServer) Query(in *enrichmentpb.EventFilter, stream grpc.ServerStreamingServer[enrichmentpb.EnrichedEventRecord]) error {
+	ctx := stream.Context()
+	filter := filterFromProto(in)
+	remaining := filter.Limit
+	page := *filter
+
+	for {
+		page.Limit = queryPageSize
+		if remaining > 0 && remaining < queryPageSize {
+			page.Limit = remaining
+		}
+
+		records, err := s.service.QueryEvents(ctx, &page)
+		if err != nil {
+			return status.Errorf(codes.Internal, "error al consultar eventos: %v", err)
+		}
+
+		for _, record := range records {
+			out, err := recordToProto(record)
+			if err != nil {
+				return status.Errorf(codes.Internal, "error al convertir el evento %s: %v", record.ID.Hex(), err)
+			}
+			if err := stream.Send(out); err != nil {
+				return err
+			}
+		}
+
+		if remaining > 0 {
+			remaining -= int64(len(records))
+			if remaining <= 0 {
+				return nil
+			}
+		}
+		if int64(len(records)) < page.Limit {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		page.Skip += int64(len(records))
+	}
+}
@@ -0,0 +1,22 @@
+package grpcserver
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/grpcserver/enrichmentpb"
+	"cloudtrail-enrichment-api-golang/internal/pkg/token"
+	"cloudtrail-enrichment-api-golang/services"
+
+	"google.golang.org/grpc"
+)
+
+// NewServer crea el servidor gRPC con los interceptores de autenticación JWT
+// y registra el servicio de enriquecimiento.
+func NewServer(service services.EnrichmentService, jwtService *token.JWTService, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(jwtService)),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor(jwtService)),
+	)
+
+	server := grpc.NewServer(opts...)
+	enrichmentpb.RegisterEnrichmentServiceServer(server, NewEnrichmentServer(service))
+	return server
+}
@@ -0,0 +1,132 @@
+package match
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StringList acepta en YAML tanto un valor escalar como una lista de valores.
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = StringList{node.Value}
+		return nil
+	}
+	var values []string
+	if err := node.Decode(&values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+// Condition es una condición sobre un campo del evento. Cada operador indicado debe
+// cumplirse (AND) y cada operador se cumple si coincide alguno de sus valores (OR).
+type Condition struct {
+	Field      string     `yaml:"field"`
+	Equals     StringList `yaml:"equals"`
+	Contains   StringList `yaml:"contains"`
+	StartsWith StringList `yaml:"startswith"`
+	EndsWith   StringList `yaml:"endswith"`
+	Regex      StringList `yaml:"regex"`
+	CIDR       StringList `yaml:"cidr"`
+	Exists     *bool      `yaml:"exists"`
+	IgnoreCase bool       `yaml:"ignore_case"`
+	Not        bool       `yaml:"not"`
+}
+
+// MatchBlock combina condiciones: todas las de All, al menos una de Any y ninguna de None.
+type MatchBlock struct {
+	All  []Condition `yaml:"all"`
+	Any  []Condition `yaml:"any"`
+	None []Condition `yaml:"none"`
+}
+
+// compileCondition traduce una Condition a un Matcher.
+func compileCondition(c Condition) (Matcher, error) {
+	operators := []struct {
+		op     Operator
+		values StringList
+	}{
+		{OpEquals, c.Equals},
+		{OpContains, c.Contains},
+		{OpStartsWith, c.StartsWith},
+		{OpEndsWith, c.EndsWith},
+		{OpRegex, c.Regex},
+		{OpCIDR, c.CIDR},
+	}
+
+	var matchers AllOf
+	for _, o := range operators {
+		if len(o.values) == 0 {
+			continue
+		}
+		m, err := NewFieldMatcher(c.Field, o.op, append([]string(nil), o.values...), c.IgnoreCase)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	if c.Exists != nil {
+		m, err := NewFieldMatcher(c.Field, OpExists, []string{fmt.Sprint(*c.Exists)}, false)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+
+	if len(matchers) == 0 {
+		return nil, fmt.Errorf("la condición sobre '%s' no define ningún operador", c.Field)
+	}
+
+	var matcher Matcher = matchers
+	if len(matchers) == 1 {
+		matcher = matchers[0]
+	}
+	if c.Not {
+		matcher = Not{Matcher: matcher}
+	}
+	return matcher, nil
+}
+
+func compileConditions(conditions []Condition) ([]Matcher, error) {
+	matchers := make([]Matcher, 0, len(conditions))
+	for _, c := range conditions {
+		m, err := compileCondition(c)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// Compile construye el Matcher del bloque: AND de All, al menos una de Any y ninguna de None.
+func (b MatchBlock) Compile() (Matcher, error) {
+	all, err := compileConditions(b.All)
+	if err != nil {
+		return nil, err
+	}
+	anyOf, err := compileConditions(b.Any)
+	if err != nil {
+		return nil, err
+	}
+	none, err := compileConditions(b.None)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 && len(anyOf) == 0 {
+		return nil, fmt.Errorf("se requiere al menos una condición en all o any")
+	}
+
+	matcher := AllOf(all)
+	if len(anyOf) > 0 {
+		matcher = append(matcher, AnyOf(anyOf))
+	}
+	if len(none) > 0 {
+		matcher = append(matcher, Not{Matcher: AnyOf(none)})
+	}
+	return matcher, nil
+}
@@ -0,0 +1,72 @@
+package match
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Document es la representación genérica de un evento enriquecido que permite evaluar
+// reglas por ruta de campo JSON (por ejemplo "userIdentity.arn" o "enrichment.country").
+type Document map[string]interface{}
+
+// NewDocument convierte un EnrichedEventRecord en un Document usando sus etiquetas JSON,
+// de forma que cualquier campo nuevo del modelo queda disponible para las reglas.
+func NewDocument(record *models.EnrichedEventRecord) (Document, error) {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar el evento para evaluar condiciones: %w", err)
+	}
+	doc := Document{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("error al convertir el evento para evaluar condiciones: %w", err)
+	}
+	return doc, nil
+}
+
+// Values devuelve los valores escalares de la ruta indicada como cadenas. Las listas
+// intermedias o finales se expanden, por lo que una ruta puede devolver varios valores.
+func (d Document) Values(path string) []string {
+	var values []string
+	collect(map[string]interface{}(d), strings.Split(path, "."), &values)
+	return values
+}
+
+func collect(node interface{}, path []string, values *[]string) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return
+		}
+		child, ok := v[path[0]]
+		if !ok {
+			return
+		}
+		collect(child, path[1:], values)
+	case []interface{}:
+		for _, item := range v {
+			collect(item, path, values)
+		}
+	default:
+		if len(path) > 0 || v == nil {
+			return
+		}
+		*values = append(*values, ScalarString(v))
+	}
+}
+
+// ScalarString convierte un valor escalar decodificado de JSON a cadena.
+func ScalarString(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
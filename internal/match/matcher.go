@@ -0,0 +1,184 @@
+package match
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Matcher evalúa una condición sobre un Document.
+type Matcher interface {
+	Match(doc Document) bool
+}
+
+// AllOf se cumple cuando todas las condiciones se cumplen (AND).
+type AllOf []Matcher
+
+func (m AllOf) Match(doc Document) bool {
+	for _, matcher := range m {
+		if !matcher.Match(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyOf se cumple cuando al menos una condición se cumple (OR).
+type AnyOf []Matcher
+
+func (m AnyOf) Match(doc Document) bool {
+	for _, matcher := range m {
+		if matcher.Match(doc) {
+			return true
+		}
+	}
+	return false
+}
+
+// Not invierte el resultado de una condición.
+type Not struct {
+	Matcher Matcher
+}
+
+func (m Not) Match(doc Document) bool {
+	return !m.Matcher.Match(doc)
+}
+
+// Operator es el tipo de comparación de un FieldMatcher.
+type Operator string
+
+const (
+	OpEquals     Operator = "equals"
+	OpContains   Operator = "contains"
+	OpStartsWith Operator = "startswith"
+	OpEndsWith   Operator = "endswith"
+	OpRegex      Operator = "regex"
+	OpCIDR       Operator = "cidr"
+	OpExists     Operator = "exists"
+)
+
+// FieldMatcher compara los valores de un campo contra una lista de valores.
+// Se cumple si algún valor del campo coincide con algún valor de la lista.
+type FieldMatcher struct {
+	Field      string
+	Op         Operator
+	Values     []string
+	IgnoreCase bool
+
+	regexps []*regexp.Regexp
+	nets    []*net.IPNet
+}
+
+// NewFieldMatcher valida y precompila un FieldMatcher. Para OpExists, Values debe
+// contener "true" o "false".
+func NewFieldMatcher(field string, op Operator, values []string, ignoreCase bool) (*FieldMatcher, error) {
+	if field == "" {
+		return nil, fmt.Errorf("la condición no define el campo")
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("la condición sobre '%s' no define valores", field)
+	}
+
+	m := &FieldMatcher{Field: field, Op: op, Values: values, IgnoreCase: ignoreCase}
+	switch op {
+	case OpEquals, OpContains, OpStartsWith, OpEndsWith:
+		if ignoreCase {
+			for i, value := range m.Values {
+				m.Values[i] = strings.ToLower(value)
+			}
+		}
+	case OpRegex:
+		for _, value := range values {
+			if ignoreCase && !strings.HasPrefix(value, "(?i)") {
+				value = "(?i)" + value
+			}
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("expresión regular inválida en '%s': %w", field, err)
+			}
+			m.regexps = append(m.regexps, re)
+		}
+	case OpCIDR:
+		for _, value := range values {
+			_, ipNet, err := net.ParseCIDR(value)
+			if err != nil {
+				return nil, fmt.Errorf("CIDR inválido en '%s': %w", field, err)
+			}
+			m.nets = append(m.nets, ipNet)
+		}
+	case OpExists:
+		if values[0] != "true" && values[0] != "false" {
+			return nil, fmt.Errorf("exists en '%s' debe ser true o false", field)
+		}
+	default:
+		return nil, fmt.Errorf("operador '%s' no admitido", op)
+	}
+	return m, nil
+}
+
+func (m *FieldMatcher) Match(doc Document) bool {
+	fieldValues := doc.Values(m.Field)
+
+	if m.Op == OpExists {
+		exists := false
+		for _, value := range fieldValues {
+			if value != "" {
+				exists = true
+				break
+			}
+		}
+		return exists == (m.Values[0] == "true")
+	}
+
+	for _, fieldValue := range fieldValues {
+		if m.matchValue(fieldValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *FieldMatcher) matchValue(fieldValue string) bool {
+	switch m.Op {
+	case OpRegex:
+		for _, re := range m.regexps {
+			if re.MatchString(fieldValue) {
+				return true
+			}
+		}
+		return false
+	case OpCIDR:
+		ip := net.ParseIP(fieldValue)
+		if ip == nil {
+			return false
+		}
+		for _, ipNet := range m.nets {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if m.IgnoreCase {
+		fieldValue = strings.ToLower(fieldValue)
+	}
+	for _, value := range m.Values {
+		var ok bool
+		switch m.Op {
+		case OpEquals:
+			ok = fieldValue == value
+		case OpContains:
+			ok = strings.Contains(fieldValue, value)
+		case OpStartsWith:
+			ok = strings.HasPrefix(fieldValue, value)
+		case OpEndsWith:
+			ok = strings.HasSuffix(fieldValue, value)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
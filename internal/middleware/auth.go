@@ -9,6 +9,9 @@ import (
 	"net/http"
 )
 
+// UserClaimsKey es la clave bajo la cual AuthTokenMiddleware guarda los claims del usuario en el contexto.
+const UserClaimsKey = "userClaims"
+
 // Middleware contiene las dependencias para los middlewares.
 type Middleware struct {
 	JWTService  *token.JWTService    // CAMBIO: Ahora es *token.JWTService
@@ -56,7 +59,33 @@ func (mw *Middleware) AuthTokenMiddleware(next http.Handler) http.Handler {
 
 		// Opcional: Puedes añadir los claims del usuario al contexto de la solicitud
 		// para que los handlers posteriores puedan acceder a ellos.
-		ctx := context.WithValue(r.Context(), "userClaims", userClaims)
+		ctx := context.WithValue(r.Context(), UserClaimsKey, userClaims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// GetUserClaims recupera los claims del usuario que AuthTokenMiddleware guardó en el contexto.
+func GetUserClaims(ctx context.Context) (*token.User, bool) {
+	userClaims, ok := ctx.Value(UserClaimsKey).(*token.User)
+	return userClaims, ok && userClaims != nil
+}
+
+// RequireRole exige que el usuario autenticado tenga el rol indicado. Debe usarse después de
+// AuthTokenMiddleware.
+func (mw *Middleware) RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := GetUserClaims(r.Context())
+			if !ok {
+				utils.WriteJSON(w, http.StatusUnauthorized, utils.JSONResponse{Error: true, Message: "usuario no autenticado"})
+				return
+			}
+			if userClaims.Role != role {
+				logger.ErrorLog.Printf("Acceso denegado a %s %s para %s: se requiere el rol %s", r.Method, r.URL.Path, userClaims.Email, role)
+				utils.WriteJSON(w, http.StatusForbidden, utils.JSONResponse{Error: true, Message: "se requiere el rol " + role})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tipos de canal de notificación.
+const (
+	ChannelWebhook   = "webhook"   // JSON genérico con la detección completa
+	ChannelSlack     = "slack"     // Payload compatible con incoming webhooks de Slack
+	ChannelPagerDuty = "pagerduty" // Payload estilo PagerDuty Events API v2
+)
+
+// Channel es la configuración de un canal de notificación. Los secretos se leen de
+// variables de entorno para no guardarlos en el archivo de canales.
+type Channel struct {
+	Name          string            `yaml:"name"`
+	Type          string            `yaml:"type"`
+	URL           string            `yaml:"url"`
+	SecretEnv     string            `yaml:"secret_env"`      // Variable con la clave HMAC para firmar las solicitudes
+	RoutingKeyEnv string            `yaml:"routing_key_env"` // Variable con la routing key de PagerDuty
+	MinSeverity   string            `yaml:"min_severity"`    // Severidad mínima (por defecto, todas)
+	Rules         []string          `yaml:"rules"`           // Solo estas reglas (por defecto, todas)
+	Headers       map[string]string `yaml:"headers"`
+	Enabled       *bool             `yaml:"enabled"`
+
+	secret     string
+	routingKey string
+}
+
+// ChannelSet es el formato del archivo de canales.
+type ChannelSet struct {
+	Channels []Channel `yaml:"channels"`
+}
+
+// Secret devuelve la clave HMAC del canal, vacía si el canal no firma sus solicitudes.
+func (c *Channel) Secret() string {
+	return c.secret
+}
+
+// Matches indica si la detección debe notificarse por este canal.
+func (c *Channel) Matches(finding *models.Finding) bool {
+	if c.MinSeverity != "" && models.SeverityRank[finding.Severity] < models.SeverityRank[c.MinSeverity] {
+		return false
+	}
+	if len(c.Rules) == 0 {
+		return true
+	}
+	for _, rule := range c.Rules {
+		if rule == finding.RuleID {
+			return true
+		}
+	}
+	return false
+}
+
+// validate comprueba la configuración del canal y resuelve sus secretos.
+func (c *Channel) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("canal sin nombre")
+	}
+	switch c.Type {
+	case ChannelWebhook, ChannelSlack, ChannelPagerDuty:
+	default:
+		return fmt.Errorf("canal %s: tipo '%s' no válido", c.Name, c.Type)
+	}
+	parsed, err := url.Parse(c.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("canal %s: url '%s' no válida", c.Name, c.URL)
+	}
+	c.MinSeverity = strings.ToLower(c.MinSeverity)
+	if _, ok := models.SeverityRank[c.MinSeverity]; c.MinSeverity != "" && !ok {
+		return fmt.Errorf("canal %s: severidad mínima '%s' no válida", c.Name, c.MinSeverity)
+	}
+
+	if c.SecretEnv != "" {
+		if c.secret = os.Getenv(c.SecretEnv); c.secret == "" {
+			return fmt.Errorf("canal %s: la variable %s no está definida", c.Name, c.SecretEnv)
+		}
+	}
+	if c.Type == ChannelPagerDuty {
+		if c.RoutingKeyEnv == "" {
+			return fmt.Errorf("canal %s: pagerduty requiere routing_key_env", c.Name)
+		}
+		if c.routingKey = os.Getenv(c.RoutingKeyEnv); c.routingKey == "" {
+			return fmt.Errorf("canal %s: la variable %s no está definida", c.Name, c.RoutingKeyEnv)
+		}
+	}
+	return nil
+}
+
+// ParseChannels decodifica y valida los canales de un documento YAML. Los canales
+// deshabilitados se omiten.
+func ParseChannels(data []byte) ([]*Channel, error) {
+	var set ChannelSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("error al decodificar canales de notificación: %w", err)
+	}
+
+	var channels []*Channel
+	seen := map[string]bool{}
+	for i := range set.Channels {
+		channel := set.Channels[i]
+		if channel.Enabled != nil && !*channel.Enabled {
+			continue
+		}
+		if err := channel.validate(); err != nil {
+			return nil, err
+		}
+		if seen[channel.Name] {
+			return nil, fmt.Errorf("canal %s duplicado", channel.Name)
+		}
+		seen[channel.Name] = true
+		channels = append(channels, &channel)
+	}
+	return channels, nil
+}
+
+// LoadChannels carga los canales de notificación desde un archivo YAML.
+func LoadChannels(path string) ([]*Channel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer los canales de notificación en %s: %w", path, err)
+	}
+	return ParseChannels(data)
+}
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Source identifica a la API como origen de las notificaciones.
+const Source = "cloudtrail-enrichment-api"
+
+// slackColors asigna un color de adjunto de Slack a cada severidad.
+var slackColors = map[string]string{
+	models.SeverityLow:      "#439FE0",
+	models.SeverityMedium:   "#F2C744",
+	models.SeverityHigh:     "#E8912D",
+	models.SeverityCritical: "#D00000",
+}
+
+// pagerDutySeverities traduce las severidades del API a las de PagerDuty.
+var pagerDutySeverities = map[string]string{
+	models.SeverityLow:      "info",
+	models.SeverityMedium:   "warning",
+	models.SeverityHigh:     "error",
+	models.SeverityCritical: "critical",
+}
+
+// Payload construye el cuerpo JSON de la notificación según el tipo de canal.
+func (c *Channel) Payload(finding *models.Finding) ([]byte, error) {
+	var payload interface{}
+	switch c.Type {
+	case ChannelSlack:
+		payload = slackPayload(finding)
+	case ChannelPagerDuty:
+		payload = pagerDutyPayload(c.routingKey, finding)
+	default:
+		payload = map[string]interface{}{
+			"event":   "finding",
+			"source":  Source,
+			"finding": finding,
+		}
+	}
+	return json.Marshal(payload)
+}
+
+func summary(finding *models.Finding) string {
+	text := fmt.Sprintf("[%s] %s", strings.ToUpper(finding.Severity), finding.RuleName)
+	if finding.GroupKey != "" {
+		text += " (" + finding.GroupKey + ")"
+	}
+	return text
+}
+
+func slackPayload(finding *models.Finding) map[string]interface{} {
+	fields := []map[string]interface{}{
+		{"title": "Regla", "value": finding.RuleID, "short": true},
+		{"title": "Severidad", "value": finding.Severity, "short": true},
+		{"title": "Eventos", "value": len(finding.EventIDs), "short": true},
+		{"title": "Detección", "value": finding.ID.Hex(), "short": true},
+	}
+	if finding.GroupKey != "" {
+		fields = append(fields, map[string]interface{}{"title": "Grupo", "value": finding.GroupKey, "short": false})
+	}
+
+	return map[string]interface{}{
+		"text": summary(finding),
+		"attachments": []map[string]interface{}{{
+			"color":  slackColors[finding.Severity],
+			"text":   finding.Description,
+			"fields": fields,
+			"footer": Source,
+			"ts":     finding.LastSeen.Unix(),
+		}},
+	}
+}
+
+func pagerDutyPayload(routingKey string, finding *models.Finding) map[string]interface{} {
+	// La dedup_key agrupa en PagerDuty las detecciones repetidas de la misma regla y grupo.
+	dedupKey := finding.RuleID
+	if finding.GroupKey != "" {
+		dedupKey += "#" + finding.GroupKey
+	}
+
+	return map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    dedupKey,
+		"payload": map[string]interface{}{
+			"summary":        summary(finding),
+			"source":         Source,
+			"severity":       pagerDutySeverities[finding.Severity],
+			"timestamp":      finding.LastSeen.Format(time.RFC3339),
+			"component":      "cloudtrail",
+			"class":          finding.RuleID,
+			"custom_details": finding,
+		},
+	}
+}
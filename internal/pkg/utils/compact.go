@@ -0,0 +1,63 @@
+package utils
+
+import "reflect"
+
+// CompactDocument elimina recursivamente de un documento los valores nulos, las cadenas vacías
+// y las estructuras anidadas (mapas o listas) que quedan vacías. Los números y booleanos se
+// conservan aunque tengan su valor cero, ya que suelen ser significativos (p. ej. code: 0).
+func CompactDocument(doc map[string]interface{}) map[string]interface{} {
+	compacted, ok := compactValue(reflect.ValueOf(doc))
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return compacted.(map[string]interface{})
+}
+
+// compactValue devuelve el valor compactado y false si el valor se considera vacío.
+func compactValue(v reflect.Value) (interface{}, bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return nil, false
+		}
+		if v.Kind() == reflect.Interface {
+			return compactValue(v.Elem())
+		}
+		return v.Interface(), true
+	case reflect.String:
+		if v.Len() == 0 {
+			return nil, false
+		}
+		return v.Interface(), true
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface(), v.Len() > 0
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			if value, ok := compactValue(iter.Value()); ok {
+				out[iter.Key().String()] = value
+			}
+		}
+		return out, len(out) > 0
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Los binarios (p. ej. ObjectID) se conservan tal cual.
+			return v.Interface(), v.Len() > 0
+		}
+		out := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if value, ok := compactValue(v.Index(i)); ok {
+				out = append(out, value)
+			}
+		}
+		return out, len(out) > 0
+	default:
+		return v.Interface(), true
+	}
+}
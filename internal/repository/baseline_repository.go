@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"time"
+)
+
+// BaselineRepository define la persistencia de las líneas base de comportamiento por principal.
+type BaselineRepository interface {
+	ObserveBaselines(ctx context.Context, principal string, observations []models.BaselineObservation, at, expiresAt time.Time) (map[models.BaselineObservation]*models.BaselineEntry, error)
+	ListBaselineEntries(ctx context.Context, principal, dimension string) ([]*models.BaselineEntry, error)
+	GetBaselineEntry(ctx context.Context, principal, dimension, value string) (*models.BaselineEntry, error)
+}
+
+var BaselineRepo BaselineRepository
+
+// SetBaselineRepository permite inyectar una implementación de BaselineRepository.
+func SetBaselineRepository(repo BaselineRepository) {
+	BaselineRepo = repo
+}
+
+// ObserveBaselines es una función auxiliar que llama al método ObserveBaselines de la implementación actual.
+func ObserveBaselines(ctx context.Context, principal string, observations []models.BaselineObservation, at, expiresAt time.Time) (map[models.BaselineObservation]*models.BaselineEntry, error) {
+	return BaselineRepo.ObserveBaselines(ctx, principal, observations, at, expiresAt)
+}
+
+// ListBaselineEntries es una función auxiliar que llama al método ListBaselineEntries de la implementación actual.
+func ListBaselineEntries(ctx context.Context, principal, dimension string) ([]*models.BaselineEntry, error) {
+	return BaselineRepo.ListBaselineEntries(ctx, principal, dimension)
+}
+
+// GetBaselineEntry es una función auxiliar que llama al método GetBaselineEntry de la implementación actual.
+func GetBaselineEntry(ctx context.Context, principal, dimension, value string) (*models.BaselineEntry, error) {
+	return BaselineRepo.GetBaselineEntry(ctx, principal, dimension, value)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+)
+
+// CorrelationStateRepository define la persistencia del estado de las reglas de correlación.
+type CorrelationStateRepository interface {
+	LoadCorrelationStates(ctx context.Context) ([]*models.CorrelationState, error)
+	SaveCorrelationState(ctx context.Context, state *models.CorrelationState) error
+	DeleteCorrelationState(ctx context.Context, key string) error
+}
+
+var CorrelationStateRepo CorrelationStateRepository
+
+// SetCorrelationStateRepository permite inyectar una implementación de CorrelationStateRepository.
+func SetCorrelationStateRepository(repo CorrelationStateRepository) {
+	CorrelationStateRepo = repo
+}
+
+// LoadCorrelationStates es una función auxiliar que llama al método LoadCorrelationStates de la implementación actual.
+func LoadCorrelationStates(ctx context.Context) ([]*models.CorrelationState, error) {
+	return CorrelationStateRepo.LoadCorrelationStates(ctx)
+}
+
+// SaveCorrelationState es una función auxiliar que llama al método SaveCorrelationState de la implementación actual.
+func SaveCorrelationState(ctx context.Context, state *models.CorrelationState) error {
+	return CorrelationStateRepo.SaveCorrelationState(ctx, state)
+}
+
+// DeleteCorrelationState es una función auxiliar que llama al método DeleteCorrelationState de la implementación actual.
+func DeleteCorrelationState(ctx context.Context, key string) error {
+	return CorrelationStateRepo.DeleteCorrelationState(ctx, key)
+}
@@ -8,6 +8,11 @@ import (
 type EnrichmentRepository interface {
 	InsertLog(ctx context.Context, event *models.EnrichedEventRecord) error
 	GetLatestLogs(ctx context.Context) ([]*models.EnrichedEventRecord, error)
+	SearchLogs(ctx context.Context, filter *models.EventFilter) ([]*models.EnrichedEventRecord, error)
+	SearchLogsProjection(ctx context.Context, filter *models.EventFilter, fields []string) ([]map[string]interface{}, error)
+	GetLogByID(ctx context.Context, id string) (*models.EnrichedEventRecord, error)
+	CountLogs(ctx context.Context, filter *models.EventFilter) (int64, error)
+	AggregateLogs(ctx context.Context, filter *models.EventFilter, field models.AggregationField, limit int64) ([]*models.AggregateBucket, error)
 }
 
 // Declaramos una variable global para la instancia del repositorio de enriquecimiento.
@@ -28,3 +33,28 @@ func InsertLog(ctx context.Context, log *models.EnrichedEventRecord) error {
 func GetLatestLogs(ctx context.Context) ([]*models.EnrichedEventRecord, error) {
 	return EnrichmentRepo.GetLatestLogs(ctx)
 }
+
+// SearchLogs es una función auxiliar que llama al método SearchLogs de la implementación actual.
+func SearchLogs(ctx context.Context, filter *models.EventFilter) ([]*models.EnrichedEventRecord, error) {
+	return EnrichmentRepo.SearchLogs(ctx, filter)
+}
+
+// SearchLogsProjection es una función auxiliar que llama al método SearchLogsProjection de la implementación actual.
+func SearchLogsProjection(ctx context.Context, filter *models.EventFilter, fields []string) ([]map[string]interface{}, error) {
+	return EnrichmentRepo.SearchLogsProjection(ctx, filter, fields)
+}
+
+// GetLogByID es una función auxiliar que llama al método GetLogByID de la implementación actual.
+func GetLogByID(ctx context.Context, id string) (*models.EnrichedEventRecord, error) {
+	return EnrichmentRepo.GetLogByID(ctx, id)
+}
+
+// CountLogs es una función auxiliar que llama al método CountLogs de la implementación actual.
+func CountLogs(ctx context.Context, filter *models.EventFilter) (int64, error) {
+	return EnrichmentRepo.CountLogs(ctx, filter)
+}
+
+// AggregateLogs es una función auxiliar que llama al método AggregateLogs de la implementación actual.
+func AggregateLogs(ctx context.Context, filter *models.EventFilter, field models.AggregationField, limit int64) ([]*models.AggregateBucket, error) {
+	return EnrichmentRepo.AggregateLogs(ctx, filter, field, limit)
+}
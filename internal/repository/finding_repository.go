@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"time"
+)
+
+// FindingRepository define las operaciones de persistencia de las detecciones.
+type FindingRepository interface {
+	InsertFinding(ctx context.Context, finding *models.Finding) error
+	ListFindings(ctx context.Context, filter *models.FindingFilter) ([]*models.Finding, error)
+	GetFinding(ctx context.Context, id string) (*models.Finding, error)
+	SetFindingStatus(ctx context.Context, id, status string, change models.FindingChange) (*models.Finding, error)
+	SetFindingAssignee(ctx context.Context, id, assignee string, change models.FindingChange) (*models.Finding, error)
+	AddFindingComment(ctx context.Context, id string, comment models.FindingComment, change models.FindingChange) (*models.Finding, error)
+	CountFindingsSince(ctx context.Context, ruleID, dedupKey string, since time.Time) (int64, error)
+	RecordSuppressed(ctx context.Context, ruleID, dedupKey string, lastSeen, at time.Time) error
+}
+
+var FindingRepo FindingRepository
+
+// SetFindingRepository permite inyectar una implementación de FindingRepository.
+func SetFindingRepository(repo FindingRepository) {
+	FindingRepo = repo
+}
+
+// InsertFinding es una función auxiliar que llama al método InsertFinding de la implementación actual.
+func InsertFinding(ctx context.Context, finding *models.Finding) error {
+	return FindingRepo.InsertFinding(ctx, finding)
+}
+
+// ListFindings es una función auxiliar que llama al método ListFindings de la implementación actual.
+func ListFindings(ctx context.Context, filter *models.FindingFilter) ([]*models.Finding, error) {
+	return FindingRepo.ListFindings(ctx, filter)
+}
+
+// GetFinding es una función auxiliar que llama al método GetFinding de la implementación actual.
+func GetFinding(ctx context.Context, id string) (*models.Finding, error) {
+	return FindingRepo.GetFinding(ctx, id)
+}
+
+// SetFindingStatus es una función auxiliar que llama al método SetFindingStatus de la implementación actual.
+func SetFindingStatus(ctx context.Context, id, status string, change models.FindingChange) (*models.Finding, error) {
+	return FindingRepo.SetFindingStatus(ctx, id, status, change)
+}
+
+// SetFindingAssignee es una función auxiliar que llama al método SetFindingAssignee de la implementación actual.
+func SetFindingAssignee(ctx context.Context, id, assignee string, change models.FindingChange) (*models.Finding, error) {
+	return FindingRepo.SetFindingAssignee(ctx, id, assignee, change)
+}
+
+// AddFindingComment es una función auxiliar que llama al método AddFindingComment de la implementación actual.
+func AddFindingComment(ctx context.Context, id string, comment models.FindingComment, change models.FindingChange) (*models.Finding, error) {
+	return FindingRepo.AddFindingComment(ctx, id, comment, change)
+}
+
+// CountFindingsSince es una función auxiliar que llama al método CountFindingsSince de la implementación actual.
+func CountFindingsSince(ctx context.Context, ruleID, dedupKey string, since time.Time) (int64, error) {
+	return FindingRepo.CountFindingsSince(ctx, ruleID, dedupKey, since)
+}
+
+// RecordSuppressed es una función auxiliar que llama al método RecordSuppressed de la implementación actual.
+func RecordSuppressed(ctx context.Context, ruleID, dedupKey string, lastSeen, at time.Time) error {
+	return FindingRepo.RecordSuppressed(ctx, ruleID, dedupKey, lastSeen, at)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+)
+
+// InventoryRepository define la persistencia del inventario de cuentas y recursos.
+type InventoryRepository interface {
+	UpsertInventoryItem(ctx context.Context, item *models.InventoryItem) error
+	GetInventoryItem(ctx context.Context, kind, key string) (*models.InventoryItem, error)
+	ListInventoryItems(ctx context.Context, kind string) ([]*models.InventoryItem, error)
+	DeleteInventoryItem(ctx context.Context, kind, key string) (bool, error)
+	ReplaceInventorySource(ctx context.Context, source string, items []*models.InventoryItem) error
+}
+
+var InventoryRepo InventoryRepository
+
+// SetInventoryRepository permite inyectar una implementación de InventoryRepository.
+func SetInventoryRepository(repo InventoryRepository) {
+	InventoryRepo = repo
+}
+
+// UpsertInventoryItem es una función auxiliar que llama al método UpsertInventoryItem de la implementación actual.
+func UpsertInventoryItem(ctx context.Context, item *models.InventoryItem) error {
+	return InventoryRepo.UpsertInventoryItem(ctx, item)
+}
+
+// GetInventoryItem es una función auxiliar que llama al método GetInventoryItem de la implementación actual.
+func GetInventoryItem(ctx context.Context, kind, key string) (*models.InventoryItem, error) {
+	return InventoryRepo.GetInventoryItem(ctx, kind, key)
+}
+
+// ListInventoryItems es una función auxiliar que llama al método ListInventoryItems de la implementación actual.
+func ListInventoryItems(ctx context.Context, kind string) ([]*models.InventoryItem, error) {
+	return InventoryRepo.ListInventoryItems(ctx, kind)
+}
+
+// DeleteInventoryItem es una función auxiliar que llama al método DeleteInventoryItem de la implementación actual.
+func DeleteInventoryItem(ctx context.Context, kind, key string) (bool, error) {
+	return InventoryRepo.DeleteInventoryItem(ctx, kind, key)
+}
+
+// ReplaceInventorySource es una función auxiliar que llama al método ReplaceInventorySource de la implementación actual.
+func ReplaceInventorySource(ctx context.Context, source string, items []*models.InventoryItem) error {
+	return InventoryRepo.ReplaceInventorySource(ctx, source, items)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+)
+
+// NotificationDeliveryRepository define la persistencia del registro de entregas de notificaciones.
+type NotificationDeliveryRepository interface {
+	InsertDelivery(ctx context.Context, delivery *models.NotificationDelivery) error
+	ListDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]*models.NotificationDelivery, error)
+}
+
+var NotificationDeliveryRepo NotificationDeliveryRepository
+
+// SetNotificationDeliveryRepository permite inyectar una implementación de NotificationDeliveryRepository.
+func SetNotificationDeliveryRepository(repo NotificationDeliveryRepository) {
+	NotificationDeliveryRepo = repo
+}
+
+// InsertDelivery es una función auxiliar que llama al método InsertDelivery de la implementación actual.
+func InsertDelivery(ctx context.Context, delivery *models.NotificationDelivery) error {
+	return NotificationDeliveryRepo.InsertDelivery(ctx, delivery)
+}
+
+// ListDeliveries es una función auxiliar que llama al método ListDeliveries de la implementación actual.
+func ListDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]*models.NotificationDelivery, error) {
+	return NotificationDeliveryRepo.ListDeliveries(ctx, filter)
+}
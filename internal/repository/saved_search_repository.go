@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"time"
+)
+
+// SavedSearchRepository define las operaciones de persistencia de las búsquedas guardadas
+// y de las instantáneas de sus ejecuciones.
+type SavedSearchRepository interface {
+	InsertSavedSearch(ctx context.Context, search *models.SavedSearch) error
+	GetSavedSearchByUUID(ctx context.Context, uuid string) (*models.SavedSearch, error)
+	GetSavedSearchesByUserID(ctx context.Context, userID int) ([]*models.SavedSearch, error)
+	GetScheduledSavedSearches(ctx context.Context) ([]*models.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, search *models.SavedSearch) error
+	UpdateSavedSearchLastRun(ctx context.Context, id int, lastRun time.Time) error
+	DeleteSavedSearch(ctx context.Context, id int) error
+
+	// Métodos para las instantáneas de resultados
+	InsertSavedSearchResult(ctx context.Context, result *models.SavedSearchResult) error
+	GetSavedSearchResults(ctx context.Context, savedSearchID int, limit int) ([]*models.SavedSearchResult, error)
+	GetLatestSavedSearchResult(ctx context.Context, savedSearchID int) (*models.SavedSearchResult, error)
+}
+
+var SavedSearchRepo SavedSearchRepository
+
+// SetSavedSearchRepository permite inyectar una implementación de SavedSearchRepository.
+func SetSavedSearchRepository(repo SavedSearchRepository) {
+	SavedSearchRepo = repo
+}
+
+func InsertSavedSearch(ctx context.Context, search *models.SavedSearch) error {
+	return SavedSearchRepo.InsertSavedSearch(ctx, search)
+}
+
+func GetSavedSearchByUUID(ctx context.Context, uuid string) (*models.SavedSearch, error) {
+	return SavedSearchRepo.GetSavedSearchByUUID(ctx, uuid)
+}
+
+func GetSavedSearchesByUserID(ctx context.Context, userID int) ([]*models.SavedSearch, error) {
+	return SavedSearchRepo.GetSavedSearchesByUserID(ctx, userID)
+}
+
+func GetScheduledSavedSearches(ctx context.Context) ([]*models.SavedSearch, error) {
+	return SavedSearchRepo.GetScheduledSavedSearches(ctx)
+}
+
+func UpdateSavedSearch(ctx context.Context, search *models.SavedSearch) error {
+	return SavedSearchRepo.UpdateSavedSearch(ctx, search)
+}
+
+func UpdateSavedSearchLastRun(ctx context.Context, id int, lastRun time.Time) error {
+	return SavedSearchRepo.UpdateSavedSearchLastRun(ctx, id, lastRun)
+}
+
+func DeleteSavedSearch(ctx context.Context, id int) error {
+	return SavedSearchRepo.DeleteSavedSearch(ctx, id)
+}
+
+func InsertSavedSearchResult(ctx context.Context, result *models.SavedSearchResult) error {
+	return SavedSearchRepo.InsertSavedSearchResult(ctx, result)
+}
+
+func GetSavedSearchResults(ctx context.Context, savedSearchID int, limit int) ([]*models.SavedSearchResult, error) {
+	return SavedSearchRepo.GetSavedSearchResults(ctx, savedSearchID, limit)
+}
+
+func GetLatestSavedSearchResult(ctx context.Context, savedSearchID int) (*models.SavedSearchResult, error) {
+	return SavedSearchRepo.GetLatestSavedSearchResult(ctx, savedSearchID)
+}
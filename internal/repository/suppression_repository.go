@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"time"
+)
+
+// SuppressionRepository define las operaciones de persistencia de las supresiones de analistas.
+type SuppressionRepository interface {
+	InsertSuppression(ctx context.Context, suppression *models.Suppression) error
+	ListSuppressions(ctx context.Context, activeAt *time.Time) ([]*models.Suppression, error)
+	ExpireSuppression(ctx context.Context, id string, at time.Time) (*models.Suppression, error)
+	RecordSuppressionHit(ctx context.Context, id string, at time.Time) error
+}
+
+var SuppressionRepo SuppressionRepository
+
+// SetSuppressionRepository permite inyectar una implementación de SuppressionRepository.
+func SetSuppressionRepository(repo SuppressionRepository) {
+	SuppressionRepo = repo
+}
+
+// InsertSuppression es una función auxiliar que llama al método InsertSuppression de la implementación actual.
+func InsertSuppression(ctx context.Context, suppression *models.Suppression) error {
+	return SuppressionRepo.InsertSuppression(ctx, suppression)
+}
+
+// ListSuppressions es una función auxiliar que llama al método ListSuppressions de la implementación actual.
+func ListSuppressions(ctx context.Context, activeAt *time.Time) ([]*models.Suppression, error) {
+	return SuppressionRepo.ListSuppressions(ctx, activeAt)
+}
+
+// ExpireSuppression es una función auxiliar que llama al método ExpireSuppression de la implementación actual.
+func ExpireSuppression(ctx context.Context, id string, at time.Time) (*models.Suppression, error) {
+	return SuppressionRepo.ExpireSuppression(ctx, id, at)
+}
+
+// RecordSuppressionHit es una función auxiliar que llama al método RecordSuppressionHit de la implementación actual.
+func RecordSuppressionHit(ctx context.Context, id string, at time.Time) error {
+	return SuppressionRepo.RecordSuppressionHit(ctx, id, at)
+}
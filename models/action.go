@@ -0,0 +1,29 @@
+package models
+
+// Categorías de la acción de un evento según su efecto.
+const (
+	ActionRead             = "read"              // Consultas de configuración: Describe*, List*, Get*...
+	ActionWrite            = "write"             // Crea o modifica recursos
+	ActionDelete           = "delete"            // Elimina o da de baja recursos
+	ActionPermissionChange = "permission-change" // Cambia políticas, permisos, ACL o concesiones
+	ActionDataAccess       = "data-access"       // Lee el contenido de los datos: objetos, secretos, ítems, descifrado...
+)
+
+// ActionCategories contiene las categorías válidas.
+var ActionCategories = map[string]bool{
+	ActionRead:             true,
+	ActionWrite:            true,
+	ActionDelete:           true,
+	ActionPermissionChange: true,
+	ActionDataAccess:       true,
+}
+
+// ActionInfo clasifica la acción del evento. ReadOnly es verdadero en las categorías read y
+// data-access, que no modifican recursos. Sensitive marca las acciones que afectan a la
+// seguridad de la cuenta, como detener el registro de CloudTrail o hacer público un bucket.
+type ActionInfo struct {
+	Category  string `json:"category" bson:"category"`
+	ReadOnly  bool   `json:"readOnly" bson:"readOnly"`
+	Sensitive bool   `json:"sensitive,omitempty" bson:"sensitive,omitempty"`
+	Reason    string `json:"reason,omitempty" bson:"reason,omitempty"` // Motivo de la marca, p. ej. logging o public-access
+}
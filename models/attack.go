@@ -0,0 +1,7 @@
+package models
+
+// AttackTags son las técnicas y tácticas de MITRE ATT&CK for Cloud asociadas a un evento.
+type AttackTags struct {
+	Techniques []string `json:"techniques" bson:"techniques"` // p. ej. T1098.001
+	Tactics    []string `json:"tactics" bson:"tactics"`       // p. ej. persistence
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Dimensiones de comportamiento registradas en la línea base de cada principal.
+const (
+	BaselineDimensionPrincipal = "principal" // Entrada única que registra desde cuándo se observa al principal
+	BaselineDimensionCountry   = "country"
+	BaselineDimensionASN       = "asn"
+	BaselineDimensionUserAgent = "userAgent"
+	BaselineDimensionRegion    = "region"
+	BaselineDimensionEventName = "eventName"
+)
+
+// BaselineDimensions contiene las dimensiones válidas para consultas y alertas.
+var BaselineDimensions = map[string]bool{
+	BaselineDimensionCountry:   true,
+	BaselineDimensionASN:       true,
+	BaselineDimensionUserAgent: true,
+	BaselineDimensionRegion:    true,
+	BaselineDimensionEventName: true,
+}
+
+// BaselineObservation es el valor de una dimensión observado en un evento.
+type BaselineObservation struct {
+	Dimension string
+	Value     string
+}
+
+// BaselineEntry es un valor observado para un principal en una dimensión. Las entradas que
+// no se vuelven a observar durante la ventana de la línea base expiran.
+type BaselineEntry struct {
+	ID        primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	Principal string             `json:"principal" bson:"principal"`
+	Dimension string             `json:"dimension" bson:"dimension"`
+	Value     string             `json:"value" bson:"value"`
+	FirstSeen time.Time          `json:"first_seen" bson:"first_seen"`
+	LastSeen  time.Time          `json:"last_seen" bson:"last_seen"`
+	Count     int64              `json:"count" bson:"count"`
+	ExpiresAt time.Time          `json:"-" bson:"expires_at"` // Índice TTL de MongoDB
+}
+
+// BaselineProfile agrupa la línea base de un principal por dimensión: lo que es "normal" para él.
+type BaselineProfile struct {
+	Principal  string                      `json:"principal"`
+	FirstSeen  *time.Time                  `json:"first_seen,omitempty"` // Primer evento dentro de la ventana
+	Dimensions map[string][]*BaselineEntry `json:"dimensions"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CorrelationEvent es un evento acumulado en el estado de una regla de correlación.
+type CorrelationEvent struct {
+	EventID primitive.ObjectID `json:"event_id" bson:"event_id"`
+	Time    time.Time          `json:"time" bson:"time"`
+	Step    int                `json:"step" bson:"step"`                         // Paso de la secuencia (0 en reglas count/distinct_count)
+	Values  map[string]string  `json:"values,omitempty" bson:"values,omitempty"` // Valores de los campos que la regla necesita comparar
+}
+
+// CorrelationState es el estado con ventana de tiempo de una regla de correlación para
+// una clave de agrupación (principal, IP, cuenta...). Se persiste para sobrevivir reinicios.
+type CorrelationState struct {
+	Key       string             `json:"key" bson:"_id"` // rule_id#group_key
+	RuleID    string             `json:"rule_id" bson:"rule_id"`
+	GroupKey  string             `json:"group_key" bson:"group_key"`
+	Events    []CorrelationEvent `json:"events" bson:"events"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"` // Índice TTL de MongoDB
+}
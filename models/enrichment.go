@@ -1,6 +1,10 @@
 package models
 
 import (
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -8,9 +12,75 @@ import (
 
 // EnrichmentData representa la información de enriquecimiento geográfico.
 type EnrichmentData struct {
-	Country   string `json:"country" bson:"country"`
-	Region    string `json:"region" bson:"region"`
-	Subregion string `json:"subregion" bson:"subregion"`
+	Country           string     `json:"country" bson:"country"`
+	Region            string     `json:"region" bson:"region"`
+	Subregion         string     `json:"subregion" bson:"subregion"`
+	City              string     `json:"city,omitempty" bson:"city,omitempty"`
+	ASN               string     `json:"asn,omitempty" bson:"asn,omitempty"`             // Sistema autónomo, p. ej. "AS16509"
+	ASOrg             string     `json:"asOrg,omitempty" bson:"asOrg,omitempty"`         // Organización dueña del sistema autónomo, p. ej. "Amazon.com, Inc."
+	ASNetwork         string     `json:"asNetwork,omitempty" bson:"asNetwork,omitempty"` // Prefijo anunciado que contiene la IP, si la base ASN lo indica
+	Latitude          float64    `json:"latitude,omitempty" bson:"latitude,omitempty"`
+	Longitude         float64    `json:"longitude,omitempty" bson:"longitude,omitempty"`
+	IsTor             bool       `json:"isTor,omitempty" bson:"isTor,omitempty"`                         // La IP es un nodo de salida de Tor
+	IsVPN             bool       `json:"isVPN,omitempty" bson:"isVPN,omitempty"`                         // La IP pertenece a un anonimizador o VPN comercial
+	IsHosting         bool       `json:"isHosting,omitempty" bson:"isHosting,omitempty"`                 // La IP pertenece a un proveedor de hosting
+	AWS               *AWSSource `json:"aws,omitempty" bson:"aws,omitempty"`                             // Origen propio de AWS: principal de servicio o IP de ip-ranges.json
+	NetworkType       string     `json:"networkType,omitempty" bson:"networkType,omitempty"`             // public, private, reserved o vpc-endpoint
+	Site              string     `json:"site,omitempty" bson:"site,omitempty"`                           // Sede u oficina del rango interno configurado que contiene la IP
+	Hostname          string     `json:"hostname,omitempty" bson:"hostname,omitempty"`                   // Nombre PTR de la IP
+	HostnameConfirmed bool       `json:"hostnameConfirmed,omitempty" bson:"hostnameConfirmed,omitempty"` // La resolución directa del nombre incluye la IP
+}
+
+// Tipos de red del origen de un evento.
+const (
+	NetworkPublic      = "public"
+	NetworkPrivate     = "private"      // RFC 1918, CGNAT (RFC 6598) e IPv6 ULA
+	NetworkReserved    = "reserved"     // Loopback, link-local, documentación, multicast y otros rangos reservados
+	NetworkVPCEndpoint = "vpc-endpoint" // IP privada de una solicitud recibida por un endpoint de VPC
+)
+
+// NetworkTypes contiene los tipos de red válidos.
+var NetworkTypes = map[string]bool{
+	NetworkPublic:      true,
+	NetworkPrivate:     true,
+	NetworkReserved:    true,
+	NetworkVPCEndpoint: true,
+}
+
+// AWSSource identifica un sourceIPAddress que pertenece a AWS.
+type AWSSource struct {
+	Service   string `json:"service" bson:"service"`                         // Servicio, p. ej. "ec2.amazonaws.com" o "EC2" según ip-ranges.json
+	Region    string `json:"region,omitempty" bson:"region,omitempty"`       // Región de AWS del rango o del evento
+	Prefix    string `json:"prefix,omitempty" bson:"prefix,omitempty"`       // Rango de ip-ranges.json que contiene la IP
+	Principal bool   `json:"principal,omitempty" bson:"principal,omitempty"` // El origen es un principal de servicio y no una IP
+}
+
+// NeedsGeo indica si corresponde consultar la geolocalización del origen: solo las IP públicas
+// que no pertenecen a AWS. Los principales de servicio, otros nombres de host y las direcciones
+// privadas o reservadas no se geolocalizan.
+func (r *EnrichedEventRecord) NeedsGeo() bool {
+	if r.Enrichment.NetworkType != "" && r.Enrichment.NetworkType != NetworkPublic {
+		return false
+	}
+	return r.Enrichment.AWS == nil && net.ParseIP(r.SourceIPAddress) != nil
+}
+
+// NormalizeASN devuelve el número de sistema autónomo con el prefijo "AS" ("16509" y
+// "as16509" se convierten en "AS16509"). Devuelve "" si el valor no es un ASN.
+func NormalizeASN(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > 2 && strings.EqualFold(value[:2], "AS") {
+		value = value[2:]
+	}
+	if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+		return ""
+	}
+	return "AS" + value
+}
+
+// HasCoordinates indica si el enriquecimiento geográfico obtuvo coordenadas para la IP.
+func (e EnrichmentData) HasCoordinates() bool {
+	return e.Latitude != 0 || e.Longitude != 0
 }
 
 // UserIdentity representa la identidad del usuario.
@@ -21,6 +91,25 @@ type UserIdentity struct {
 	AccessKeyID string `json:"accessKeyId" bson:"accessKeyId"`
 	AccountID   string `json:"accountId" bson:"accountId"`
 	UserName    string `json:"userName" bson:"userName"`
+	InvokedBy   string `json:"invokedBy,omitempty" bson:"invokedBy,omitempty"` // Servicio que hizo la llamada, en identidades AWSService
+	// SessionContext solo está presente en credenciales temporales (roles, consola)
+	SessionContext *SessionContext `json:"sessionContext,omitempty" bson:"sessionContext,omitempty"`
+}
+
+// SessionContext representa el contexto de la sesión de credenciales temporales.
+type SessionContext struct {
+	Attributes SessionAttributes `json:"attributes" bson:"attributes"`
+}
+
+// SessionAttributes representa los atributos de la sesión.
+type SessionAttributes struct {
+	MfaAuthenticated string `json:"mfaAuthenticated" bson:"mfaAuthenticated"` // "true" o "false"
+	CreationDate     string `json:"creationDate,omitempty" bson:"creationDate,omitempty"`
+}
+
+// AdditionalEventData representa los datos adicionales del evento (p. ej. MFAUsed en ConsoleLogin).
+type AdditionalEventData struct {
+	MFAUsed string `json:"MFAUsed,omitempty" bson:"MFAUsed,omitempty"` // "Yes" o "No"
 }
 
 // InstanceItem representa un elemento de instancia.
@@ -33,9 +122,79 @@ type InstancesSet struct {
 	Items []InstanceItem `json:"items" bson:"items"`
 }
 
-// RequestParameters representa los parámetros de la solicitud.
+// RequestParameters representa los parámetros de la solicitud. Además de los campos
+// tipados conserva el resto de parámetros originales de CloudTrail (bucketName, userName,
+// policyArn...) en Other, que se guarda y se serializa al mismo nivel que los tipados.
 type RequestParameters struct {
-	InstancesSet InstancesSet `json:"instancesSet" bson:"instancesSet"`
+	InstancesSet InstancesSet           `json:"instancesSet" bson:"instancesSet"`
+	Other        map[string]interface{} `json:"-" bson:",inline"`
+}
+
+// UnmarshalJSON decodifica los campos tipados y guarda los demás parámetros en Other.
+func (p *RequestParameters) UnmarshalJSON(data []byte) error {
+	type typed RequestParameters
+	if err := json.Unmarshal(data, (*typed)(p)); err != nil {
+		return err
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	delete(all, "instancesSet")
+	p.Other = nil
+	if len(all) > 0 {
+		p.Other = all
+	}
+	return nil
+}
+
+// MarshalJSON serializa los parámetros tipados junto con los de Other.
+func (p RequestParameters) MarshalJSON() ([]byte, error) {
+	all := make(map[string]interface{}, len(p.Other)+1)
+	for key, value := range p.Other {
+		all[key] = plainValue(value)
+	}
+	all["instancesSet"] = p.InstancesSet
+	return json.Marshal(all)
+}
+
+// plainValue convierte los documentos y arreglos BSON leídos de MongoDB en mapas y listas,
+// para que se serialicen en JSON igual que el evento original.
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.D:
+		m := make(map[string]interface{}, len(v))
+		for _, elem := range v {
+			m[elem.Key] = plainValue(elem.Value)
+		}
+		return m
+	case primitive.M:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[key] = plainValue(elem)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[key] = plainValue(elem)
+		}
+		return m
+	case primitive.A:
+		list := make([]interface{}, len(v))
+		for i, elem := range v {
+			list[i] = plainValue(elem)
+		}
+		return list
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, elem := range v {
+			list[i] = plainValue(elem)
+		}
+		return list
+	default:
+		return v
+	}
 }
 
 // CurrentState representa el estado actual de una instancia.
@@ -62,42 +221,100 @@ type ResponseInstancesSet struct {
 	Items []ResponseInstanceItem `json:"items" bson:"items"`
 }
 
-// ResponseElements representa los elementos de la respuesta.
+// ResponseElements representa los elementos de la respuesta. Igual que RequestParameters,
+// conserva en Other los elementos originales que no están tipados (roleArn, bucket, keyId...).
 type ResponseElements struct {
-	InstancesSet ResponseInstancesSet `json:"instancesSet" bson:"instancesSet"`
+	InstancesSet ResponseInstancesSet   `json:"instancesSet" bson:"instancesSet"`
+	ConsoleLogin string                 `json:"ConsoleLogin,omitempty" bson:"ConsoleLogin,omitempty"` // Success/Failure en eventos ConsoleLogin
+	Other        map[string]interface{} `json:"-" bson:",inline"`
+}
+
+// UnmarshalJSON decodifica los campos tipados y guarda los demás elementos en Other.
+func (e *ResponseElements) UnmarshalJSON(data []byte) error {
+	type typed ResponseElements
+	if err := json.Unmarshal(data, (*typed)(e)); err != nil {
+		return err
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	delete(all, "instancesSet")
+	delete(all, "ConsoleLogin")
+	e.Other = nil
+	if len(all) > 0 {
+		e.Other = all
+	}
+	return nil
+}
+
+// MarshalJSON serializa los elementos tipados junto con los de Other.
+func (e ResponseElements) MarshalJSON() ([]byte, error) {
+	all := make(map[string]interface{}, len(e.Other)+2)
+	for key, value := range e.Other {
+		all[key] = plainValue(value)
+	}
+	all["instancesSet"] = e.InstancesSet
+	if e.ConsoleLogin != "" {
+		all["ConsoleLogin"] = e.ConsoleLogin
+	}
+	return json.Marshal(all)
 }
 
 // Event es la estructura original que define el formato de entrada de los eventos.
 // Hemos modificado los tipos anidados para usar los tipos nombrados definidos arriba.
 type Event struct {
 	Records []struct {
-		EventVersion      string            `json:"eventVersion"`
-		UserIdentity      UserIdentity      `json:"userIdentity"` // Usamos el tipo nombrado
-		EventTime         time.Time         `json:"eventTime"`
-		EventSource       string            `json:"eventSource"`
-		EventName         string            `json:"eventName"`
-		AwsRegion         string            `json:"awsRegion"`
-		SourceIPAddress   string            `json:"sourceIPAddress"`
-		UserAgent         string            `json:"userAgent"`
-		RequestParameters RequestParameters `json:"requestParameters"` // Usamos el tipo nombrado
-		ResponseElements  ResponseElements  `json:"responseElements"`  // Usamos el tipo nombrado
-		Enrichment        EnrichmentData    `json:"enrichment"`        // Usamos el tipo nombrado
+		EventVersion        string               `json:"eventVersion"`
+		UserIdentity        UserIdentity         `json:"userIdentity"` // Usamos el tipo nombrado
+		EventTime           time.Time            `json:"eventTime"`
+		EventSource         string               `json:"eventSource"`
+		EventName           string               `json:"eventName"`
+		AwsRegion           string               `json:"awsRegion"`
+		SourceIPAddress     string               `json:"sourceIPAddress"`
+		UserAgent           string               `json:"userAgent"`
+		RequestParameters   RequestParameters    `json:"requestParameters"` // Usamos el tipo nombrado
+		ResponseElements    ResponseElements     `json:"responseElements"`  // Usamos el tipo nombrado
+		AdditionalEventData *AdditionalEventData `json:"additionalEventData,omitempty"`
+		ErrorCode           string               `json:"errorCode,omitempty"`
+		ErrorMessage        string               `json:"errorMessage,omitempty"`
+		VpcEndpointID       string               `json:"vpcEndpointId,omitempty"`
+		RecipientAccountID  string               `json:"recipientAccountId,omitempty"`
+		ReadOnly            *bool                `json:"readOnly,omitempty"`
+		EventCategory       string               `json:"eventCategory,omitempty"`
+		Resources           []Resource           `json:"resources,omitempty"`
+		Enrichment          EnrichmentData       `json:"enrichment"` // Usamos el tipo nombrado
 	} `json:"Records"`
 }
 
 // EnrichedEventRecord representa un único registro de evento después de ser enriquecido,
 // listo para ser insertado en la base de datos.
 type EnrichedEventRecord struct {
-	ID                primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"` // ID de MongoDB
-	EventVersion      string             `json:"eventVersion" bson:"eventVersion"`
-	UserIdentity      UserIdentity       `json:"userIdentity" bson:"userIdentity"`
-	EventTime         time.Time          `json:"eventTime" bson:"eventTime"`
-	EventSource       string             `json:"eventSource" bson:"eventSource"`
-	EventName         string             `json:"eventName" bson:"eventName"`
-	AwsRegion         string             `json:"awsRegion" bson:"awsRegion"`
-	SourceIPAddress   string             `json:"sourceIPAddress" bson:"sourceIPAddress"`
-	UserAgent         string             `json:"userAgent" bson:"userAgent"`
-	RequestParameters RequestParameters  `json:"requestParameters" bson:"requestParameters"`
-	ResponseElements  ResponseElements   `json:"responseElements" bson:"responseElements"`
-	Enrichment        EnrichmentData     `json:"enrichment" bson:"enrichment"` // La información de enriquecimiento
+	ID                  primitive.ObjectID   `json:"id,omitempty" bson:"_id,omitempty"` // ID de MongoDB
+	EventVersion        string               `json:"eventVersion" bson:"eventVersion"`
+	UserIdentity        UserIdentity         `json:"userIdentity" bson:"userIdentity"`
+	EventTime           time.Time            `json:"eventTime" bson:"eventTime"`
+	EventSource         string               `json:"eventSource" bson:"eventSource"`
+	EventName           string               `json:"eventName" bson:"eventName"`
+	AwsRegion           string               `json:"awsRegion" bson:"awsRegion"`
+	SourceIPAddress     string               `json:"sourceIPAddress" bson:"sourceIPAddress"`
+	UserAgent           string               `json:"userAgent" bson:"userAgent"`
+	RequestParameters   RequestParameters    `json:"requestParameters" bson:"requestParameters"`
+	ResponseElements    ResponseElements     `json:"responseElements" bson:"responseElements"`
+	Enrichment          EnrichmentData       `json:"enrichment" bson:"enrichment"` // La información de enriquecimiento
+	AdditionalEventData *AdditionalEventData `json:"additionalEventData,omitempty" bson:"additionalEventData,omitempty"`
+	ErrorCode           string               `json:"errorCode,omitempty" bson:"errorCode,omitempty"`
+	ErrorMessage        string               `json:"errorMessage,omitempty" bson:"errorMessage,omitempty"`
+	VpcEndpointID       string               `json:"vpcEndpointId,omitempty" bson:"vpcEndpointId,omitempty"`
+	RecipientAccountID  string               `json:"recipientAccountId,omitempty" bson:"recipientAccountId,omitempty"`
+	ReadOnly            *bool                `json:"readOnly,omitempty" bson:"readOnly,omitempty"`           // Campo readOnly de CloudTrail, ausente en algunos eventos
+	EventCategory       string               `json:"eventCategory,omitempty" bson:"eventCategory,omitempty"` // Management, Data o Insight
+	Resources           []Resource           `json:"resources,omitempty" bson:"resources,omitempty"`         // Recursos que tocó el evento, con tipo y ARN
+	Risk                *RiskScore           `json:"risk,omitempty" bson:"risk,omitempty"`                   // Puntaje de riesgo calculado en la ingesta
+	Attack              *AttackTags          `json:"attack,omitempty" bson:"attack,omitempty"`               // Técnicas y tácticas de MITRE ATT&CK
+	ThreatIntel         []ThreatMatch        `json:"threatIntel,omitempty" bson:"threatIntel,omitempty"`     // Coincidencias con feeds de inteligencia de amenazas
+	UserAgentInfo       *UserAgentInfo       `json:"userAgentInfo,omitempty" bson:"userAgentInfo,omitempty"` // User agent descompuesto en herramienta, SDK, runtime y sistema operativo
+	Identity            *Identity            `json:"identity,omitempty" bson:"identity,omitempty"`           // userIdentity descompuesta en cuenta, rol, sesión y actor
+	Action              *ActionInfo          `json:"action,omitempty" bson:"action,omitempty"`               // Categoría de la acción (lectura, escritura...) y si es sensible
+	Inventory           *InventoryLabels     `json:"inventory,omitempty" bson:"inventory,omitempty"`         // Etiquetas del inventario de la cuenta y de los recursos
 }
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Niveles de severidad admitidos por las reglas de detección.
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// SeverityRank ordena las severidades de menor a mayor para comparaciones y umbrales.
+var SeverityRank = map[string]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// Estados del flujo de triage de una detección.
+const (
+	FindingStatusOpen          = "open"
+	FindingStatusAcknowledged  = "acknowledged"
+	FindingStatusResolved      = "resolved"
+	FindingStatusFalsePositive = "false-positive"
+)
+
+// FindingStatuses contiene los estados válidos de una detección.
+var FindingStatuses = map[string]bool{
+	FindingStatusOpen:          true,
+	FindingStatusAcknowledged:  true,
+	FindingStatusResolved:      true,
+	FindingStatusFalsePositive: true,
+}
+
+// FindingActor identifica al usuario (según los claims del JWT) que realizó un cambio.
+type FindingActor struct {
+	UserID int    `json:"user_id" bson:"user_id"`
+	Email  string `json:"email" bson:"email"`
+}
+
+// FindingComment es un comentario de triage sobre una detección.
+type FindingComment struct {
+	ID        primitive.ObjectID `json:"id" bson:"id"`
+	Author    FindingActor       `json:"author" bson:"author"`
+	Text      string             `json:"text" bson:"text"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// FindingChange registra quién cambió qué en una detección.
+type FindingChange struct {
+	Field string       `json:"field" bson:"field"` // status, assignee o comment
+	From  string       `json:"from,omitempty" bson:"from,omitempty"`
+	To    string       `json:"to,omitempty" bson:"to,omitempty"`
+	Actor FindingActor `json:"actor" bson:"actor"`
+	At    time.Time    `json:"at" bson:"at"`
+}
+
+// Finding representa una detección generada al evaluar una regla sobre los eventos enriquecidos.
+type Finding struct {
+	ID          primitive.ObjectID   `json:"id,omitempty" bson:"_id,omitempty"`
+	RuleID      string               `json:"rule_id" bson:"rule_id"`
+	RuleName    string               `json:"rule_name" bson:"rule_name"`
+	Description string               `json:"description,omitempty" bson:"description,omitempty"`
+	Severity    string               `json:"severity" bson:"severity"`
+	GroupKey    string               `json:"group_key,omitempty" bson:"group_key,omitempty"` // Valores de agrupación del umbral
+	DedupKey    string               `json:"dedup_key,omitempty" bson:"dedup_key,omitempty"` // Clave de deduplicación de la supresión
+	EventIDs    []primitive.ObjectID `json:"event_ids" bson:"event_ids"`                     // Eventos que dispararon la regla
+	FirstSeen   time.Time            `json:"first_seen" bson:"first_seen"`
+	LastSeen    time.Time            `json:"last_seen" bson:"last_seen"`
+	CreatedAt   time.Time            `json:"created_at" bson:"created_at"`
+
+	// Triage
+	Status    string           `json:"status" bson:"status"`
+	Assignee  string           `json:"assignee,omitempty" bson:"assignee,omitempty"`
+	Comments  []FindingComment `json:"comments,omitempty" bson:"comments,omitempty"`
+	History   []FindingChange  `json:"history,omitempty" bson:"history,omitempty"`
+	UpdatedAt time.Time        `json:"updated_at" bson:"updated_at"`
+
+	// Supresión: coincidencias posteriores que no generaron una nueva detección
+	SuppressedCount  int64      `json:"suppressed_count" bson:"suppressed_count"`
+	LastSuppressedAt *time.Time `json:"last_suppressed_at,omitempty" bson:"last_suppressed_at,omitempty"`
+}
+
+// FindingFilter define los criterios de búsqueda de detecciones. Los campos vacíos se ignoran.
+type FindingFilter struct {
+	RuleID   string     `json:"rule_id,omitempty"`
+	Severity string     `json:"severity,omitempty"`
+	Status   string     `json:"status,omitempty"`
+	Assignee string     `json:"assignee,omitempty"`
+	From     *time.Time `json:"from,omitempty"` // created_at desde
+	To       *time.Time `json:"to,omitempty"`   // created_at hasta
+	Limit    int64      `json:"limit,omitempty"`
+	Skip     int64      `json:"skip,omitempty"`
+}
+
+// FindingStatusPayload es el payload para cambiar el estado de una detección.
+type FindingStatusPayload struct {
+	Status string `json:"status"`
+}
+
+// FindingAssignPayload es el payload para asignar una detección. Un assignee vacío la desasigna.
+type FindingAssignPayload struct {
+	Assignee string `json:"assignee"`
+}
+
+// FindingCommentPayload es el payload para comentar una detección.
+type FindingCommentPayload struct {
+	Text string `json:"text"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import "strings"
+
+// Tipos de principal de la identidad que originó un evento.
+const (
+	PrincipalRoot          = "root"
+	PrincipalUser          = "user"           // Usuario de IAM
+	PrincipalRole          = "role"           // Rol de IAM, cuando el ARN es el del rol y no el de la sesión
+	PrincipalAssumedRole   = "assumed-role"   // Sesión de un rol asumido (STS, SSO, perfiles de instancia, Lambda...)
+	PrincipalFederatedUser = "federated-user" // Sesión de GetFederationToken
+	PrincipalService       = "service"        // Servicio de AWS que actúa por su cuenta (userIdentity.type AWSService)
+	PrincipalAnonymous     = "anonymous"      // Solicitud sin firmar, p. ej. a un bucket público
+	PrincipalUnknown       = "unknown"
+)
+
+// PrincipalTypes contiene los tipos de principal válidos.
+var PrincipalTypes = map[string]bool{
+	PrincipalRoot:          true,
+	PrincipalUser:          true,
+	PrincipalRole:          true,
+	PrincipalAssumedRole:   true,
+	PrincipalFederatedUser: true,
+	PrincipalService:       true,
+	PrincipalAnonymous:     true,
+	PrincipalUnknown:       true,
+}
+
+// Identity es la identidad del evento descompuesta a partir de userIdentity.arn y
+// userIdentity.principalId. Actor es una clave estable del principal que no cambia entre
+// sesiones, para agrupar y filtrar la actividad de un mismo usuario o rol.
+type Identity struct {
+	PrincipalType string `json:"principalType" bson:"principalType"`
+	Actor         string `json:"actor,omitempty" bson:"actor,omitempty"`
+	Partition     string `json:"partition,omitempty" bson:"partition,omitempty"`     // aws, aws-cn o aws-us-gov
+	Account       string `json:"account,omitempty" bson:"account,omitempty"`         // Cuenta dueña del principal
+	Name          string `json:"name,omitempty" bson:"name,omitempty"`               // Usuario, rol o usuario federado
+	Path          string `json:"path,omitempty" bson:"path,omitempty"`               // Ruta de IAM del usuario o rol, p. ej. "/admins/"
+	Role          string `json:"role,omitempty" bson:"role,omitempty"`               // Rol asumido
+	SessionName   string `json:"sessionName,omitempty" bson:"sessionName,omitempty"` // Nombre de la sesión del rol asumido
+	UniqueID      string `json:"uniqueId,omitempty" bson:"uniqueId,omitempty"`       // Identificador único del principal (AIDA..., AROA...)
+	IsRoot        bool   `json:"isRoot,omitempty" bson:"isRoot,omitempty"`
+	IsSSO         bool   `json:"isSSO,omitempty" bson:"isSSO,omitempty"` // Rol creado por IAM Identity Center (AWSReservedSSO_*)
+}
+
+// ARN es un Amazon Resource Name descompuesto: arn:partition:service:region:account:resource.
+type ARN struct {
+	Partition string
+	Service   string
+	Region    string
+	Account   string
+	Resource  string
+}
+
+// ParseARN descompone un ARN. Devuelve false si el valor no tiene el formato de un ARN.
+func ParseARN(value string) (ARN, bool) {
+	parts := strings.SplitN(strings.TrimSpace(value), ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[1] == "" || parts[2] == "" {
+		return ARN{}, false
+	}
+	return ARN{Partition: parts[1], Service: parts[2], Region: parts[3], Account: parts[4], Resource: parts[5]}, true
+}
+
+// ResourceType devuelve el tipo de recurso del ARN: lo que precede al primer "/" o ":" del
+// recurso ("user" en "user/alice", "function" en "function:my-func"). Devuelve "" si el
+// recurso no indica tipo, como en los buckets de S3.
+func (a ARN) ResourceType() string {
+	if i := strings.IndexAny(a.Resource, "/:"); i >= 0 {
+		return a.Resource[:i]
+	}
+	return ""
+}
+
+// ResourceID devuelve el recurso sin su tipo ("alice" en "user/alice").
+func (a ARN) ResourceID() string {
+	if i := strings.IndexAny(a.Resource, "/:"); i >= 0 {
+		return a.Resource[i+1:]
+	}
+	return a.Resource
+}
+
+// String vuelve a componer el ARN.
+func (a ARN) String() string {
+	return strings.Join([]string{"arn", a.Partition, a.Service, a.Region, a.Account, a.Resource}, ":")
+}
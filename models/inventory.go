@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Tipos de elemento del inventario.
+const (
+	InventoryKindAccount  = "account"  // Cuenta de AWS, identificada por su número
+	InventoryKindResource = "resource" // Recurso, identificado por su ARN
+)
+
+// InventoryKinds contiene los tipos de elemento válidos.
+var InventoryKinds = map[string]bool{
+	InventoryKindAccount:  true,
+	InventoryKindResource: true,
+}
+
+// InventorySourceAPI es el origen de los elementos creados con la API de administración.
+const InventorySourceAPI = "api"
+
+// InventoryItem es la metadata de una cuenta o un recurso. Key es el número de cuenta o el ARN
+// del recurso; un ARN terminado en "*" abarca todos los recursos con ese prefijo.
+type InventoryItem struct {
+	ID                 int               `json:"-"`
+	Kind               string            `json:"kind"`
+	Key                string            `json:"key"`
+	Name               string            `json:"name,omitempty"`
+	Environment        string            `json:"environment,omitempty"`         // prod, staging, dev...
+	Team               string            `json:"team,omitempty"`                // Equipo dueño
+	DataClassification string            `json:"data_classification,omitempty"` // public, internal, confidential, restricted...
+	Labels             map[string]string `json:"labels,omitempty"`              // Etiquetas adicionales, p. ej. cost_center
+	Source             string            `json:"source"`                        // Archivo del que se importó o "api"
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// InventoryPayload es el payload para crear o reemplazar un elemento del inventario.
+type InventoryPayload struct {
+	Kind               string            `json:"kind"`
+	Key                string            `json:"key"`
+	Name               string            `json:"name"`
+	Environment        string            `json:"environment"`
+	Team               string            `json:"team"`
+	DataClassification string            `json:"data_classification"`
+	Labels             map[string]string `json:"labels"`
+}
+
+// InventoryLabels son las etiquetas del inventario que corresponden a un evento: las de la
+// cuenta (userIdentity.accountId) y las de los recursos del evento que figuran en el
+// inventario. Environment, Team y DataClassification toman el valor del primer recurso que lo
+// define y, si ninguno lo hace, el de la cuenta.
+type InventoryLabels struct {
+	AccountName        string                    `json:"accountName,omitempty" bson:"accountName,omitempty"`
+	Environment        string                    `json:"environment,omitempty" bson:"environment,omitempty"`
+	Team               string                    `json:"team,omitempty" bson:"team,omitempty"`
+	DataClassification string                    `json:"dataClassification,omitempty" bson:"dataClassification,omitempty"`
+	Labels             map[string]string         `json:"labels,omitempty" bson:"labels,omitempty"`
+	Resources          []InventoryResourceLabels `json:"resources,omitempty" bson:"resources,omitempty"`
+}
+
+// InventoryResourceLabels son las etiquetas de un recurso del evento.
+type InventoryResourceLabels struct {
+	ARN                string `json:"arn" bson:"arn"`
+	Name               string `json:"name,omitempty" bson:"name,omitempty"`
+	Environment        string `json:"environment,omitempty" bson:"environment,omitempty"`
+	Team               string `json:"team,omitempty" bson:"team,omitempty"`
+	DataClassification string `json:"dataClassification,omitempty" bson:"dataClassification,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resultados posibles de una entrega de notificación.
+const (
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+	DeliveryStatusDropped   = "dropped"   // Descartada sin intentos porque la cola estaba llena
+	DeliveryStatusAbandoned = "abandoned" // Pendiente en la cola al detener el despachador
+)
+
+// DeliveryStatuses contiene los resultados válidos de una entrega.
+var DeliveryStatuses = map[string]bool{
+	DeliveryStatusDelivered: true,
+	DeliveryStatusFailed:    true,
+	DeliveryStatusDropped:   true,
+	DeliveryStatusAbandoned: true,
+}
+
+// NotificationDelivery es el registro de una entrega de notificación a un canal,
+// incluyendo los reintentos realizados.
+type NotificationDelivery struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	FindingID   primitive.ObjectID `json:"finding_id" bson:"finding_id"`
+	RuleID      string             `json:"rule_id" bson:"rule_id"`
+	Channel     string             `json:"channel" bson:"channel"`
+	ChannelType string             `json:"channel_type" bson:"channel_type"`
+	Status      string             `json:"status" bson:"status"`
+	Attempts    int                `json:"attempts" bson:"attempts"`
+	StatusCode  int                `json:"status_code,omitempty" bson:"status_code,omitempty"` // Último código HTTP recibido
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`             // Último error
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	CompletedAt time.Time          `json:"completed_at" bson:"completed_at"`
+}
+
+// DeliveryFilter define los criterios de búsqueda del registro de entregas.
+type DeliveryFilter struct {
+	FindingID string `json:"finding_id,omitempty"`
+	Channel   string `json:"channel,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Limit     int64  `json:"limit,omitempty"`
+	Skip      int64  `json:"skip,omitempty"`
+}
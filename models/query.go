@@ -0,0 +1,131 @@
+package models
+
+import "time"
+
+// EventFilter agrupa los criterios de búsqueda sobre los eventos enriquecidos.
+// Los campos vacíos no se aplican como filtro.
+type EventFilter struct {
+	EventName          string     `json:"eventName,omitempty"`
+	EventSource        string     `json:"eventSource,omitempty"`
+	AwsRegion          string     `json:"awsRegion,omitempty"`
+	SourceIPAddress    string     `json:"sourceIPAddress,omitempty"`
+	UserName           string     `json:"userName,omitempty"`
+	AccountID          string     `json:"accountId,omitempty"`
+	Actor              string     `json:"actor,omitempty"`              // identity.actor
+	IdentityAccount    string     `json:"identityAccount,omitempty"`    // identity.account
+	IdentityRole       string     `json:"identityRole,omitempty"`       // identity.role
+	SessionName        string     `json:"sessionName,omitempty"`        // identity.sessionName
+	PrincipalType      string     `json:"principalType,omitempty"`      // identity.principalType
+	IsRoot             *bool      `json:"isRoot,omitempty"`             // identity.isRoot; nil no filtra
+	AccountName        string     `json:"accountName,omitempty"`        // inventory.accountName
+	Environment        string     `json:"environment,omitempty"`        // inventory.environment
+	Team               string     `json:"team,omitempty"`               // inventory.team
+	DataClassification string     `json:"dataClassification,omitempty"` // inventory.dataClassification
+	ActionCategory     string     `json:"actionCategory,omitempty"`     // action.category
+	ReadOnly           *bool      `json:"readOnly,omitempty"`           // action.readOnly; false deja solo las acciones que modifican
+	Sensitive          *bool      `json:"sensitive,omitempty"`          // action.sensitive; nil no filtra
+	ResourceARN        string     `json:"resourceArn,omitempty"`        // resources.arn
+	ResourceType       string     `json:"resourceType,omitempty"`       // resources.type, p. ej. AWS::S3::Bucket
+	ResourceID         string     `json:"resourceId,omitempty"`         // resources.id
+	Country            string     `json:"country,omitempty"`
+	ASN                string     `json:"asn,omitempty"`         // enrichment.asn; admite "AS16509" o "16509"
+	ASOrg              string     `json:"asOrg,omitempty"`       // enrichment.asOrg
+	UATool             string     `json:"uaTool,omitempty"`      // userAgentInfo.tool, p. ej. terraform
+	UACategory         string     `json:"uaCategory,omitempty"`  // userAgentInfo.category
+	UASDK              string     `json:"uaSDK,omitempty"`       // userAgentInfo.sdk, p. ej. botocore
+	NetworkType        string     `json:"networkType,omitempty"` // enrichment.networkType
+	Site               string     `json:"site,omitempty"`        // enrichment.site
+	Hostname           string     `json:"hostname,omitempty"`    // enrichment.hostname; "*.example.com" busca por sufijo
+	IsTor              *bool      `json:"isTor,omitempty"`       // enrichment.isTor; nil no filtra
+	IsVPN              *bool      `json:"isVPN,omitempty"`       // enrichment.isVPN; nil no filtra
+	IsHosting          *bool      `json:"isHosting,omitempty"`   // enrichment.isHosting; nil no filtra
+	MinRisk            int64      `json:"minRisk,omitempty"`     // Puntaje de riesgo mínimo (risk.score)
+	SortBy             string     `json:"sortBy,omitempty"`      // eventTime (por defecto) o risk
+	From               *time.Time `json:"from,omitempty"`
+	To                 *time.Time `json:"to,omitempty"`
+	Limit              int64      `json:"limit,omitempty"`
+	Skip               int64      `json:"skip,omitempty"`
+}
+
+// Criterios de ordenamiento de las búsquedas de eventos (siempre descendente).
+const (
+	EventSortEventTime = "eventTime"
+	EventSortRisk      = "risk"
+)
+
+// EventSortFields contiene los criterios de ordenamiento válidos.
+var EventSortFields = map[string]bool{
+	EventSortEventTime: true,
+	EventSortRisk:      true,
+}
+
+// IsEmpty indica si el filtro no tiene ningún criterio de búsqueda ni paginación.
+func (f *EventFilter) IsEmpty() bool {
+	return f == nil || *f == EventFilter{}
+}
+
+// EventProjection define la forma de la respuesta de las consultas de eventos.
+// Fields admite rutas anidadas (por ejemplo "userIdentity.arn") y Compact elimina
+// las estructuras anidadas vacías del resultado.
+type EventProjection struct {
+	Fields  []string `json:"fields,omitempty"`
+	Compact bool     `json:"compact,omitempty"`
+}
+
+// IsEmpty indica si la proyección devuelve los documentos completos sin modificar.
+func (p *EventProjection) IsEmpty() bool {
+	return p == nil || (len(p.Fields) == 0 && !p.Compact)
+}
+
+// AggregateBucket es un grupo de una agregación: el valor del campo y la cantidad de eventos.
+type AggregateBucket struct {
+	Key   string `json:"key" bson:"_id"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+// AggregationField describe un campo por el que se pueden agrupar los eventos.
+type AggregationField struct {
+	Path   string // Ruta BSON del campo en EnrichedEventRecord
+	Array  bool   // El campo es una lista y debe desenrollarse antes de agrupar
+	Unwind string // Lista a desenrollar cuando Path es un campo de sus elementos; vacío usa Path
+}
+
+// AggregationFields son los campos admitidos para agregaciones, indexados por su nombre público.
+var AggregationFields = map[string]AggregationField{
+	"eventName":          {Path: "eventName"},
+	"eventSource":        {Path: "eventSource"},
+	"awsRegion":          {Path: "awsRegion"},
+	"sourceIPAddress":    {Path: "sourceIPAddress"},
+	"userName":           {Path: "userIdentity.userName"},
+	"accountId":          {Path: "userIdentity.accountId"},
+	"actor":              {Path: "identity.actor"},
+	"identityAccount":    {Path: "identity.account"},
+	"identityRole":       {Path: "identity.role"},
+	"sessionName":        {Path: "identity.sessionName"},
+	"principalType":      {Path: "identity.principalType"},
+	"accountName":        {Path: "inventory.accountName"},
+	"environment":        {Path: "inventory.environment"},
+	"team":               {Path: "inventory.team"},
+	"dataClassification": {Path: "inventory.dataClassification"},
+	"country":            {Path: "enrichment.country"},
+	"region":             {Path: "enrichment.region"},
+	"awsService":         {Path: "enrichment.aws.service"},
+	"networkType":        {Path: "enrichment.networkType"},
+	"asn":                {Path: "enrichment.asn"},
+	"uaTool":             {Path: "userAgentInfo.tool"},
+	"uaVersion":          {Path: "userAgentInfo.version"},
+	"uaCategory":         {Path: "userAgentInfo.category"},
+	"uaSDK":              {Path: "userAgentInfo.sdk"},
+	"uaSDKVersion":       {Path: "userAgentInfo.sdkVersion"},
+	"uaRuntime":          {Path: "userAgentInfo.runtime"},
+	"uaOS":               {Path: "userAgentInfo.os"},
+	"asOrg":              {Path: "enrichment.asOrg"},
+	"site":               {Path: "enrichment.site"},
+	"hostname":           {Path: "enrichment.hostname"},
+	"actionCategory":     {Path: "action.category"},
+	"actionReason":       {Path: "action.reason"},
+	"resourceType":       {Path: "resources.type", Array: true, Unwind: "resources"},
+	"resourceArn":        {Path: "resources.arn", Array: true, Unwind: "resources"},
+	"tactic":             {Path: "attack.tactics", Array: true},
+	"technique":          {Path: "attack.techniques", Array: true},
+}
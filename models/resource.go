@@ -0,0 +1,12 @@
+package models
+
+// Resource es un recurso de AWS que tocó un evento. Type usa los nombres de CloudFormation
+// ("AWS::S3::Bucket", "AWS::IAM::Role"). ARN está vacío cuando el evento no trae datos
+// suficientes para componerlo; en ese caso ID identifica al recurso dentro de su tipo.
+// Coincide con el formato de la lista resources que CloudTrail incluye en algunos eventos.
+type Resource struct {
+	Type      string `json:"type" bson:"type"`
+	ARN       string `json:"arn,omitempty" bson:"arn,omitempty"`
+	ID        string `json:"id,omitempty" bson:"id,omitempty"`               // Nombre o identificador del recurso (bucket, i-..., sg-...)
+	AccountID string `json:"accountId,omitempty" bson:"accountId,omitempty"` // Cuenta dueña del recurso
+}
@@ -0,0 +1,37 @@
+package models
+
+// Factores que contribuyen al puntaje de riesgo de un evento.
+const (
+	RiskFactorSensitiveAPI = "sensitive_api" // API de IAM, KMS, Organizations...
+	RiskFactorRootIdentity = "root_identity"
+	RiskFactorMissingMFA   = "missing_mfa"
+	RiskFactorErrorCode    = "error_code" // AccessDenied, UnauthorizedOperation...
+	RiskFactorUnusualGeo   = "unusual_geo"
+	RiskFactorBadIP        = "bad_ip" // Tor o IP de mala reputación conocida
+)
+
+// MaxRiskScore es el puntaje máximo de un evento; la suma de los pesos se trunca a este valor.
+const MaxRiskScore = 100
+
+// DefaultRiskWeights son los pesos por defecto de cada factor. Un peso 0 deshabilita el factor.
+var DefaultRiskWeights = map[string]int{
+	RiskFactorSensitiveAPI: 20,
+	RiskFactorRootIdentity: 30,
+	RiskFactorMissingMFA:   15,
+	RiskFactorErrorCode:    15,
+	RiskFactorUnusualGeo:   25,
+	RiskFactorBadIP:        40,
+}
+
+// RiskFactor es un factor que contribuyó al puntaje de riesgo.
+type RiskFactor struct {
+	Name   string `json:"name" bson:"name"`
+	Weight int    `json:"weight" bson:"weight"`
+	Detail string `json:"detail,omitempty" bson:"detail,omitempty"`
+}
+
+// RiskScore es el puntaje de riesgo (0-100) de un evento y los factores que lo componen.
+type RiskScore struct {
+	Score   int          `json:"score" bson:"score"`
+	Factors []RiskFactor `json:"factors" bson:"factors"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// SavedSearch representa una búsqueda de eventos guardada por un usuario,
+// con una programación opcional en formato cron para ejecutarla en segundo plano.
+type SavedSearch struct {
+	ID          int         `json:"-"`
+	UUID        string      `json:"uuid"`
+	UserID      int         `json:"-"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Filter      EventFilter `json:"filter"`
+	Schedule    string      `json:"schedule,omitempty"` // Expresión cron estándar (5 campos) o descriptores como @hourly
+	LastRunAt   *time.Time  `json:"last_run_at,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// SavedSearchPayload es la estructura para crear o actualizar una búsqueda guardada.
+type SavedSearchPayload struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Filter      EventFilter `json:"filter"`
+	Schedule    string      `json:"schedule"`
+}
+
+// SavedSearchResult es la instantánea de una ejecución de una búsqueda guardada.
+// Added y Removed contienen la diferencia respecto a la ejecución anterior.
+type SavedSearchResult struct {
+	ID            int       `json:"id"`
+	SavedSearchID int       `json:"-"`
+	RunAt         time.Time `json:"run_at"`
+	Total         int       `json:"total"`
+	EventIDs      []string  `json:"event_ids"`
+	Added         []string  `json:"added"`
+	Removed       []string  `json:"removed"`
+}
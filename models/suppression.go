@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SuppressionCondition exige que el campo del evento (ruta JSON) tenga el valor indicado.
+type SuppressionCondition struct {
+	Field string `json:"field" bson:"field"`
+	Value string `json:"value" bson:"value"`
+}
+
+// Suppression es una supresión temporal creada por un analista. Mientras está vigente, las
+// detecciones de la regla (o de todas, si RuleID está vacío) cuyo evento cumple todas las
+// condiciones no se generan y solo incrementan los contadores.
+type Suppression struct {
+	ID               primitive.ObjectID     `json:"id,omitempty" bson:"_id,omitempty"`
+	RuleID           string                 `json:"rule_id,omitempty" bson:"rule_id,omitempty"`
+	Conditions       []SuppressionCondition `json:"conditions,omitempty" bson:"conditions,omitempty"`
+	Reason           string                 `json:"reason" bson:"reason"`
+	CreatedBy        FindingActor           `json:"created_by" bson:"created_by"`
+	CreatedAt        time.Time              `json:"created_at" bson:"created_at"`
+	ExpiresAt        time.Time              `json:"expires_at" bson:"expires_at"`
+	SuppressedCount  int64                  `json:"suppressed_count" bson:"suppressed_count"`
+	LastSuppressedAt *time.Time             `json:"last_suppressed_at,omitempty" bson:"last_suppressed_at,omitempty"`
+}
+
+// Active indica si la supresión está vigente en el instante indicado.
+func (s *Suppression) Active(at time.Time) bool {
+	return at.Before(s.ExpiresAt)
+}
+
+// SuppressionPayload es el payload para crear una supresión. Duration usa el formato de
+// time.ParseDuration (por ejemplo "4h" o "72h").
+type SuppressionPayload struct {
+	RuleID     string                 `json:"rule_id"`
+	Conditions []SuppressionCondition `json:"conditions"`
+	Reason     string                 `json:"reason"`
+	Duration   string                 `json:"duration"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// Tipos de indicador de inteligencia de amenazas.
+const (
+	IndicatorIP     = "ip"
+	IndicatorCIDR   = "cidr"
+	IndicatorDomain = "domain"
+)
+
+// ThreatMatch es la coincidencia de un valor del evento con un indicador de un feed de
+// inteligencia de amenazas.
+type ThreatMatch struct {
+	Indicator   string `json:"indicator" bson:"indicator"`                         // IP, CIDR o dominio del feed
+	Type        string `json:"type" bson:"type"`                                   // ip, cidr o domain
+	Field       string `json:"field" bson:"field"`                                 // Campo del evento que coincidió, p. ej. sourceIPAddress
+	Value       string `json:"value" bson:"value"`                                 // Valor del evento que coincidió
+	Feed        string `json:"feed" bson:"feed"`                                   // Nombre del feed
+	Confidence  int    `json:"confidence" bson:"confidence"`                       // 0-100
+	Description string `json:"description,omitempty" bson:"description,omitempty"` // Descripción del indicador, si el feed la incluye
+}
@@ -0,0 +1,34 @@
+package models
+
+// Categorías del cliente que originó un evento, según su user agent.
+const (
+	UserAgentConsole     = "console"      // Consola de AWS o navegador
+	UserAgentCLI         = "cli"          // AWS CLI, ec2-api-tools, AWS Tools for PowerShell...
+	UserAgentSDK         = "sdk"          // SDK de AWS usado directamente (boto3, aws-sdk-go...)
+	UserAgentIaC         = "iac"          // Infraestructura como código: Terraform, CloudFormation, CDK, Pulumi...
+	UserAgentAWSInternal = "aws-internal" // Llamadas internas de AWS o de un servicio en nombre del usuario
+	UserAgentUnknown     = "unknown"
+)
+
+// UserAgentCategories contiene las categorías válidas.
+var UserAgentCategories = map[string]bool{
+	UserAgentConsole:     true,
+	UserAgentCLI:         true,
+	UserAgentSDK:         true,
+	UserAgentIaC:         true,
+	UserAgentAWSInternal: true,
+	UserAgentUnknown:     true,
+}
+
+// UserAgentInfo es el user agent del evento descompuesto en herramienta, SDK, runtime y sistema
+// operativo. Los nombres se guardan en minúsculas para poder filtrarlos.
+type UserAgentInfo struct {
+	Category       string `json:"category" bson:"category"`
+	Tool           string `json:"tool,omitempty" bson:"tool,omitempty"`                     // p. ej. terraform, aws-cli, boto3, console
+	Version        string `json:"version,omitempty" bson:"version,omitempty"`               // Versión de la herramienta
+	SDK            string `json:"sdk,omitempty" bson:"sdk,omitempty"`                       // SDK subyacente, p. ej. aws-sdk-go-v2 o botocore
+	SDKVersion     string `json:"sdkVersion,omitempty" bson:"sdkVersion,omitempty"`         // Versión del SDK
+	Runtime        string `json:"runtime,omitempty" bson:"runtime,omitempty"`               // Lenguaje: python, go, java, nodejs, ruby, .net...
+	RuntimeVersion string `json:"runtimeVersion,omitempty" bson:"runtimeVersion,omitempty"` // Versión del lenguaje
+	OS             string `json:"os,omitempty" bson:"os,omitempty"`                         // linux, macos, windows...
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/repository"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidBaselineQuery se devuelve cuando la consulta de línea base no es válida.
+var ErrInvalidBaselineQuery = errors.New("consulta de línea base inválida")
+
+// BaselineRuleID es el id de regla de las detecciones de comportamiento nunca visto.
+const BaselineRuleID = "baseline-first-seen"
+
+// Valores por defecto de las líneas base.
+const (
+	defaultBaselineWindow   = 90 * 24 * time.Hour
+	defaultBaselineLearning = 7 * 24 * time.Hour
+)
+
+// defaultBaselineAlertDimensions son las dimensiones que generan detecciones si no se configuran otras.
+var defaultBaselineAlertDimensions = []string{models.BaselineDimensionCountry, models.BaselineDimensionASN, models.BaselineDimensionEventName}
+
+// BaselineOptions configura las líneas base. Los valores en cero toman los valores por defecto.
+type BaselineOptions struct {
+	Window          time.Duration // Ventana móvil: un valor no observado durante este tiempo se olvida
+	LearningPeriod  time.Duration // Tiempo de observación de un principal antes de alertar
+	AlertDimensions []string      // Dimensiones cuyos valores nuevos generan una detección
+	Severity        string
+}
+
+// BaselineService define la interfaz de consulta de las líneas base de comportamiento.
+type BaselineService interface {
+	GetBaseline(ctx context.Context, principal, dimension string) (*models.BaselineProfile, error)
+}
+
+// DefaultBaselineService es la implementación predeterminada de BaselineService. Registra, por
+// principal (userIdentity.arn), los países, ASN, user agents, regiones y eventNames observados y
+// genera una detección la primera vez que aparece un valor en una dimensión de alerta.
+type DefaultBaselineService struct {
+	repo    repository.BaselineRepository
+	options BaselineOptions
+	alerts  map[string]bool
+}
+
+// NewDefaultBaselineService crea una nueva instancia de DefaultBaselineService.
+func NewDefaultBaselineService(repo repository.BaselineRepository, options BaselineOptions) (*DefaultBaselineService, error) {
+	if options.Window <= 0 {
+		options.Window = defaultBaselineWindow
+	}
+	if options.LearningPeriod <= 0 {
+		options.LearningPeriod = defaultBaselineLearning
+	}
+	if len(options.AlertDimensions) == 0 {
+		options.AlertDimensions = defaultBaselineAlertDimensions
+	}
+	if options.Severity == "" {
+		options.Severity = models.SeverityMedium
+	}
+	options.Severity = strings.ToLower(options.Severity)
+	if _, ok := models.SeverityRank[options.Severity]; !ok {
+		return nil, fmt.Errorf("severidad '%s' no válida para la línea base", options.Severity)
+	}
+
+	alerts := make(map[string]bool, len(options.AlertDimensions))
+	for _, dimension := range options.AlertDimensions {
+		dimension = strings.TrimSpace(dimension)
+		if !models.BaselineDimensions[dimension] {
+			return nil, fmt.Errorf("dimensión de línea base '%s' no válida", dimension)
+		}
+		alerts[dimension] = true
+	}
+
+	return &DefaultBaselineService{
+		repo:    repo,
+		options: options,
+		alerts:  alerts,
+	}, nil
+}
+
+// ObserveEvent actualiza la línea base del principal. Se usa como EventObserver cuando el
+// motor de detección está deshabilitado y las anomalías no se convierten en detecciones.
+func (s *DefaultBaselineService) ObserveEvent(ctx context.Context, record *models.EnrichedEventRecord) {
+	s.observe(ctx, record)
+}
+
+// Findings actualiza la línea base del principal y devuelve una detección si el evento
+// introduce valores nunca vistos en las dimensiones de alerta. Implementa FindingSource.
+func (s *DefaultBaselineService) Findings(ctx context.Context, record *models.EnrichedEventRecord) []*models.Finding {
+	firstSeen := s.observe(ctx, record)
+	if len(firstSeen) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	principal := record.UserIdentity.Arn
+	return []*models.Finding{{
+		RuleID:      BaselineRuleID,
+		RuleName:    "Comportamiento nunca visto",
+		Description: fmt.Sprintf("Primera observación para %s en su línea base: %s.", principal, strings.Join(firstSeen, ", ")),
+		Severity:    s.options.Severity,
+		GroupKey:    "userIdentity.arn=" + principal,
+		EventIDs:    []primitive.ObjectID{record.ID},
+		FirstSeen:   record.EventTime,
+		LastSeen:    record.EventTime,
+		CreatedAt:   now,
+		Status:      models.FindingStatusOpen,
+		UpdatedAt:   now,
+	}}
+}
+
+// observe registra cada dimensión del evento y devuelve los valores nuevos de las dimensiones
+// de alerta. Mientras el principal está en período de aprendizaje no se devuelve ninguno. La
+// ventana de las entradas corre desde la hora del evento o, para eventos del pasado
+// (reprocesados o cargados tarde), desde ahora: de lo contrario se guardarían ya vencidas y
+// el mismo valor volvería a ser nuevo en cada reproceso.
+func (s *DefaultBaselineService) observe(ctx context.Context, record *models.EnrichedEventRecord) []string {
+	principal := record.UserIdentity.Arn
+	if principal == "" {
+		return nil
+	}
+	at := record.EventTime
+	expiresAt := at
+	if now := time.Now(); now.After(expiresAt) {
+		expiresAt = now
+	}
+	expiresAt = expiresAt.Add(s.options.Window)
+
+	observations := []models.BaselineObservation{{Dimension: models.BaselineDimensionPrincipal}}
+	for _, dimension := range baselineValues(record) {
+		if dimension.value != "" {
+			observations = append(observations, models.BaselineObservation{Dimension: dimension.name, Value: dimension.value})
+		}
+	}
+	previous, err := s.repo.ObserveBaselines(ctx, principal, observations, at, expiresAt)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al actualizar la línea base de %s: %v", principal, err)
+		return nil
+	}
+
+	since := previous[observations[0]]
+	if since == nil || since.FirstSeen.After(at.Add(-s.options.LearningPeriod)) {
+		return nil
+	}
+	var firstSeen []string
+	for _, observation := range observations[1:] {
+		if previous[observation] == nil && s.alerts[observation.Dimension] {
+			firstSeen = append(firstSeen, observation.Dimension+"="+observation.Value)
+		}
+	}
+	return firstSeen
+}
+
+// IsUnusual indica si el valor nunca se observó para el principal en la dimensión indicada.
+// Devuelve false mientras el principal está en período de aprendizaje o si la consulta falla.
+func (s *DefaultBaselineService) IsUnusual(ctx context.Context, principal, dimension, value string, at time.Time) bool {
+	if principal == "" || value == "" {
+		return false
+	}
+	since, err := s.repo.GetBaselineEntry(ctx, principal, models.BaselineDimensionPrincipal, "")
+	if err != nil || since == nil || since.FirstSeen.After(at.Add(-s.options.LearningPeriod)) {
+		return false
+	}
+	entry, err := s.repo.GetBaselineEntry(ctx, principal, dimension, value)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al consultar la línea base de %s (%s): %v", principal, dimension, err)
+		return false
+	}
+	return entry == nil
+}
+
+type baselineValue struct {
+	name  string
+	value string
+}
+
+// baselineValues extrae del evento el valor de cada dimensión de la línea base.
+func baselineValues(record *models.EnrichedEventRecord) []baselineValue {
+	return []baselineValue{
+		{models.BaselineDimensionCountry, record.Enrichment.Country},
+		{models.BaselineDimensionASN, record.Enrichment.ASN},
+		{models.BaselineDimensionUserAgent, record.UserAgent},
+		{models.BaselineDimensionRegion, record.AwsRegion},
+		{models.BaselineDimensionEventName, record.EventName},
+	}
+}
+
+// GetBaseline devuelve la línea base vigente del principal agrupada por dimensión.
+func (s *DefaultBaselineService) GetBaseline(ctx context.Context, principal, dimension string) (*models.BaselineProfile, error) {
+	if strings.TrimSpace(principal) == "" {
+		return nil, fmt.Errorf("%w: el parámetro 'principal' es requerido", ErrInvalidBaselineQuery)
+	}
+	if dimension != "" && !models.BaselineDimensions[dimension] {
+		return nil, fmt.Errorf("%w: dimensión '%s' no válida", ErrInvalidBaselineQuery, dimension)
+	}
+
+	entries, err := s.repo.ListBaselineEntries(ctx, principal, dimension)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al obtener la línea base de %s: %v", principal, err)
+		return nil, fmt.Errorf("error al obtener la línea base: %w", err)
+	}
+
+	profile := &models.BaselineProfile{
+		Principal:  principal,
+		Dimensions: map[string][]*models.BaselineEntry{},
+	}
+	for _, entry := range entries {
+		if entry.Dimension == models.BaselineDimensionPrincipal {
+			firstSeen := entry.FirstSeen
+			profile.FirstSeen = &firstSeen
+			continue
+		}
+		profile.Dimensions[entry.Dimension] = append(profile.Dimensions[entry.Dimension], entry)
+	}
+	return profile, nil
+}
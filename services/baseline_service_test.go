@@ -0,0 +1,97 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"testing"
+	"time"
+)
+
+// memoryBaselineRepo es un BaselineRepository en memoria. Igual que el índice TTL de MongoDB,
+// trata como inexistentes las entradas cuyo expires_at ya pasó.
+type memoryBaselineRepo struct {
+	entries map[models.BaselineObservation]*models.BaselineEntry
+}
+
+func newMemoryBaselineRepo() *memoryBaselineRepo {
+	return &memoryBaselineRepo{entries: map[models.BaselineObservation]*models.BaselineEntry{}}
+}
+
+func (r *memoryBaselineRepo) ObserveBaselines(ctx context.Context, principal string, observations []models.BaselineObservation, at, expiresAt time.Time) (map[models.BaselineObservation]*models.BaselineEntry, error) {
+	live := time.Now()
+	if at.After(live) {
+		live = at
+	}
+	previous := map[models.BaselineObservation]*models.BaselineEntry{}
+	for _, observation := range observations {
+		entry := r.entries[observation]
+		if entry != nil && entry.ExpiresAt.After(live) {
+			before := *entry
+			previous[observation] = &before
+		} else {
+			entry = &models.BaselineEntry{Principal: principal, Dimension: observation.Dimension, Value: observation.Value, FirstSeen: at}
+			r.entries[observation] = entry
+		}
+		if at.Before(entry.FirstSeen) {
+			entry.FirstSeen = at
+		}
+		if at.After(entry.LastSeen) {
+			entry.LastSeen = at
+		}
+		if expiresAt.After(entry.ExpiresAt) {
+			entry.ExpiresAt = expiresAt
+		}
+		entry.Count++
+	}
+	return previous, nil
+}
+
+func (r *memoryBaselineRepo) ListBaselineEntries(ctx context.Context, principal, dimension string) ([]*models.BaselineEntry, error) {
+	return nil, nil
+}
+
+func (r *memoryBaselineRepo) GetBaselineEntry(ctx context.Context, principal, dimension, value string) (*models.BaselineEntry, error) {
+	return nil, nil
+}
+
+func baselineRecord(country string, at time.Time) *models.EnrichedEventRecord {
+	record := &models.EnrichedEventRecord{EventName: "ListBuckets"}
+	record.UserIdentity.Arn = "arn:aws:iam::111111111111:user/alice"
+	record.Enrichment.Country = country
+	record.EventTime = at
+	return record
+}
+
+// Los eventos del pasado no se guardan ya vencidos: al reprocesarlos, el valor visto en la
+// primera pasada no vuelve a generar una detección.
+func TestBaselineBackfilledEvents(t *testing.T) {
+	repo := newMemoryBaselineRepo()
+	service, err := NewDefaultBaselineService(repo, BaselineOptions{Window: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("NewDefaultBaselineService: %v", err)
+	}
+	ctx := context.Background()
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if findings := service.Findings(ctx, baselineRecord("AR", base)); len(findings) != 0 {
+		t.Fatalf("se generó una detección durante el período de aprendizaje")
+	}
+	later := baselineRecord("BR", base.Add(10*24*time.Hour))
+	if findings := service.Findings(ctx, later); len(findings) != 1 {
+		t.Fatalf("se generaron %d detecciones para el primer evento desde BR, se esperaba 1", len(findings))
+	}
+
+	entry := repo.entries[models.BaselineObservation{Dimension: models.BaselineDimensionCountry, Value: "BR"}]
+	if !entry.ExpiresAt.After(time.Now()) {
+		t.Errorf("la entrada del evento histórico se guardó vencida (expires_at %v)", entry.ExpiresAt)
+	}
+	if !entry.LastSeen.Equal(later.EventTime) {
+		t.Errorf("last_seen = %v, se esperaba la hora del evento %v", entry.LastSeen, later.EventTime)
+	}
+
+	for i := 0; i < 2; i++ {
+		if findings := service.Findings(ctx, baselineRecord("BR", later.EventTime)); len(findings) != 0 {
+			t.Errorf("reproceso %d: el país ya observado generó una detección", i+1)
+		}
+	}
+}
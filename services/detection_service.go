@@ -0,0 +1,307 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/detection"
+	"cloudtrail-enrichment-api-golang/internal/match"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/repository"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FindingObserver recibe cada detección después de ser guardada.
+type FindingObserver interface {
+	ObserveFinding(ctx context.Context, finding *models.Finding)
+}
+
+// FindingSource genera detecciones adicionales a partir de cada evento, por ejemplo las
+// anomalías frente a la línea base del principal.
+type FindingSource interface {
+	Findings(ctx context.Context, record *models.EnrichedEventRecord) []*models.Finding
+}
+
+// DetectionService evalúa las reglas de detección y de correlación sobre cada evento
+// ingerido y persiste las detecciones resultantes. Implementa EventObserver.
+type DetectionService struct {
+	engine       *detection.Engine
+	correlations *detection.CorrelationEngine
+	repo         repository.FindingRepository
+	stateRepo    repository.CorrelationStateRepository
+	suppressRepo repository.SuppressionRepository
+	travel       *detection.TravelDetector // Opcional: detección de viaje imposible
+	sources      []FindingSource
+	observers    []FindingObserver
+
+	mu           sync.RWMutex
+	suppressions map[string]*detection.SuppressionConfig // Supresión configurada por id de regla
+}
+
+// NewDetectionService crea una nueva instancia de DetectionService. El estado de las
+// correlaciones se persiste en stateRepo y las supresiones de analistas se leen de suppressRepo.
+func NewDetectionService(engine *detection.Engine, correlations *detection.CorrelationEngine, repo repository.FindingRepository, stateRepo repository.CorrelationStateRepository, suppressRepo repository.SuppressionRepository) *DetectionService {
+	return &DetectionService{
+		engine:       engine,
+		correlations: correlations,
+		repo:         repo,
+		stateRepo:    stateRepo,
+		suppressRepo: suppressRepo,
+		suppressions: map[string]*detection.SuppressionConfig{},
+	}
+}
+
+// ReloadRules vuelve a cargar las reglas YAML desde rulesPath y, si se indica sigmaPath,
+// importa además las reglas Sigma de CloudTrail. Las reglas Sigma no soportadas se
+// registran en el log y no impiden la carga del resto.
+func (s *DetectionService) ReloadRules(rulesPath, sigmaPath string) error {
+	rules, err := detection.LoadRules(rulesPath)
+	if err != nil {
+		return fmt.Errorf("error al recargar reglas de detección: %w", err)
+	}
+	logger.InfoLog.Printf("%d reglas de detección cargadas desde %s.", len(rules), rulesPath)
+
+	if sigmaPath != "" {
+		sigmaRules, report, err := detection.LoadSigmaRules(sigmaPath)
+		if err != nil {
+			return fmt.Errorf("error al importar reglas Sigma: %w", err)
+		}
+
+		ids := make(map[string]bool, len(rules))
+		for _, rule := range rules {
+			ids[rule.ID] = true
+		}
+		for _, rule := range sigmaRules {
+			if ids[rule.ID] {
+				return fmt.Errorf("error al importar reglas Sigma: id de regla duplicado '%s' en %s", rule.ID, rule.Source)
+			}
+			ids[rule.ID] = true
+			rules = append(rules, rule)
+		}
+
+		logger.InfoLog.Printf("%d reglas Sigma importadas desde %s, %d no soportadas.", len(sigmaRules), sigmaPath, len(report.Unsupported))
+		for _, skipped := range report.Unsupported {
+			logger.InfoLog.Printf("Regla Sigma no soportada %s (%s): %s", skipped.File, skipped.Title, skipped.Reason)
+		}
+	}
+
+	correlations, err := detection.LoadCorrelationRules(rulesPath)
+	if err != nil {
+		return fmt.Errorf("error al recargar reglas de correlación: %w", err)
+	}
+	ids := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		ids[rule.ID] = true
+	}
+	for _, rule := range correlations {
+		if ids[rule.ID] {
+			return fmt.Errorf("error al recargar reglas de correlación: id de regla duplicado '%s' en %s", rule.ID, rule.Source)
+		}
+	}
+	logger.InfoLog.Printf("%d reglas de correlación cargadas desde %s.", len(correlations), rulesPath)
+
+	suppressions := map[string]*detection.SuppressionConfig{}
+	for _, rule := range rules {
+		if rule.Suppression != nil {
+			suppressions[rule.ID] = rule.Suppression
+		}
+	}
+	for _, rule := range correlations {
+		if rule.Suppression != nil {
+			suppressions[rule.ID] = rule.Suppression
+		}
+	}
+	s.mu.Lock()
+	s.suppressions = suppressions
+	s.mu.Unlock()
+
+	s.engine.SetRules(rules)
+	for _, key := range s.correlations.SetRules(correlations) {
+		if err := s.stateRepo.DeleteCorrelationState(context.Background(), key); err != nil {
+			logger.ErrorLog.Printf("Error al descartar el estado de correlación %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// AddFindingObserver registra un observador que recibe cada detección guardada.
+func (s *DetectionService) AddFindingObserver(observer FindingObserver) {
+	s.observers = append(s.observers, observer)
+}
+
+// AddFindingSource registra una fuente de detecciones que se evalúa sobre cada evento.
+func (s *DetectionService) AddFindingSource(source FindingSource) {
+	s.sources = append(s.sources, source)
+}
+
+// SetTravelDetector habilita la detección de viaje imposible. Su estado se persiste en el
+// mismo repositorio que el de las correlaciones. Debe llamarse antes de RestoreCorrelationState.
+func (s *DetectionService) SetTravelDetector(detector *detection.TravelDetector) {
+	s.travel = detector
+}
+
+// RestoreCorrelationState carga el estado persistido de las correlaciones para que las
+// ventanas en curso sobrevivan a un reinicio. Debe llamarse después de ReloadRules.
+func (s *DetectionService) RestoreCorrelationState(ctx context.Context) error {
+	states, err := s.stateRepo.LoadCorrelationStates(ctx)
+	if err != nil {
+		return fmt.Errorf("error al restaurar el estado de correlación: %w", err)
+	}
+	loaded := s.correlations.LoadStates(states)
+	logger.InfoLog.Printf("%d estados de correlación restaurados.", loaded)
+	if s.travel != nil {
+		logger.InfoLog.Printf("%d ubicaciones de viaje imposible restauradas.", s.travel.LoadStates(states))
+	}
+	return nil
+}
+
+// ObserveEvent evalúa las reglas sobre el evento y guarda cada detección generada.
+func (s *DetectionService) ObserveEvent(ctx context.Context, record *models.EnrichedEventRecord) {
+	doc, err := match.NewDocument(record)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al evaluar reglas de detección sobre el evento %s: %v", record.ID.Hex(), err)
+		return
+	}
+
+	findings := s.engine.EvaluateDocument(record, doc)
+
+	correlation := s.correlations.Evaluate(record, doc)
+	for _, state := range correlation.Updated {
+		if err := s.stateRepo.SaveCorrelationState(ctx, state); err != nil {
+			logger.ErrorLog.Printf("Error al guardar el estado de correlación %s: %v", state.Key, err)
+		}
+	}
+	for _, key := range correlation.Deleted {
+		if err := s.stateRepo.DeleteCorrelationState(ctx, key); err != nil {
+			logger.ErrorLog.Printf("Error al eliminar el estado de correlación %s: %v", key, err)
+		}
+	}
+	findings = append(findings, correlation.Findings...)
+
+	if s.travel != nil {
+		finding, state := s.travel.Evaluate(record)
+		if state != nil {
+			if err := s.stateRepo.SaveCorrelationState(ctx, state); err != nil {
+				logger.ErrorLog.Printf("Error al guardar la última ubicación %s: %v", state.Key, err)
+			}
+		}
+		if finding != nil {
+			findings = append(findings, finding)
+		}
+	}
+	for _, source := range s.sources {
+		findings = append(findings, source.Findings(ctx, record)...)
+	}
+
+	if len(findings) == 0 {
+		return
+	}
+	// Las supresiones vigentes se consultan una sola vez para todas las detecciones del evento
+	now := time.Now()
+	suppressions := s.activeSuppressions(ctx, now)
+	for _, finding := range findings {
+		if s.suppress(ctx, finding, doc, suppressions, now) {
+			continue
+		}
+		if err := s.repo.InsertFinding(ctx, finding); err != nil {
+			logger.ErrorLog.Printf("Error al guardar la detección de la regla %s: %v", finding.RuleID, err)
+			continue
+		}
+		logger.InfoLog.Printf("Detección generada por la regla %s (%s) para el evento %s.", finding.RuleID, finding.Severity, record.ID.Hex())
+
+		for _, observer := range s.observers {
+			observer.ObserveFinding(ctx, finding)
+		}
+	}
+}
+
+// suppress decide si la detección debe suprimirse, ya sea por una supresión vigente de un
+// analista o por la ventana de deduplicación de su regla. Las detecciones suprimidas
+// incrementan el contador de la última detección equivalente en lugar de guardarse.
+func (s *DetectionService) suppress(ctx context.Context, finding *models.Finding, doc match.Document, suppressions []*models.Suppression, now time.Time) bool {
+	s.mu.RLock()
+	config := s.suppressions[finding.RuleID]
+	s.mu.RUnlock()
+
+	finding.DedupKey = finding.GroupKey
+	if config != nil && len(config.GroupBy) > 0 {
+		finding.DedupKey = detection.GroupKey(doc, config.GroupBy)
+	}
+
+	if suppression := matchingSuppression(suppressions, finding.RuleID, doc); suppression != nil {
+		if err := s.suppressRepo.RecordSuppressionHit(ctx, suppression.ID.Hex(), now); err != nil {
+			logger.ErrorLog.Printf("Error al registrar la supresión %s: %v", suppression.ID.Hex(), err)
+		}
+		s.recordSuppressed(ctx, finding, now)
+		return true
+	}
+
+	if config == nil {
+		return false
+	}
+	count, err := s.repo.CountFindingsSince(ctx, finding.RuleID, finding.DedupKey, now.Add(-config.Window))
+	if err != nil {
+		// Ante un error se prefiere generar la detección a perderla.
+		logger.ErrorLog.Printf("Error al evaluar la supresión de la regla %s: %v", finding.RuleID, err)
+		return false
+	}
+	if count < int64(config.MaxAlerts) {
+		return false
+	}
+	s.recordSuppressed(ctx, finding, now)
+	return true
+}
+
+// recordSuppressed registra la detección suprimida. last_seen conserva la hora del evento,
+// como en el resto de las detecciones; at (la hora de proceso) solo va a last_suppressed_at.
+func (s *DetectionService) recordSuppressed(ctx context.Context, finding *models.Finding, at time.Time) {
+	if err := s.repo.RecordSuppressed(ctx, finding.RuleID, finding.DedupKey, finding.LastSeen, at); err != nil {
+		logger.ErrorLog.Printf("Error al registrar la detección suprimida de la regla %s: %v", finding.RuleID, err)
+		return
+	}
+	logger.InfoLog.Printf("Detección de la regla %s suprimida (%s).", finding.RuleID, finding.DedupKey)
+}
+
+// activeSuppressions devuelve las supresiones de analistas vigentes en now.
+func (s *DetectionService) activeSuppressions(ctx context.Context, now time.Time) []*models.Suppression {
+	if s.suppressRepo == nil {
+		return nil
+	}
+	suppressions, err := s.suppressRepo.ListSuppressions(ctx, &now)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al consultar las supresiones vigentes: %v", err)
+		return nil
+	}
+	return suppressions
+}
+
+// matchingSuppression devuelve la primera supresión que aplica a la regla y al evento.
+func matchingSuppression(suppressions []*models.Suppression, ruleID string, doc match.Document) *models.Suppression {
+	for _, suppression := range suppressions {
+		if suppression.RuleID != "" && suppression.RuleID != ruleID {
+			continue
+		}
+		if suppressionMatches(suppression.Conditions, doc) {
+			return suppression
+		}
+	}
+	return nil
+}
+
+// suppressionMatches indica si el evento cumple todas las condiciones de la supresión.
+func suppressionMatches(conditions []models.SuppressionCondition, doc match.Document) bool {
+	for _, condition := range conditions {
+		matched := false
+		for _, value := range doc.Values(condition.Field) {
+			if value == condition.Value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
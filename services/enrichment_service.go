@@ -2,13 +2,19 @@ package services
 
 import (
 	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/pkg/utils"
 	"cloudtrail-enrichment-api-golang/internal/repository"
 	"cloudtrail-enrichment-api-golang/models"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type EnrichmentService interface {
@@ -17,10 +23,59 @@ type EnrichmentService interface {
 	// y devuelve una slice de *models.EnrichedEventRecord (los registros procesados) y un error.
 	EnrichEvent(ctx context.Context, event *models.Event) ([]*models.EnrichedEventRecord, error)
 	Top10QueryEvents(ctx context.Context) ([]*models.EnrichedEventRecord, error)
+	QueryEvents(ctx context.Context, filter *models.EventFilter) ([]*models.EnrichedEventRecord, error)
+	QueryEventsProjected(ctx context.Context, filter *models.EventFilter, projection *models.EventProjection) ([]map[string]interface{}, error)
+	GetEvent(ctx context.Context, id string) (*models.EnrichedEventRecord, error)
+	CountEvents(ctx context.Context, filter *models.EventFilter) (int64, error)
+	AggregateEvents(ctx context.Context, filter *models.EventFilter, groupBy string, limit int64) ([]*models.AggregateBucket, error)
+}
+
+var (
+	// ErrInvalidProjection se devuelve cuando el parámetro fields contiene rutas no válidas.
+	ErrInvalidProjection = errors.New("proyección de campos inválida")
+	// ErrEventNotFound se devuelve cuando el evento solicitado no existe.
+	ErrEventNotFound = errors.New("evento no encontrado")
+	// ErrInvalidAggregation se devuelve cuando se pide agrupar por un campo no admitido.
+	ErrInvalidAggregation = errors.New("campo de agregación no admitido")
+)
+
+// projectionFieldPattern acepta rutas como "eventName" o "userIdentity.arn" y rechaza
+// operadores de MongoDB ($) o segmentos vacíos.
+var projectionFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// maxProjectionFields limita la cantidad de campos que se pueden solicitar en una proyección.
+const maxProjectionFields = 50
+
+// EventObserver recibe cada evento enriquecido una vez persistido (por ejemplo, el motor de detección).
+// Sus errores no deben interrumpir la ingesta.
+type EventObserver interface {
+	ObserveEvent(ctx context.Context, record *models.EnrichedEventRecord)
+}
+
+// Enricher añade información a un evento antes de persistirlo (técnicas ATT&CK, inteligencia
+// de amenazas...). Sus errores se registran en el log y no interrumpen la ingesta.
+type Enricher interface {
+	Name() string
+	Enrich(ctx context.Context, record *models.EnrichedEventRecord) error
+}
+
+// Prefetcher es un Enricher que puede adelantar trabajo lento, como consultas de red, para todo
+// el lote antes de que se procese cada registro.
+type Prefetcher interface {
+	Prefetch(ctx context.Context, sourceIPs []string)
+}
+
+// RiskScorer calcula el puntaje de riesgo de un evento enriquecido antes de persistirlo.
+type RiskScorer interface {
+	ScoreEvent(ctx context.Context, record *models.EnrichedEventRecord) *models.RiskScore
 }
 
 type DefaultEnrichmentService struct {
-	repo repository.EnrichmentRepository
+	repo        repository.EnrichmentRepository
+	observers   []EventObserver
+	classifiers []Enricher
+	enrichers   []Enricher
+	risk        RiskScorer // Opcional: puntaje de riesgo de cada evento
 }
 
 func NewDefaultEnrichmentService(repo repository.EnrichmentRepository) *DefaultEnrichmentService {
@@ -29,11 +84,45 @@ func NewDefaultEnrichmentService(repo repository.EnrichmentRepository) *DefaultE
 	}
 }
 
+// AddObserver registra un EventObserver que se invocará tras persistir cada evento enriquecido.
+func (s *DefaultEnrichmentService) AddObserver(observer EventObserver) {
+	s.observers = append(s.observers, observer)
+}
+
+// AddClassifier registra un Enricher que se aplica, en orden de registro, antes de la
+// geolocalización. Los clasificadores (origen propio de AWS, redes privadas) determinan si el
+// origen debe geolocalizarse; ver models.EnrichedEventRecord.NeedsGeo.
+func (s *DefaultEnrichmentService) AddClassifier(classifier Enricher) {
+	s.classifiers = append(s.classifiers, classifier)
+}
+
+// AddEnricher registra un Enricher que se aplica, en orden de registro, a cada evento antes de
+// calcular su riesgo y persistirlo.
+func (s *DefaultEnrichmentService) AddEnricher(enricher Enricher) {
+	s.enrichers = append(s.enrichers, enricher)
+}
+
+// SetRiskScorer habilita el cálculo del puntaje de riesgo de cada evento ingerido.
+func (s *DefaultEnrichmentService) SetRiskScorer(scorer RiskScorer) {
+	s.risk = scorer
+}
+
 // Implementación de EnrichEvent para DefaultEnrichmentService
 // Coincide con la nueva firma de la interfaz.
 func (s *DefaultEnrichmentService) EnrichEvent(ctx context.Context, event *models.Event) ([]*models.EnrichedEventRecord, error) {
 	var enrichedRecords []*models.EnrichedEventRecord // Cambiamos a slice de punteros para consistencia y eficiencia
 
+	// Los enriquecedores con consultas lentas empiezan a resolver todo el lote en segundo plano
+	sourceIPs := make([]string, 0, len(event.Records))
+	for _, record := range event.Records {
+		sourceIPs = append(sourceIPs, record.SourceIPAddress)
+	}
+	for _, enricher := range s.enrichers {
+		if prefetcher, ok := enricher.(Prefetcher); ok {
+			prefetcher.Prefetch(ctx, sourceIPs)
+		}
+	}
+
 	// Iterar sobre cada record en el evento de entrada
 	for i, record := range event.Records {
 		sourceIP := record.SourceIPAddress
@@ -43,43 +132,77 @@ func (s *DefaultEnrichmentService) EnrichEvent(ctx context.Context, event *model
 		}
 		logger.InfoLog.Printf("IP extraída del registro %d: %s", i, sourceIP)
 
-		country, err := GetCountryFromIP(sourceIP)
-		if err != nil {
-			logger.ErrorLog.Printf("Error al obtener el país para la IP %s (registro %d): %v", sourceIP, i, err)
-			// Decide si quieres fallar todo el batch o solo saltar este registro.
-			// Por ahora, lo hacemos fallar para demostrar el error.
-			return nil, fmt.Errorf("error al obtener el país para el registro %d: %w", i, err)
+		// Crear una nueva instancia de EnrichedEventRecord para la base de datos
+		enrichedRecord := models.EnrichedEventRecord{
+			EventVersion:        record.EventVersion,
+			UserIdentity:        record.UserIdentity,
+			EventTime:           record.EventTime,
+			EventSource:         record.EventSource,
+			EventName:           record.EventName,
+			AwsRegion:           record.AwsRegion,
+			SourceIPAddress:     record.SourceIPAddress,
+			UserAgent:           record.UserAgent,
+			RequestParameters:   record.RequestParameters,
+			ResponseElements:    record.ResponseElements,
+			AdditionalEventData: record.AdditionalEventData,
+			ErrorCode:           record.ErrorCode,
+			ErrorMessage:        record.ErrorMessage,
+			VpcEndpointID:       record.VpcEndpointID,
+			RecipientAccountID:  record.RecipientAccountID,
+			Resources:           record.Resources,
+			ReadOnly:            record.ReadOnly,
+			EventCategory:       record.EventCategory,
 		}
-		logger.InfoLog.Printf("País obtenido para la IP %s (registro %d): %s", sourceIP, i, country)
-
-		// Aquí puedes decidir si llamas a GetRegionFromCountry y GetSubregionFromRegion
-		// Es mejor tener una función auxiliar para obtener todo el enrichment de una IP
-		// para evitar llamadas repetidas a APIs y manejar errores de forma más granular.
-		// Por simplicidad, aquí solo obtenemos la región por ahora.
-		region, err := GetRegionFromCountry(country)
-		if err != nil {
-			logger.ErrorLog.Printf("Error al obtener la región para el país %s (registro %d): %v", country, i, err)
-			return nil, fmt.Errorf("error al obtener la región para el registro %d: %w", i, err)
+
+		// Los clasificadores deciden, antes de consultar APIs externas, si el origen se geolocaliza
+		for _, classifier := range s.classifiers {
+			if err := classifier.Enrich(ctx, &enrichedRecord); err != nil {
+				logger.ErrorLog.Printf("Error en el clasificador %s (registro %d): %v", classifier.Name(), i, err)
+			}
 		}
-		logger.InfoLog.Printf("País: %s, Región: %s (registro %d)", country, region, i)
 
-		// Crear una nueva instancia de EnrichedEventRecord para la base de datos
-		enrichedRecord := models.EnrichedEventRecord{
-			EventVersion:      record.EventVersion,
-			UserIdentity:      record.UserIdentity,
-			EventTime:         record.EventTime,
-			EventSource:       record.EventSource,
-			EventName:         record.EventName,
-			AwsRegion:         record.AwsRegion,
-			SourceIPAddress:   record.SourceIPAddress,
-			UserAgent:         record.UserAgent,
-			RequestParameters: record.RequestParameters,
-			ResponseElements:  record.ResponseElements,
-			Enrichment: models.EnrichmentData{ // Asignar la información de enriquecimiento
-				Country:   country,
-				Region:    region,
-				Subregion: "", // No se está obteniendo la subregión en este ejemplo.
-			},
+		if enrichedRecord.NeedsGeo() {
+			geo, err := GetGeoFromIP(sourceIP)
+			if err != nil {
+				logger.ErrorLog.Printf("Error al obtener el país para la IP %s (registro %d): %v", sourceIP, i, err)
+				// Decide si quieres fallar todo el batch o solo saltar este registro.
+				// Por ahora, lo hacemos fallar para demostrar el error.
+				return nil, fmt.Errorf("error al obtener el país para el registro %d: %w", i, err)
+			}
+			country := geo.Country
+			logger.InfoLog.Printf("País obtenido para la IP %s (registro %d): %s", sourceIP, i, country)
+
+			// Aquí puedes decidir si llamas a GetRegionFromCountry y GetSubregionFromRegion
+			// Es mejor tener una función auxiliar para obtener todo el enrichment de una IP
+			// para evitar llamadas repetidas a APIs y manejar errores de forma más granular.
+			// Por simplicidad, aquí solo obtenemos la región por ahora.
+			region, err := GetRegionFromCountry(country)
+			if err != nil {
+				logger.ErrorLog.Printf("Error al obtener la región para el país %s (registro %d): %v", country, i, err)
+				return nil, fmt.Errorf("error al obtener la región para el registro %d: %w", i, err)
+			}
+			logger.InfoLog.Printf("País: %s, Región: %s (registro %d)", country, region, i)
+
+			// Asignar la información de enriquecimiento
+			enrichedRecord.Enrichment.Country = country
+			enrichedRecord.Enrichment.Region = region
+			enrichedRecord.Enrichment.Subregion = "" // No se está obteniendo la subregión en este ejemplo.
+			enrichedRecord.Enrichment.City = geo.City
+			enrichedRecord.Enrichment.ASN, enrichedRecord.Enrichment.ASOrg = splitAS(geo.AS)
+			enrichedRecord.Enrichment.Latitude = geo.Lat
+			enrichedRecord.Enrichment.Longitude = geo.Lon
+		} else {
+			logger.InfoLog.Printf("Se omite la geolocalización del origen %s (registro %d).", sourceIP, i)
+		}
+
+		for _, enricher := range s.enrichers {
+			if err := enricher.Enrich(ctx, &enrichedRecord); err != nil {
+				logger.ErrorLog.Printf("Error en el enriquecedor %s (registro %d): %v", enricher.Name(), i, err)
+			}
+		}
+
+		if s.risk != nil {
+			enrichedRecord.Risk = s.risk.ScoreEvent(ctx, &enrichedRecord)
 		}
 
 		if err := s.repo.InsertLog(ctx, &enrichedRecord); err != nil {
@@ -87,6 +210,10 @@ func (s *DefaultEnrichmentService) EnrichEvent(ctx context.Context, event *model
 			return nil, fmt.Errorf("error al insertar evento enriquecido (registro %d): %w", i, err)
 		}
 
+		for _, observer := range s.observers {
+			observer.ObserveEvent(ctx, &enrichedRecord)
+		}
+
 		enrichedRecords = append(enrichedRecords, &enrichedRecord) // Añadir puntero al slice
 
 		logger.InfoLog.Printf("Evento enriquecido insertado exitosamente (registro %d). SourceIP: %s", i, sourceIP)
@@ -96,9 +223,20 @@ func (s *DefaultEnrichmentService) EnrichEvent(ctx context.Context, event *model
 }
 
 type IPInfo struct {
-	Country string `json:"country"`
-	Status  string `json:"status"`
-	Message string `json:"message"` // Añadido para capturar mensajes de error de la API
+	Country string  `json:"country"`
+	City    string  `json:"city"`
+	Lat     float64 `json:"lat"` // Coordenadas usadas por la detección de viaje imposible
+	Lon     float64 `json:"lon"`
+	AS      string  `json:"as"`
+	Status  string  `json:"status"`
+	Message string  `json:"message"` // Añadido para capturar mensajes de error de la API
+}
+
+// splitAS separa el campo "as" de ip-api.com ("AS16509 Amazon.com, Inc.") en número de
+// sistema autónomo y organización.
+func splitAS(as string) (string, string) {
+	asn, org, _ := strings.Cut(strings.TrimSpace(as), " ")
+	return models.NormalizeASN(asn), strings.TrimSpace(org)
 }
 
 type CountryInfo []struct {
@@ -117,29 +255,143 @@ func (s *DefaultEnrichmentService) Top10QueryEvents(ctx context.Context) ([]*mod
 	return records, nil
 }
 
+// QueryEvents busca los eventos enriquecidos que cumplen con el filtro indicado.
+func (s *DefaultEnrichmentService) QueryEvents(ctx context.Context, filter *models.EventFilter) ([]*models.EnrichedEventRecord, error) {
+	records, err := s.repo.SearchLogs(ctx, filter)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al buscar eventos: %v", err)
+		return nil, fmt.Errorf("error al buscar eventos en el repositorio: %w", err)
+	}
+	logger.InfoLog.Printf("Servicio: %d eventos encontrados para el filtro.", len(records))
+	return records, nil
+}
+
+// QueryEventsProjected busca eventos enriquecidos devolviendo solo los campos solicitados y,
+// en modo compacto, sin las estructuras anidadas vacías.
+func (s *DefaultEnrichmentService) QueryEventsProjected(ctx context.Context, filter *models.EventFilter, projection *models.EventProjection) ([]map[string]interface{}, error) {
+	var fields []string
+	compact := false
+	if projection != nil {
+		fields = projection.Fields
+		compact = projection.Compact
+	}
+
+	if len(fields) > maxProjectionFields {
+		return nil, fmt.Errorf("%w: se permiten como máximo %d campos", ErrInvalidProjection, maxProjectionFields)
+	}
+	for _, field := range fields {
+		if !projectionFieldPattern.MatchString(field) {
+			return nil, fmt.Errorf("%w: campo '%s' no válido", ErrInvalidProjection, field)
+		}
+	}
+	if parent, child, ok := overlappingProjectionFields(fields); ok {
+		return nil, fmt.Errorf("%w: los campos '%s' y '%s' se superponen", ErrInvalidProjection, parent, child)
+	}
+
+	documents, err := s.repo.SearchLogsProjection(ctx, filter, fields)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al buscar eventos con proyección: %v", err)
+		return nil, fmt.Errorf("error al buscar eventos en el repositorio: %w", err)
+	}
+
+	if compact {
+		for i, doc := range documents {
+			documents[i] = utils.CompactDocument(doc)
+		}
+	}
+
+	logger.InfoLog.Printf("Servicio: %d eventos proyectados (%d campos, compacto: %t).", len(documents), len(fields), compact)
+	return documents, nil
+}
+
+// overlappingProjectionFields devuelve el primer par de campos repetidos o en el que uno
+// contiene al otro (por ejemplo "userIdentity" y "userIdentity.arn"), que MongoDB rechaza
+// como colisión de rutas.
+func overlappingProjectionFields(fields []string) (string, string, bool) {
+	for i, a := range fields {
+		for _, b := range fields[i+1:] {
+			if a == b || strings.HasPrefix(b, a+".") {
+				return a, b, true
+			}
+			if strings.HasPrefix(a, b+".") {
+				return b, a, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// GetEvent devuelve un evento enriquecido por su identificador.
+func (s *DefaultEnrichmentService) GetEvent(ctx context.Context, id string) (*models.EnrichedEventRecord, error) {
+	record, err := s.repo.GetLogByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEventNotFound
+		}
+		logger.ErrorLog.Printf("Error en el servicio al obtener el evento %s: %v", id, err)
+		return nil, fmt.Errorf("error al obtener el evento: %w", err)
+	}
+	return record, nil
+}
+
+// CountEvents cuenta los eventos enriquecidos que cumplen con el filtro.
+func (s *DefaultEnrichmentService) CountEvents(ctx context.Context, filter *models.EventFilter) (int64, error) {
+	count, err := s.repo.CountLogs(ctx, filter)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al contar eventos: %v", err)
+		return 0, fmt.Errorf("error al contar eventos: %w", err)
+	}
+	return count, nil
+}
+
+// AggregateEvents agrupa los eventos que cumplen con el filtro por uno de los campos de models.AggregationFields.
+func (s *DefaultEnrichmentService) AggregateEvents(ctx context.Context, filter *models.EventFilter, groupBy string, limit int64) ([]*models.AggregateBucket, error) {
+	field, ok := models.AggregationFields[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAggregation, groupBy)
+	}
+
+	buckets, err := s.repo.AggregateLogs(ctx, filter, field, limit)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al agregar eventos por %s: %v", groupBy, err)
+		return nil, fmt.Errorf("error al agregar eventos: %w", err)
+	}
+	logger.InfoLog.Printf("Servicio: %d grupos obtenidos al agregar eventos por %s.", len(buckets), groupBy)
+	return buckets, nil
+}
+
 // Retrieves the country of an IP address using the ip-api.com API.
 func GetCountryFromIP(ip string) (string, error) {
+	ipInfo, err := GetGeoFromIP(ip)
+	if err != nil {
+		return "", err
+	}
+	return ipInfo.Country, nil
+}
+
+// GetGeoFromIP consulta ip-api.com y devuelve el país, la ciudad y las coordenadas de la IP.
+func GetGeoFromIP(ip string) (*IPInfo, error) {
 	request := fmt.Sprintf("http://ip-api.com/json/%s", ip)
 
 	resp, err := http.Get(request)
 	if err != nil {
-		return "", fmt.Errorf("error al realizar la solicitud HTTP a ip-api.com: %w", err)
+		return nil, fmt.Errorf("error al realizar la solicitud HTTP a ip-api.com: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("respuesta inesperada de ip-api.com: %s", resp.Status)
+		return nil, fmt.Errorf("respuesta inesperada de ip-api.com: %s", resp.Status)
 	}
 
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("error al leer el cuerpo de la respuesta de ip-api.com: %w", err)
+		return nil, fmt.Errorf("error al leer el cuerpo de la respuesta de ip-api.com: %w", err)
 	}
 
 	var ipInfo IPInfo
 	err = json.Unmarshal(bodyBytes, &ipInfo)
 	if err != nil {
-		return "", fmt.Errorf("error al decodificar la respuesta de ip-api.com: %w", err)
+		return nil, fmt.Errorf("error al decodificar la respuesta de ip-api.com: %w", err)
 	}
 
 	if ipInfo.Status != "success" {
@@ -148,10 +400,10 @@ func GetCountryFromIP(ip string) (string, error) {
 		if ipInfo.Message != "" {
 			errMsg = fmt.Sprintf("%s, Mensaje: %s", errMsg, ipInfo.Message)
 		}
-		return "", fmt.Errorf(errMsg)
+		return nil, fmt.Errorf(errMsg)
 	}
 
-	return ipInfo.Country, nil
+	return &ipInfo, nil
 }
 
 // Retrieves the geographical region of a country using the restcountries.com API.
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestQueryEventsProjectedRejectsOverlappingFields(t *testing.T) {
+	service := &DefaultEnrichmentService{}
+	for _, fields := range [][]string{
+		{"userIdentity", "userIdentity.arn"},
+		{"userIdentity.arn", "eventName", "userIdentity"},
+		{"eventName", "eventName"},
+	} {
+		_, err := service.QueryEventsProjected(context.Background(), nil, &models.EventProjection{Fields: fields})
+		if !errors.Is(err, ErrInvalidProjection) {
+			t.Errorf("%v: error = %v, se esperaba ErrInvalidProjection", fields, err)
+		}
+	}
+}
+
+func TestOverlappingProjectionFields(t *testing.T) {
+	if parent, child, ok := overlappingProjectionFields([]string{"userIdentity.arn", "id", "userIdentity"}); !ok || parent != "userIdentity" || child != "userIdentity.arn" {
+		t.Errorf("superposición = %q, %q, %v; se esperaba userIdentity, userIdentity.arn", parent, child, ok)
+	}
+	// Un prefijo que no termina en un segmento completo no se superpone
+	if _, _, ok := overlappingProjectionFields([]string{"event", "eventName", "userIdentity.arn", "userIdentity.accountId"}); ok {
+		t.Errorf("se informó una superposición entre campos independientes")
+	}
+}
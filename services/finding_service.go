@@ -0,0 +1,175 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/repository"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrFindingNotFound se devuelve cuando la detección solicitada no existe.
+	ErrFindingNotFound = errors.New("detección no encontrada")
+	// ErrInvalidFinding se devuelve cuando el filtro o el payload de triage no es válido.
+	ErrInvalidFinding = errors.New("solicitud de detección inválida")
+	// ErrFindingConflict se devuelve cuando otro usuario cambió la detección durante la actualización.
+	ErrFindingConflict = errors.New("la detección fue modificada por otro usuario")
+)
+
+// maxFindingCommentLength limita el tamaño de los comentarios de triage.
+const maxFindingCommentLength = 4000
+
+// FindingService define la interfaz para la consulta y el triage de detecciones.
+type FindingService interface {
+	ListFindings(ctx context.Context, filter *models.FindingFilter) ([]*models.Finding, error)
+	GetFinding(ctx context.Context, id string) (*models.Finding, error)
+	UpdateFindingStatus(ctx context.Context, actor models.FindingActor, id string, payload *models.FindingStatusPayload) (*models.Finding, error)
+	AssignFinding(ctx context.Context, actor models.FindingActor, id string, payload *models.FindingAssignPayload) (*models.Finding, error)
+	CommentFinding(ctx context.Context, actor models.FindingActor, id string, payload *models.FindingCommentPayload) (*models.Finding, error)
+}
+
+// DefaultFindingService es la implementación predeterminada de FindingService.
+type DefaultFindingService struct {
+	repo repository.FindingRepository
+}
+
+// NewDefaultFindingService crea una nueva instancia de DefaultFindingService.
+func NewDefaultFindingService(repo repository.FindingRepository) *DefaultFindingService {
+	return &DefaultFindingService{
+		repo: repo,
+	}
+}
+
+// findingError traduce los errores del repositorio a los errores del servicio.
+func findingError(id string, err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrFindingNotFound
+	}
+	logger.ErrorLog.Printf("Error en el servicio de detecciones (%s): %v", id, err)
+	return fmt.Errorf("error al procesar la detección: %w", err)
+}
+
+// validFindingID indica si el identificador tiene formato de ObjectID. Los identificadores
+// inválidos se tratan como inexistentes.
+func validFindingID(id string) bool {
+	_, err := primitive.ObjectIDFromHex(id)
+	return err == nil
+}
+
+// ListFindings lista las detecciones que cumplen con el filtro.
+func (s *DefaultFindingService) ListFindings(ctx context.Context, filter *models.FindingFilter) ([]*models.Finding, error) {
+	if filter.Status != "" && !models.FindingStatuses[filter.Status] {
+		return nil, fmt.Errorf("%w: estado '%s' no válido", ErrInvalidFinding, filter.Status)
+	}
+	if filter.Severity != "" {
+		if _, ok := models.SeverityRank[filter.Severity]; !ok {
+			return nil, fmt.Errorf("%w: severidad '%s' no válida", ErrInvalidFinding, filter.Severity)
+		}
+	}
+
+	findings, err := s.repo.ListFindings(ctx, filter)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al listar detecciones: %v", err)
+		return nil, fmt.Errorf("error al listar detecciones: %w", err)
+	}
+	return findings, nil
+}
+
+// GetFinding devuelve una detección por su identificador.
+func (s *DefaultFindingService) GetFinding(ctx context.Context, id string) (*models.Finding, error) {
+	if !validFindingID(id) {
+		return nil, ErrFindingNotFound
+	}
+	finding, err := s.repo.GetFinding(ctx, id)
+	if err != nil {
+		return nil, findingError(id, err)
+	}
+	return finding, nil
+}
+
+// UpdateFindingStatus cambia el estado de la detección registrando quién hizo el cambio. La
+// actualización solo se aplica si el estado no cambió desde que se leyó; si cambió devuelve
+// ErrFindingConflict para que el analista revise el nuevo estado.
+func (s *DefaultFindingService) UpdateFindingStatus(ctx context.Context, actor models.FindingActor, id string, payload *models.FindingStatusPayload) (*models.Finding, error) {
+	status := strings.ToLower(strings.TrimSpace(payload.Status))
+	if !models.FindingStatuses[status] {
+		return nil, fmt.Errorf("%w: estado '%s' no válido", ErrInvalidFinding, payload.Status)
+	}
+
+	current, err := s.GetFinding(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == status {
+		return current, nil
+	}
+
+	change := models.FindingChange{Field: "status", From: current.Status, To: status, Actor: actor, At: time.Now()}
+	finding, err := s.repo.SetFindingStatus(ctx, id, status, change)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		logger.InfoLog.Printf("Detección %s: el estado dejó de ser %s antes de cambiarlo a %s.", id, current.Status, status)
+		return nil, fmt.Errorf("%w: el estado ya no es '%s'", ErrFindingConflict, current.Status)
+	}
+	if err != nil {
+		return nil, findingError(id, err)
+	}
+	logger.InfoLog.Printf("Detección %s cambió de estado %s -> %s por %s.", id, current.Status, status, actor.Email)
+	return finding, nil
+}
+
+// AssignFinding asigna la detección a un analista (o la desasigna) registrando quién hizo el
+// cambio. Igual que UpdateFindingStatus, devuelve ErrFindingConflict si la asignación cambió
+// desde que se leyó.
+func (s *DefaultFindingService) AssignFinding(ctx context.Context, actor models.FindingActor, id string, payload *models.FindingAssignPayload) (*models.Finding, error) {
+	assignee := strings.TrimSpace(payload.Assignee)
+
+	current, err := s.GetFinding(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Assignee == assignee {
+		return current, nil
+	}
+
+	change := models.FindingChange{Field: "assignee", From: current.Assignee, To: assignee, Actor: actor, At: time.Now()}
+	finding, err := s.repo.SetFindingAssignee(ctx, id, assignee, change)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		logger.InfoLog.Printf("Detección %s: la asignación dejó de ser '%s' antes de cambiarla a '%s'.", id, current.Assignee, assignee)
+		return nil, fmt.Errorf("%w: la asignación ya no es '%s'", ErrFindingConflict, current.Assignee)
+	}
+	if err != nil {
+		return nil, findingError(id, err)
+	}
+	logger.InfoLog.Printf("Detección %s asignada a '%s' por %s.", id, assignee, actor.Email)
+	return finding, nil
+}
+
+// CommentFinding agrega un comentario del usuario autenticado a la detección.
+func (s *DefaultFindingService) CommentFinding(ctx context.Context, actor models.FindingActor, id string, payload *models.FindingCommentPayload) (*models.Finding, error) {
+	text := strings.TrimSpace(payload.Text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: el comentario es requerido", ErrInvalidFinding)
+	}
+	if len(text) > maxFindingCommentLength {
+		return nil, fmt.Errorf("%w: el comentario supera los %d caracteres", ErrInvalidFinding, maxFindingCommentLength)
+	}
+	if !validFindingID(id) {
+		return nil, ErrFindingNotFound
+	}
+
+	now := time.Now()
+	comment := models.FindingComment{ID: primitive.NewObjectID(), Author: actor, Text: text, CreatedAt: now}
+	change := models.FindingChange{Field: "comment", To: comment.ID.Hex(), Actor: actor, At: now}
+	finding, err := s.repo.AddFindingComment(ctx, id, comment, change)
+	if err != nil {
+		return nil, findingError(id, err)
+	}
+	return finding, nil
+}
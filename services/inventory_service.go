@@ -0,0 +1,180 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/enrich"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/repository"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInventoryItemNotFound se devuelve cuando el elemento no existe en el inventario.
+	ErrInventoryItemNotFound = errors.New("elemento de inventario no encontrado")
+	// ErrInvalidInventoryItem se devuelve cuando el payload del elemento no es válido.
+	ErrInvalidInventoryItem = errors.New("elemento de inventario inválido")
+)
+
+// InventoryIndex recibe el inventario completo cada vez que cambia. Lo implementa el
+// enriquecedor de inventario, que trabaja sobre una copia en memoria.
+type InventoryIndex interface {
+	Load(items []*models.InventoryItem)
+}
+
+// InventoryService define la interfaz para la administración del inventario de cuentas y recursos.
+type InventoryService interface {
+	ListInventory(ctx context.Context, kind string) ([]*models.InventoryItem, error)
+	GetInventoryItem(ctx context.Context, kind, key string) (*models.InventoryItem, error)
+	UpsertInventoryItem(ctx context.Context, payload *models.InventoryPayload) (*models.InventoryItem, error)
+	DeleteInventoryItem(ctx context.Context, kind, key string) error
+	ImportInventoryFiles(ctx context.Context) (int, error)
+}
+
+// DefaultInventoryService es la implementación predeterminada de InventoryService. Importa los
+// archivos configurados, persiste el inventario en PostgreSQL y mantiene actualizado el índice
+// del enriquecedor.
+type DefaultInventoryService struct {
+	repo  repository.InventoryRepository
+	index InventoryIndex
+	files []string
+
+	reload *enrich.PeriodicReload
+}
+
+// NewDefaultInventoryService crea una nueva instancia de DefaultInventoryService. index puede
+// ser nil si el enriquecedor está deshabilitado.
+func NewDefaultInventoryService(repo repository.InventoryRepository, index InventoryIndex, files []string) *DefaultInventoryService {
+	return &DefaultInventoryService{
+		repo:   repo,
+		index:  index,
+		files:  files,
+		reload: enrich.NewPeriodicReload(),
+	}
+}
+
+// ListInventory devuelve el inventario completo o solo las cuentas o los recursos.
+func (s *DefaultInventoryService) ListInventory(ctx context.Context, kind string) ([]*models.InventoryItem, error) {
+	if kind != "" && !models.InventoryKinds[kind] {
+		return nil, fmt.Errorf("%w: tipo '%s' no válido, se admite account o resource", ErrInvalidInventoryItem, kind)
+	}
+	items, err := s.repo.ListInventoryItems(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar el inventario: %w", err)
+	}
+	return items, nil
+}
+
+// GetInventoryItem devuelve un elemento del inventario.
+func (s *DefaultInventoryService) GetInventoryItem(ctx context.Context, kind, key string) (*models.InventoryItem, error) {
+	item, err := s.repo.GetInventoryItem(ctx, kind, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInventoryItemNotFound
+		}
+		return nil, fmt.Errorf("error al obtener elemento de inventario: %w", err)
+	}
+	return item, nil
+}
+
+// UpsertInventoryItem crea o reemplaza un elemento del inventario desde la API. Si el elemento
+// venía de un archivo, la próxima importación de ese archivo vuelve a reemplazarlo.
+func (s *DefaultInventoryService) UpsertInventoryItem(ctx context.Context, payload *models.InventoryPayload) (*models.InventoryItem, error) {
+	item := &models.InventoryItem{
+		Kind:               strings.ToLower(strings.TrimSpace(payload.Kind)),
+		Key:                strings.TrimSpace(payload.Key),
+		Name:               strings.TrimSpace(payload.Name),
+		Environment:        strings.TrimSpace(payload.Environment),
+		Team:               strings.TrimSpace(payload.Team),
+		DataClassification: strings.TrimSpace(payload.DataClassification),
+		Labels:             payload.Labels,
+		Source:             models.InventorySourceAPI,
+	}
+	if err := enrich.ValidateInventoryItem(item); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInventoryItem, err)
+	}
+	if err := s.repo.UpsertInventoryItem(ctx, item); err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al guardar elemento de inventario: %v", err)
+		return nil, fmt.Errorf("error al guardar elemento de inventario: %w", err)
+	}
+	s.refresh(ctx)
+	return item, nil
+}
+
+// DeleteInventoryItem elimina un elemento del inventario.
+func (s *DefaultInventoryService) DeleteInventoryItem(ctx context.Context, kind, key string) error {
+	deleted, err := s.repo.DeleteInventoryItem(ctx, kind, key)
+	if err != nil {
+		return fmt.Errorf("error al eliminar elemento de inventario: %w", err)
+	}
+	if !deleted {
+		return ErrInventoryItemNotFound
+	}
+	s.refresh(ctx)
+	return nil
+}
+
+// ImportInventoryFiles importa los archivos configurados. Cada archivo reemplaza los elementos
+// que se importaron antes desde él. Los archivos se validan todos antes de escribir, de modo que
+// un archivo con errores no deja el inventario a medio importar.
+func (s *DefaultInventoryService) ImportInventoryFiles(ctx context.Context) (int, error) {
+	loaded := make([][]*models.InventoryItem, len(s.files))
+	for i, path := range s.files {
+		items, err := enrich.LoadInventoryFile(path)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidInventoryItem, err)
+		}
+		loaded[i] = items
+	}
+
+	total := 0
+	for i, path := range s.files {
+		if err := s.repo.ReplaceInventorySource(ctx, path, loaded[i]); err != nil {
+			return total, fmt.Errorf("error al importar el inventario %s: %w", path, err)
+		}
+		total += len(loaded[i])
+	}
+	s.refresh(ctx)
+	return total, nil
+}
+
+// Refresh carga el inventario de PostgreSQL en el índice del enriquecedor.
+func (s *DefaultInventoryService) Refresh(ctx context.Context) error {
+	if s.index == nil {
+		return nil
+	}
+	items, err := s.repo.ListInventoryItems(ctx, "")
+	if err != nil {
+		return err
+	}
+	s.index.Load(items)
+	return nil
+}
+
+// refresh actualiza el índice tras un cambio. Si falla, el índice se actualiza en la próxima
+// recarga periódica.
+func (s *DefaultInventoryService) refresh(ctx context.Context) {
+	if err := s.Refresh(ctx); err != nil {
+		logger.ErrorLog.Printf("Error al actualizar el inventario en memoria: %v", err)
+	}
+}
+
+// Start recarga periódicamente el índice desde PostgreSQL, para tomar los cambios hechos por
+// otras instancias de la API. Un intervalo menor o igual a cero no inicia la recarga.
+func (s *DefaultInventoryService) Start(interval time.Duration) {
+	if s.index == nil {
+		return
+	}
+	s.reload.Start(interval, "Error al actualizar el inventario en memoria", func() error {
+		return s.Refresh(context.Background())
+	})
+}
+
+// Stop detiene la recarga periódica.
+func (s *DefaultInventoryService) Stop() {
+	s.reload.Stop()
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"bytes"
+	"cloudtrail-enrichment-api-golang/internal/notify"
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/repository"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrChannelNotFound se devuelve cuando el canal de notificación solicitado no existe.
+	ErrChannelNotFound = errors.New("canal de notificación no encontrado")
+	// ErrInvalidDeliveryFilter se devuelve cuando el filtro del registro de entregas no es válido.
+	ErrInvalidDeliveryFilter = errors.New("filtro de entregas inválido")
+)
+
+// Valores por defecto del despachador de notificaciones.
+const (
+	defaultNotificationWorkers   = 2
+	defaultNotificationQueueSize = 1000
+	defaultNotificationAttempts  = 5
+	defaultNotificationBackoff   = time.Second
+	defaultNotificationMaxWait   = time.Minute
+	defaultNotificationTimeout   = 10 * time.Second
+)
+
+// NotificationOptions configura el despachador de notificaciones. Los valores en cero
+// toman los valores por defecto.
+type NotificationOptions struct {
+	Workers        int
+	QueueSize      int
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration
+}
+
+// NotificationService define la interfaz del envío de notificaciones de detecciones.
+type NotificationService interface {
+	ListDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]*models.NotificationDelivery, error)
+	SendTest(ctx context.Context, channel string) (*models.NotificationDelivery, error)
+}
+
+// notificationJob es una detección pendiente de entregar por un canal.
+type notificationJob struct {
+	finding *models.Finding
+	channel *notify.Channel
+}
+
+// DefaultNotificationService es la implementación predeterminada de NotificationService.
+// Implementa FindingObserver: encola cada detección para los canales que la aceptan y un
+// grupo de workers en segundo plano la entrega con reintentos y backoff exponencial.
+type DefaultNotificationService struct {
+	repo     repository.NotificationDeliveryRepository
+	channels []*notify.Channel
+	client   *http.Client
+	options  NotificationOptions
+
+	queue chan notificationJob
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewDefaultNotificationService crea una nueva instancia de DefaultNotificationService.
+func NewDefaultNotificationService(repo repository.NotificationDeliveryRepository, channels []*notify.Channel, options NotificationOptions) *DefaultNotificationService {
+	if options.Workers <= 0 {
+		options.Workers = defaultNotificationWorkers
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultNotificationQueueSize
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultNotificationAttempts
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = defaultNotificationBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = defaultNotificationMaxWait
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultNotificationTimeout
+	}
+
+	return &DefaultNotificationService{
+		repo:     repo,
+		channels: channels,
+		client:   &http.Client{Timeout: options.Timeout},
+		options:  options,
+		queue:    make(chan notificationJob, options.QueueSize),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start inicia los workers del despachador.
+func (s *DefaultNotificationService) Start() {
+	for i := 0; i < s.options.Workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for {
+				select {
+				case <-s.stop:
+					return
+				case job := <-s.queue:
+					s.dispatch(job)
+				}
+			}
+		}()
+	}
+	logger.InfoLog.Printf("Despachador de notificaciones iniciado con %d canales y %d workers.", len(s.channels), s.options.Workers)
+}
+
+// Stop detiene los workers. Las entregas en espera de reintento se abandonan y quedan
+// registradas como fallidas; las que seguían en la cola se registran como abandonadas.
+func (s *DefaultNotificationService) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+
+	abandoned := 0
+	for {
+		select {
+		case job := <-s.queue:
+			s.recordUndelivered(context.Background(), job, models.DeliveryStatusAbandoned, "despachador detenido antes del envío")
+			abandoned++
+		default:
+			if abandoned > 0 {
+				logger.ErrorLog.Printf("Despachador de notificaciones detenido con %d entregas pendientes en la cola.", abandoned)
+			}
+			return
+		}
+	}
+}
+
+// ObserveFinding encola la detección para cada canal que la acepta. Si la cola está
+// llena la notificación se descarta para no bloquear la ingesta y queda registrada como
+// descartada.
+func (s *DefaultNotificationService) ObserveFinding(ctx context.Context, finding *models.Finding) {
+	for _, channel := range s.channels {
+		if !channel.Matches(finding) {
+			continue
+		}
+		select {
+		case s.queue <- notificationJob{finding: finding, channel: channel}:
+		default:
+			logger.ErrorLog.Printf("Cola de notificaciones llena: se descarta la detección %s para el canal %s.", finding.ID.Hex(), channel.Name)
+			s.recordUndelivered(ctx, notificationJob{finding: finding, channel: channel}, models.DeliveryStatusDropped, "cola de notificaciones llena")
+		}
+	}
+}
+
+// dispatch entrega la notificación y registra el resultado.
+func (s *DefaultNotificationService) dispatch(job notificationJob) {
+	delivery := s.deliver(job, s.options.MaxAttempts)
+	if err := s.repo.InsertDelivery(context.Background(), delivery); err != nil {
+		logger.ErrorLog.Printf("Error al registrar la entrega al canal %s: %v", job.channel.Name, err)
+	}
+}
+
+// recordUndelivered registra una notificación que no llegó a enviarse.
+func (s *DefaultNotificationService) recordUndelivered(ctx context.Context, job notificationJob, status, reason string) {
+	now := time.Now()
+	delivery := &models.NotificationDelivery{
+		FindingID:   job.finding.ID,
+		RuleID:      job.finding.RuleID,
+		Channel:     job.channel.Name,
+		ChannelType: job.channel.Type,
+		Status:      status,
+		Error:       reason,
+		CreatedAt:   now,
+		CompletedAt: now,
+	}
+	if err := s.repo.InsertDelivery(ctx, delivery); err != nil {
+		logger.ErrorLog.Printf("Error al registrar la entrega %s al canal %s: %v", status, job.channel.Name, err)
+	}
+}
+
+// deliver envía la notificación reintentando los errores de red, 429 y 5xx con backoff
+// exponencial (con jitter) hasta maxAttempts intentos.
+func (s *DefaultNotificationService) deliver(job notificationJob, maxAttempts int) *models.NotificationDelivery {
+	delivery := &models.NotificationDelivery{
+		FindingID:   job.finding.ID,
+		RuleID:      job.finding.RuleID,
+		Channel:     job.channel.Name,
+		ChannelType: job.channel.Type,
+		Status:      models.DeliveryStatusFailed,
+		CreatedAt:   time.Now(),
+	}
+	defer func() { delivery.CompletedAt = time.Now() }()
+
+	body, err := job.channel.Payload(job.finding)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	backoff := s.options.InitialBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		delivery.Attempts = attempt
+		statusCode, retry, err := s.send(job.channel, body)
+		delivery.StatusCode = statusCode
+		if err == nil {
+			delivery.Status = models.DeliveryStatusDelivered
+			delivery.Error = ""
+			logger.InfoLog.Printf("Detección %s notificada al canal %s (intento %d).", job.finding.ID.Hex(), job.channel.Name, attempt)
+			return delivery
+		}
+		delivery.Error = err.Error()
+		if !retry || attempt == maxAttempts {
+			break
+		}
+
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+		select {
+		case <-s.stop:
+			delivery.Error += " (despachador detenido)"
+			return delivery
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > s.options.MaxBackoff {
+			backoff = s.options.MaxBackoff
+		}
+	}
+
+	logger.ErrorLog.Printf("No se pudo notificar la detección %s al canal %s tras %d intentos: %s", job.finding.ID.Hex(), job.channel.Name, delivery.Attempts, delivery.Error)
+	return delivery
+}
+
+// send realiza un intento de entrega. Devuelve el código HTTP y si el error es reintentable.
+func (s *DefaultNotificationService) send(channel *notify.Channel, body []byte) (int, bool, error) {
+	req, err := http.NewRequest(http.MethodPost, channel.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", notify.Source)
+	for key, value := range channel.Headers {
+		req.Header.Set(key, value)
+	}
+	if secret := channel.Secret(); secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(notify.TimestampHeader, fmt.Sprint(timestamp))
+		req.Header.Set(notify.SignatureHeader, notify.Sign(secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return resp.StatusCode, retry, fmt.Errorf("el canal respondió %s", resp.Status)
+}
+
+// ListDeliveries consulta el registro de entregas.
+func (s *DefaultNotificationService) ListDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]*models.NotificationDelivery, error) {
+	if filter.FindingID != "" && !validFindingID(filter.FindingID) {
+		return nil, fmt.Errorf("%w: finding_id inválido", ErrInvalidDeliveryFilter)
+	}
+	if filter.Status != "" && !models.DeliveryStatuses[filter.Status] {
+		return nil, fmt.Errorf("%w: estado '%s' no válido", ErrInvalidDeliveryFilter, filter.Status)
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al listar entregas de notificaciones: %v", err)
+		return nil, fmt.Errorf("error al listar entregas: %w", err)
+	}
+	return deliveries, nil
+}
+
+// SendTest envía de forma síncrona, en un único intento, una detección de prueba al canal
+// indicado y registra la entrega. Sirve para validar la configuración contra un receptor local.
+func (s *DefaultNotificationService) SendTest(ctx context.Context, channelName string) (*models.NotificationDelivery, error) {
+	var channel *notify.Channel
+	for _, c := range s.channels {
+		if c.Name == channelName {
+			channel = c
+			break
+		}
+	}
+	if channel == nil {
+		return nil, ErrChannelNotFound
+	}
+
+	now := time.Now()
+	finding := &models.Finding{
+		ID:          primitive.NewObjectID(),
+		RuleID:      "notification-test",
+		RuleName:    "Notificación de prueba",
+		Description: "Detección sintética para verificar el canal de notificación.",
+		Severity:    models.SeverityLow,
+		EventIDs:    []primitive.ObjectID{},
+		FirstSeen:   now,
+		LastSeen:    now,
+		CreatedAt:   now,
+		Status:      models.FindingStatusOpen,
+		UpdatedAt:   now,
+	}
+
+	delivery := s.deliver(notificationJob{finding: finding, channel: channel}, 1)
+	if err := s.repo.InsertDelivery(ctx, delivery); err != nil {
+		logger.ErrorLog.Printf("Error al registrar la entrega de prueba al canal %s: %v", channel.Name, err)
+	}
+	return delivery, nil
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Valores por defecto de los factores de riesgo configurables.
+var (
+	defaultRiskSensitiveSources = []string{"iam.amazonaws.com", "kms.amazonaws.com", "organizations.amazonaws.com"}
+	defaultRiskErrorCodes       = []string{"AccessDenied", "AccessDeniedException", "UnauthorizedOperation", "Client.UnauthorizedOperation"}
+)
+
+// RiskOptions configura el cálculo del puntaje de riesgo. Las listas vacías toman los valores
+// por defecto, salvo ExpectedCountries y BadIPCIDRs que son opcionales.
+type RiskOptions struct {
+	Weights           map[string]int // Peso por factor; los factores ausentes toman el peso por defecto y 0 los deshabilita
+	SensitiveSources  []string       // eventSource considerados sensibles
+	ErrorCodes        []string       // errorCode que suman riesgo
+	ExpectedCountries []string       // Si se indica, cualquier otro país se considera geografía inusual
+	BadIPCIDRs        []string       // IP de Tor o de mala reputación conocida
+}
+
+// UnusualChecker indica si un valor nunca se observó para un principal. Lo implementa
+// DefaultBaselineService.
+type UnusualChecker interface {
+	IsUnusual(ctx context.Context, principal, dimension, value string, at time.Time) bool
+}
+
+// DefaultRiskService calcula el puntaje de riesgo de cada evento enriquecido como la suma de
+// los pesos de los factores presentes, truncada a models.MaxRiskScore. Implementa RiskScorer.
+type DefaultRiskService struct {
+	weights    map[string]int
+	sensitive  map[string]bool
+	errorCodes map[string]bool
+	expected   map[string]bool
+	badIPs     []*net.IPNet
+	baselines  UnusualChecker
+}
+
+// NewDefaultRiskService valida la configuración y crea una nueva instancia de DefaultRiskService.
+func NewDefaultRiskService(options RiskOptions) (*DefaultRiskService, error) {
+	weights := make(map[string]int, len(models.DefaultRiskWeights))
+	for factor, weight := range models.DefaultRiskWeights {
+		weights[factor] = weight
+	}
+	for factor, weight := range options.Weights {
+		if _, ok := models.DefaultRiskWeights[factor]; !ok {
+			return nil, fmt.Errorf("factor de riesgo '%s' no válido", factor)
+		}
+		if weight < 0 || weight > models.MaxRiskScore {
+			return nil, fmt.Errorf("peso %d no válido para el factor de riesgo '%s'", weight, factor)
+		}
+		weights[factor] = weight
+	}
+
+	if len(options.SensitiveSources) == 0 {
+		options.SensitiveSources = defaultRiskSensitiveSources
+	}
+	if len(options.ErrorCodes) == 0 {
+		options.ErrorCodes = defaultRiskErrorCodes
+	}
+
+	service := &DefaultRiskService{
+		weights:    weights,
+		sensitive:  stringSet(options.SensitiveSources),
+		errorCodes: stringSet(options.ErrorCodes),
+		expected:   stringSet(options.ExpectedCountries),
+	}
+	for _, cidr := range options.BadIPCIDRs {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("rango de IP de mala reputación '%s' no válido: %w", cidr, err)
+		}
+		service.badIPs = append(service.badIPs, network)
+	}
+	return service, nil
+}
+
+// SetBaselines habilita el factor de geografía inusual basado en la línea base del principal.
+func (s *DefaultRiskService) SetBaselines(baselines UnusualChecker) {
+	s.baselines = baselines
+}
+
+// ScoreEvent calcula el puntaje de riesgo del evento y los factores que lo componen.
+func (s *DefaultRiskService) ScoreEvent(ctx context.Context, record *models.EnrichedEventRecord) *models.RiskScore {
+	risk := &models.RiskScore{Factors: []models.RiskFactor{}}
+	add := func(factor, detail string) {
+		if weight := s.weights[factor]; weight > 0 {
+			risk.Factors = append(risk.Factors, models.RiskFactor{Name: factor, Weight: weight, Detail: detail})
+			risk.Score += weight
+		}
+	}
+
+	if s.sensitive[record.EventSource] {
+		add(models.RiskFactorSensitiveAPI, record.EventSource+":"+record.EventName)
+	}
+	if record.UserIdentity.Type == "Root" {
+		add(models.RiskFactorRootIdentity, record.UserIdentity.Arn)
+	}
+	if detail, missing := missingMFA(record); missing {
+		add(models.RiskFactorMissingMFA, detail)
+	}
+	if s.errorCodes[record.ErrorCode] {
+		add(models.RiskFactorErrorCode, record.ErrorCode)
+	}
+	if detail, unusual := s.unusualGeo(ctx, record); unusual {
+		add(models.RiskFactorUnusualGeo, detail)
+	}
+	if detail, bad := s.badIP(record); bad {
+		add(models.RiskFactorBadIP, detail)
+	}
+
+	if risk.Score > models.MaxRiskScore {
+		risk.Score = models.MaxRiskScore
+	}
+	return risk
+}
+
+// unusualGeo evalúa el país del evento contra la lista de países esperados o, si no se
+// configuró, contra la línea base del principal.
+func (s *DefaultRiskService) unusualGeo(ctx context.Context, record *models.EnrichedEventRecord) (string, bool) {
+	country := record.Enrichment.Country
+	if country == "" {
+		return "", false
+	}
+	if len(s.expected) > 0 {
+		return "país " + country + " fuera de los esperados", !s.expected[country]
+	}
+	if s.baselines != nil && s.baselines.IsUnusual(ctx, record.UserIdentity.Arn, models.BaselineDimensionCountry, country, record.EventTime) {
+		return "país " + country + " nunca visto para el principal", true
+	}
+	return "", false
+}
+
+// badIP evalúa la IP de origen contra bad_ip_cidrs, las coincidencias de los feeds de
+// inteligencia de amenazas y la lista de nodos de salida de Tor.
+func (s *DefaultRiskService) badIP(record *models.EnrichedEventRecord) (string, bool) {
+	if ip := net.ParseIP(record.SourceIPAddress); ip != nil {
+		for _, network := range s.badIPs {
+			if network.Contains(ip) {
+				return record.SourceIPAddress + " en " + network.String(), true
+			}
+		}
+	}
+	for _, match := range record.ThreatIntel {
+		if match.Field == "sourceIPAddress" {
+			return record.SourceIPAddress + " en el feed " + match.Feed, true
+		}
+	}
+	if record.Enrichment.IsTor {
+		return record.SourceIPAddress + " es un nodo de salida de Tor", true
+	}
+	return "", false
+}
+
+// missingMFA detecta inicios de sesión en consola sin MFA y sesiones de usuarios IAM o root
+// no autenticadas con MFA. Sin información de MFA en el evento no se asume su ausencia.
+func missingMFA(record *models.EnrichedEventRecord) (string, bool) {
+	if record.AdditionalEventData != nil && record.AdditionalEventData.MFAUsed == "No" {
+		return "MFAUsed=No", true
+	}
+	identityType := record.UserIdentity.Type
+	if session := record.UserIdentity.SessionContext; session != nil && (identityType == "IAMUser" || identityType == "Root") {
+		if session.Attributes.MfaAuthenticated == "false" {
+			return "mfaAuthenticated=false", true
+		}
+	}
+	return "", false
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			set[value] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// scheduledRunTimeout limita la duración de cada ejecución programada.
+const scheduledRunTimeout = 30 * time.Second
+
+// StartScheduler carga las búsquedas programadas desde la base de datos y arranca el planificador.
+func (s *DefaultSavedSearchService) StartScheduler(ctx context.Context) error {
+	searches, err := s.repo.GetScheduledSavedSearches(ctx)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al cargar búsquedas guardadas programadas: %v", err)
+		return fmt.Errorf("error al cargar búsquedas programadas: %w", err)
+	}
+
+	for _, search := range searches {
+		if err := s.schedule(search); err != nil {
+			// Una programación inválida no debe impedir que arranquen las demás.
+			logger.ErrorLog.Printf("No se pudo programar la búsqueda guardada %s: %v", search.UUID, err)
+		}
+	}
+
+	s.cron.Start()
+	logger.InfoLog.Printf("Planificador de búsquedas guardadas iniciado con %d búsquedas programadas.", len(searches))
+	return nil
+}
+
+// StopScheduler detiene el planificador y espera a que terminen las ejecuciones en curso.
+func (s *DefaultSavedSearchService) StopScheduler() {
+	<-s.cron.Stop().Done()
+	logger.InfoLog.Println("Planificador de búsquedas guardadas detenido.")
+}
+
+// schedule registra (o reemplaza) la entrada cron de una búsqueda guardada.
+// Si la búsqueda no tiene programación, se elimina cualquier entrada previa.
+func (s *DefaultSavedSearchService) schedule(search *models.SavedSearch) error {
+	s.unschedule(search.ID)
+	if search.Schedule == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := *search
+	entryID, err := s.cron.AddFunc(search.Schedule, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), scheduledRunTimeout)
+		defer cancel()
+		if _, err := s.execute(ctx, &job); err != nil {
+			logger.ErrorLog.Printf("Error en la ejecución programada de la búsqueda guardada %s: %v", job.UUID, err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("%w: programación cron inválida: %v", ErrInvalidSavedSearch, err)
+	}
+
+	s.entries[search.ID] = entryID
+	return nil
+}
+
+// unschedule elimina la entrada cron de una búsqueda guardada, si existe.
+func (s *DefaultSavedSearchService) unschedule(searchID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entryID, ok := s.entries[searchID]; ok {
+		s.cron.Remove(entryID)
+		delete(s.entries, searchID)
+	}
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/repository"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	// ErrSavedSearchNotFound se devuelve cuando la búsqueda no existe o no pertenece al usuario.
+	ErrSavedSearchNotFound = errors.New("búsqueda guardada no encontrada")
+	// ErrInvalidSavedSearch se devuelve cuando el payload de la búsqueda guardada no es válido.
+	ErrInvalidSavedSearch = errors.New("búsqueda guardada inválida")
+)
+
+// maxSnapshotSize limita la cantidad de eventos registrados en cada instantánea
+// cuando el filtro de la búsqueda no define un límite propio.
+const maxSnapshotSize int64 = 1000
+
+// SavedSearchService define la interfaz para las operaciones sobre búsquedas guardadas.
+type SavedSearchService interface {
+	CreateSavedSearch(ctx context.Context, userID int, payload *models.SavedSearchPayload) (*models.SavedSearch, error)
+	ListSavedSearches(ctx context.Context, userID int) ([]*models.SavedSearch, error)
+	GetSavedSearch(ctx context.Context, userID int, searchUUID string) (*models.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, userID int, searchUUID string, payload *models.SavedSearchPayload) (*models.SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, userID int, searchUUID string) error
+	RunSavedSearch(ctx context.Context, userID int, searchUUID string) (*models.SavedSearchResult, error)
+	GetSavedSearchResults(ctx context.Context, userID int, searchUUID string, limit int) ([]*models.SavedSearchResult, error)
+}
+
+// DefaultSavedSearchService es la implementación predeterminada de SavedSearchService.
+// También mantiene el planificador cron que ejecuta las búsquedas programadas.
+type DefaultSavedSearchService struct {
+	repo       repository.SavedSearchRepository
+	enrichRepo repository.EnrichmentRepository
+
+	cron    *cron.Cron
+	mu      sync.Mutex
+	entries map[int]cron.EntryID
+}
+
+// NewDefaultSavedSearchService crea una nueva instancia de DefaultSavedSearchService.
+func NewDefaultSavedSearchService(repo repository.SavedSearchRepository, enrichRepo repository.EnrichmentRepository) *DefaultSavedSearchService {
+	return &DefaultSavedSearchService{
+		repo:       repo,
+		enrichRepo: enrichRepo,
+		cron:       cron.New(),
+		entries:    make(map[int]cron.EntryID),
+	}
+}
+
+// validateSavedSearchPayload valida los campos obligatorios y la expresión cron.
+func validateSavedSearchPayload(payload *models.SavedSearchPayload) error {
+	if payload.Name == "" {
+		return fmt.Errorf("%w: el nombre es requerido", ErrInvalidSavedSearch)
+	}
+	if payload.Schedule != "" {
+		if _, err := cron.ParseStandard(payload.Schedule); err != nil {
+			return fmt.Errorf("%w: programación cron inválida: %v", ErrInvalidSavedSearch, err)
+		}
+	}
+	return nil
+}
+
+// CreateSavedSearch crea una búsqueda guardada para el usuario y la programa si corresponde.
+func (s *DefaultSavedSearchService) CreateSavedSearch(ctx context.Context, userID int, payload *models.SavedSearchPayload) (*models.SavedSearch, error) {
+	if err := validateSavedSearchPayload(payload); err != nil {
+		return nil, err
+	}
+
+	search := &models.SavedSearch{
+		UUID:        uuid.NewString(),
+		UserID:      userID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Filter:      payload.Filter,
+		Schedule:    payload.Schedule,
+	}
+
+	if err := s.repo.InsertSavedSearch(ctx, search); err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al crear búsqueda guardada: %v", err)
+		return nil, fmt.Errorf("error al crear búsqueda guardada: %w", err)
+	}
+
+	if err := s.schedule(search); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Búsqueda guardada %s creada para el usuario %d.", search.UUID, userID)
+	return search, nil
+}
+
+// ListSavedSearches devuelve las búsquedas guardadas del usuario.
+func (s *DefaultSavedSearchService) ListSavedSearches(ctx context.Context, userID int) ([]*models.SavedSearch, error) {
+	searches, err := s.repo.GetSavedSearchesByUserID(ctx, userID)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al listar búsquedas guardadas del usuario %d: %v", userID, err)
+		return nil, fmt.Errorf("error al listar búsquedas guardadas: %w", err)
+	}
+	return searches, nil
+}
+
+// GetSavedSearch devuelve una búsqueda guardada siempre que pertenezca al usuario.
+func (s *DefaultSavedSearchService) GetSavedSearch(ctx context.Context, userID int, searchUUID string) (*models.SavedSearch, error) {
+	search, err := s.repo.GetSavedSearchByUUID(ctx, searchUUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSavedSearchNotFound
+		}
+		return nil, fmt.Errorf("error al obtener búsqueda guardada: %w", err)
+	}
+	if search.UserID != userID {
+		logger.InfoLog.Printf("El usuario %d intentó acceder a la búsqueda guardada %s de otro usuario.", userID, searchUUID)
+		return nil, ErrSavedSearchNotFound
+	}
+	return search, nil
+}
+
+// UpdateSavedSearch reemplaza los datos de una búsqueda guardada y actualiza su programación.
+func (s *DefaultSavedSearchService) UpdateSavedSearch(ctx context.Context, userID int, searchUUID string, payload *models.SavedSearchPayload) (*models.SavedSearch, error) {
+	if err := validateSavedSearchPayload(payload); err != nil {
+		return nil, err
+	}
+
+	search, err := s.GetSavedSearch(ctx, userID, searchUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	search.Name = payload.Name
+	search.Description = payload.Description
+	search.Filter = payload.Filter
+	search.Schedule = payload.Schedule
+
+	if err := s.repo.UpdateSavedSearch(ctx, search); err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al actualizar búsqueda guardada %s: %v", searchUUID, err)
+		return nil, fmt.Errorf("error al actualizar búsqueda guardada: %w", err)
+	}
+
+	if err := s.schedule(search); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// DeleteSavedSearch elimina una búsqueda guardada y cancela su programación.
+func (s *DefaultSavedSearchService) DeleteSavedSearch(ctx context.Context, userID int, searchUUID string) error {
+	search, err := s.GetSavedSearch(ctx, userID, searchUUID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSavedSearch(ctx, search.ID); err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al eliminar búsqueda guardada %s: %v", searchUUID, err)
+		return fmt.Errorf("error al eliminar búsqueda guardada: %w", err)
+	}
+
+	s.unschedule(search.ID)
+	return nil
+}
+
+// RunSavedSearch ejecuta inmediatamente una búsqueda guardada y almacena su instantánea.
+func (s *DefaultSavedSearchService) RunSavedSearch(ctx context.Context, userID int, searchUUID string) (*models.SavedSearchResult, error) {
+	search, err := s.GetSavedSearch(ctx, userID, searchUUID)
+	if err != nil {
+		return nil, err
+	}
+	return s.execute(ctx, search)
+}
+
+// GetSavedSearchResults devuelve las últimas instantáneas de una búsqueda guardada.
+func (s *DefaultSavedSearchService) GetSavedSearchResults(ctx context.Context, userID int, searchUUID string, limit int) ([]*models.SavedSearchResult, error) {
+	search, err := s.GetSavedSearch(ctx, userID, searchUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	results, err := s.repo.GetSavedSearchResults(ctx, search.ID, limit)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al obtener resultados de la búsqueda guardada %s: %v", searchUUID, err)
+		return nil, fmt.Errorf("error al obtener resultados de búsqueda guardada: %w", err)
+	}
+	return results, nil
+}
+
+// execute corre el filtro de la búsqueda contra el repositorio de eventos, calcula la
+// diferencia respecto a la instantánea anterior y persiste el nuevo resultado.
+func (s *DefaultSavedSearchService) execute(ctx context.Context, search *models.SavedSearch) (*models.SavedSearchResult, error) {
+	filter := search.Filter
+	if filter.Limit == 0 {
+		filter.Limit = maxSnapshotSize
+	}
+
+	records, err := s.enrichRepo.SearchLogs(ctx, &filter)
+	if err != nil {
+		logger.ErrorLog.Printf("Error al ejecutar la búsqueda guardada %s: %v", search.UUID, err)
+		return nil, fmt.Errorf("error al ejecutar búsqueda guardada: %w", err)
+	}
+
+	eventIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		eventIDs = append(eventIDs, record.ID.Hex())
+	}
+
+	var previousIDs []string
+	previous, err := s.repo.GetLatestSavedSearchResult(ctx, search.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error al obtener la instantánea anterior: %w", err)
+	}
+	if previous != nil {
+		previousIDs = previous.EventIDs
+	}
+
+	result := &models.SavedSearchResult{
+		SavedSearchID: search.ID,
+		RunAt:         time.Now(),
+		Total:         len(eventIDs),
+		EventIDs:      eventIDs,
+		Added:         diffIDs(eventIDs, previousIDs),
+		Removed:       diffIDs(previousIDs, eventIDs),
+	}
+
+	if err := s.repo.InsertSavedSearchResult(ctx, result); err != nil {
+		return nil, fmt.Errorf("error al guardar la instantánea: %w", err)
+	}
+	if err := s.repo.UpdateSavedSearchLastRun(ctx, search.ID, result.RunAt); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Búsqueda guardada %s ejecutada: %d eventos, %d nuevos, %d eliminados.", search.UUID, result.Total, len(result.Added), len(result.Removed))
+	return result, nil
+}
+
+// diffIDs devuelve los elementos de a que no están en b, conservando el orden de a.
+func diffIDs(a, b []string) []string {
+	seen := make(map[string]struct{}, len(b))
+	for _, id := range b {
+		seen[id] = struct{}{}
+	}
+	diff := []string{}
+	for _, id := range a {
+		if _, ok := seen[id]; !ok {
+			diff = append(diff, id)
+		}
+	}
+	return diff
+}
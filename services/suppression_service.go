@@ -0,0 +1,113 @@
+package services
+
+import (
+	"cloudtrail-enrichment-api-golang/internal/pkg/logger"
+	"cloudtrail-enrichment-api-golang/internal/repository"
+	"cloudtrail-enrichment-api-golang/models"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrSuppressionNotFound se devuelve cuando la supresión no existe o ya venció.
+	ErrSuppressionNotFound = errors.New("supresión no encontrada o vencida")
+	// ErrInvalidSuppression se devuelve cuando el payload de la supresión no es válido.
+	ErrInvalidSuppression = errors.New("supresión inválida")
+)
+
+// maxSuppressionDuration limita la vigencia de las supresiones creadas por analistas.
+const maxSuppressionDuration = 30 * 24 * time.Hour
+
+// SuppressionService define la interfaz para las supresiones temporales de detecciones.
+type SuppressionService interface {
+	CreateSuppression(ctx context.Context, actor models.FindingActor, payload *models.SuppressionPayload) (*models.Suppression, error)
+	ListSuppressions(ctx context.Context, activeOnly bool) ([]*models.Suppression, error)
+	ExpireSuppression(ctx context.Context, id string) (*models.Suppression, error)
+}
+
+// DefaultSuppressionService es la implementación predeterminada de SuppressionService.
+type DefaultSuppressionService struct {
+	repo repository.SuppressionRepository
+}
+
+// NewDefaultSuppressionService crea una nueva instancia de DefaultSuppressionService.
+func NewDefaultSuppressionService(repo repository.SuppressionRepository) *DefaultSuppressionService {
+	return &DefaultSuppressionService{
+		repo: repo,
+	}
+}
+
+// CreateSuppression crea una supresión vigente durante payload.Duration. Se exige una regla
+// o al menos una condición para no silenciar todas las detecciones por error.
+func (s *DefaultSuppressionService) CreateSuppression(ctx context.Context, actor models.FindingActor, payload *models.SuppressionPayload) (*models.Suppression, error) {
+	if strings.TrimSpace(payload.Reason) == "" {
+		return nil, fmt.Errorf("%w: el motivo es requerido", ErrInvalidSuppression)
+	}
+	if payload.RuleID == "" && len(payload.Conditions) == 0 {
+		return nil, fmt.Errorf("%w: se requiere rule_id o al menos una condición", ErrInvalidSuppression)
+	}
+	for _, condition := range payload.Conditions {
+		if condition.Field == "" || !projectionFieldPattern.MatchString(condition.Field) {
+			return nil, fmt.Errorf("%w: campo de condición '%s' no válido", ErrInvalidSuppression, condition.Field)
+		}
+	}
+	duration, err := time.ParseDuration(payload.Duration)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("%w: duración '%s' no válida", ErrInvalidSuppression, payload.Duration)
+	}
+	if duration > maxSuppressionDuration {
+		return nil, fmt.Errorf("%w: la duración máxima es %s", ErrInvalidSuppression, maxSuppressionDuration)
+	}
+
+	now := time.Now()
+	suppression := &models.Suppression{
+		RuleID:     payload.RuleID,
+		Conditions: payload.Conditions,
+		Reason:     strings.TrimSpace(payload.Reason),
+		CreatedBy:  actor,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(duration),
+	}
+	if err := s.repo.InsertSuppression(ctx, suppression); err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al crear supresión: %v", err)
+		return nil, fmt.Errorf("error al crear supresión: %w", err)
+	}
+	logger.InfoLog.Printf("Supresión %s creada por %s hasta %s.", suppression.ID.Hex(), actor.Email, suppression.ExpiresAt.Format(time.RFC3339))
+	return suppression, nil
+}
+
+// ListSuppressions lista las supresiones; con activeOnly solo las vigentes.
+func (s *DefaultSuppressionService) ListSuppressions(ctx context.Context, activeOnly bool) ([]*models.Suppression, error) {
+	var activeAt *time.Time
+	if activeOnly {
+		now := time.Now()
+		activeAt = &now
+	}
+	suppressions, err := s.repo.ListSuppressions(ctx, activeAt)
+	if err != nil {
+		logger.ErrorLog.Printf("Error en el servicio al listar supresiones: %v", err)
+		return nil, fmt.Errorf("error al listar supresiones: %w", err)
+	}
+	return suppressions, nil
+}
+
+// ExpireSuppression levanta una supresión vigente; el registro se conserva para auditoría.
+func (s *DefaultSuppressionService) ExpireSuppression(ctx context.Context, id string) (*models.Suppression, error) {
+	if !validFindingID(id) {
+		return nil, ErrSuppressionNotFound
+	}
+	suppression, err := s.repo.ExpireSuppression(ctx, id, time.Now())
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSuppressionNotFound
+		}
+		logger.ErrorLog.Printf("Error en el servicio al vencer la supresión %s: %v", id, err)
+		return nil, fmt.Errorf("error al vencer la supresión: %w", err)
+	}
+	return suppression, nil
+}